@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"strings"
 )
@@ -176,13 +177,13 @@ func NewAccountAPI(client *Client) *AccountAPI {
 	return &AccountAPI{client: client}
 }
 
-func (a *AccountAPI) Info() (*AccountInfo, error) {
+func (a *AccountAPI) Info(ctx context.Context) (*AccountInfo, error) {
 	var info AccountInfo
-	err := a.client.Get("/v4/organization/information/outseta", nil, &info)
+	err := a.client.Get(ctx, "/v4/organization/information/outseta", nil, &info)
 	if info.UsageLimits != nil {
 		info.Subscription = info.UsageLimits
 		if info.Subscription.APIKeysLimit == 0 {
-			if sub, subErr := a.Subscription(); subErr == nil && sub != nil {
+			if sub, subErr := a.Subscription(ctx); subErr == nil && sub != nil {
 				info.Subscription.APIKeysLimit = sub.APIKeysLimit
 				if info.Subscription.PlanName == "" {
 					info.Subscription.PlanName = sub.EffectivePlanName()
@@ -193,14 +194,14 @@ func (a *AccountAPI) Info() (*AccountInfo, error) {
 	return &info, err
 }
 
-func (a *AccountAPI) Subscription() (*SubscriptionInfo, error) {
+func (a *AccountAPI) Subscription(ctx context.Context) (*SubscriptionInfo, error) {
 	var sub SubscriptionInfo
-	err := a.client.Get("/v3/user/subscription", nil, &sub)
+	err := a.client.Get(ctx, "/v3/user/subscription", nil, &sub)
 	return &sub, err
 }
 
-func (a *AccountAPI) Discount() (*DiscountInfo, error) {
+func (a *AccountAPI) Discount(ctx context.Context) (*DiscountInfo, error) {
 	var discount DiscountInfo
-	err := a.client.Get("/v4/organization/information/outseta/discount", nil, &discount)
+	err := a.client.Get(ctx, "/v4/organization/information/outseta/discount", nil, &discount)
 	return &discount, err
 }
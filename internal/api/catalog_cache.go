@@ -0,0 +1,178 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const chainsPath = "/v3/chains"
+
+// ErrCatalogNotCached is returned in offline mode when no cached catalog exists.
+var ErrCatalogNotCached = errors.New("the chain catalog has not been cached yet")
+
+// CatalogCacheMode controls how the catalog cache is consulted.
+type CatalogCacheMode int
+
+const (
+	// CatalogCacheDefault serves the cache within its TTL and revalidates it afterwards.
+	CatalogCacheDefault CatalogCacheMode = iota
+	// CatalogCacheRefresh always fetches a fresh catalog and rewrites the cache.
+	CatalogCacheRefresh
+	// CatalogCacheOffline serves the cache regardless of age and never touches the network.
+	CatalogCacheOffline
+)
+
+// Catalog sources reported in CatalogStatus.
+const (
+	CatalogFromNetwork     = "network"
+	CatalogFromCache       = "cache"
+	CatalogFromRevalidated = "revalidated"
+	CatalogFromStale       = "stale"
+)
+
+// CatalogStatus describes where a catalog listing came from.
+type CatalogStatus struct {
+	Source    string
+	FetchedAt time.Time
+	Age       time.Duration
+}
+
+// CatalogCache stores the /v3/chains response on disk so that list, search,
+// and get do not need a network round trip on every invocation.
+type CatalogCache struct {
+	Path string
+	TTL  time.Duration
+	Mode CatalogCacheMode
+	// Now overrides the clock in tests.
+	Now func() time.Time
+}
+
+type catalogCacheFile struct {
+	BaseURL   string    `json:"base_url"`
+	FetchedAt time.Time `json:"fetched_at"`
+	Validators
+	Chains []Chain `json:"chains"`
+}
+
+// NewCatalogCache returns a cache stored under configDir/cache.
+func NewCatalogCache(configDir string, ttl time.Duration, mode CatalogCacheMode) *CatalogCache {
+	return &CatalogCache{
+		Path: filepath.Join(configDir, "cache", "chains.json"),
+		TTL:  ttl,
+		Mode: mode,
+	}
+}
+
+// List returns the catalog for client, using and maintaining the cache
+// according to the cache mode.
+func (c *CatalogCache) List(ctx context.Context, client *Client) ([]Chain, CatalogStatus, error) {
+	now := c.now()
+	cached := c.read(client.BaseURL())
+
+	if c.Mode == CatalogCacheOffline {
+		if cached == nil {
+			return nil, CatalogStatus{}, ErrCatalogNotCached
+		}
+		return cached.Chains, c.status(CatalogFromCache, cached, now), nil
+	}
+	if c.Mode == CatalogCacheDefault && cached != nil && now.Sub(cached.FetchedAt) < c.TTL {
+		return cached.Chains, c.status(CatalogFromCache, cached, now), nil
+	}
+
+	var prev Validators
+	if c.Mode == CatalogCacheDefault && cached != nil {
+		prev = cached.Validators
+	}
+	var chains []Chain
+	next, notModified, err := client.GetConditional(ctx, chainsPath, prev, &chains)
+	if err != nil {
+		if cached != nil && c.Mode == CatalogCacheDefault && ctx.Err() == nil && isUnavailable(err) {
+			return cached.Chains, c.status(CatalogFromStale, cached, now), nil
+		}
+		return nil, CatalogStatus{}, err
+	}
+
+	source := CatalogFromNetwork
+	if notModified {
+		source = CatalogFromRevalidated
+		chains = cached.Chains
+	}
+	entry := &catalogCacheFile{
+		BaseURL:    client.BaseURL(),
+		FetchedAt:  now,
+		Validators: next,
+		Chains:     chains,
+	}
+	// The cache is an optimisation; failing to write it must not fail the command.
+	_ = c.write(entry)
+	return chains, c.status(source, entry, now), nil
+}
+
+func (c *CatalogCache) now() time.Time {
+	if c.Now != nil {
+		return c.Now()
+	}
+	return time.Now()
+}
+
+func (c *CatalogCache) status(source string, entry *catalogCacheFile, now time.Time) CatalogStatus {
+	age := now.Sub(entry.FetchedAt)
+	if age < 0 {
+		age = 0
+	}
+	return CatalogStatus{Source: source, FetchedAt: entry.FetchedAt, Age: age}
+}
+
+// read loads the cache file, treating unreadable files and catalogs fetched
+// from a different API as missing.
+func (c *CatalogCache) read(baseURL string) *catalogCacheFile {
+	data, err := os.ReadFile(c.Path)
+	if err != nil {
+		return nil
+	}
+	var entry catalogCacheFile
+	if err := json.Unmarshal(data, &entry); err != nil || entry.BaseURL != baseURL || entry.FetchedAt.IsZero() {
+		return nil
+	}
+	return &entry
+}
+
+func (c *CatalogCache) write(entry *catalogCacheFile) error {
+	if err := os.MkdirAll(filepath.Dir(c.Path), 0o700); err != nil {
+		return fmt.Errorf("creating cache dir: %w", err)
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("marshaling catalog cache: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(c.Path), ".chains-*.json")
+	if err != nil {
+		return fmt.Errorf("writing catalog cache: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("writing catalog cache: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("writing catalog cache: %w", err)
+	}
+	return os.Rename(tmp.Name(), c.Path)
+}
+
+// isUnavailable reports whether err means the API could not serve the request
+// (network failure or server error), as opposed to a client-side problem such
+// as expired credentials.
+func isUnavailable(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode >= 500
+	}
+	return true
+}
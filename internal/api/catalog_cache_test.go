@@ -0,0 +1,129 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const catalogBody = `[{"id": 1, "name": "Ethereum", "ecosystem": "evm", "networks": [{"id": 1, "name": "Mainnet", "nodes": []}]}]`
+
+// newCatalogServer serves /v3/chains with an ETag and honours If-None-Match.
+// Setting down makes it answer 503.
+func newCatalogServer(t *testing.T, hits *atomic.Int64, down *atomic.Bool) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		if down.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte(catalogBody))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestCatalogCacheTTLAndRevalidation(t *testing.T) {
+	var hits atomic.Int64
+	var down atomic.Bool
+	server := newCatalogServer(t, &hits, &down)
+	client := NewClient(server.URL, "token")
+	client.Retry = RetryPolicy{MaxAttempts: 1}
+
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	cache := NewCatalogCache(t.TempDir(), time.Hour, CatalogCacheDefault)
+	cache.Now = func() time.Time { return now }
+
+	chains, status, err := cache.List(context.Background(), client)
+	if err != nil || len(chains) != 1 || status.Source != CatalogFromNetwork {
+		t.Fatalf("first list: chains=%v status=%+v err=%v", chains, status, err)
+	}
+
+	now = now.Add(30 * time.Minute)
+	chains, status, err = cache.List(context.Background(), client)
+	if err != nil || len(chains) != 1 || status.Source != CatalogFromCache || status.Age != 30*time.Minute {
+		t.Fatalf("cached list: chains=%v status=%+v err=%v", chains, status, err)
+	}
+	if hits.Load() != 1 {
+		t.Fatalf("expected cache hit without a request, got %d requests", hits.Load())
+	}
+
+	now = now.Add(time.Hour)
+	chains, status, err = cache.List(context.Background(), client)
+	if err != nil || len(chains) != 1 || status.Source != CatalogFromRevalidated || status.Age != 0 {
+		t.Fatalf("revalidated list: chains=%v status=%+v err=%v", chains, status, err)
+	}
+
+	now = now.Add(2 * time.Hour)
+	down.Store(true)
+	chains, status, err = cache.List(context.Background(), client)
+	if err != nil || len(chains) != 1 || status.Source != CatalogFromStale || status.Age != 2*time.Hour {
+		t.Fatalf("stale list: chains=%v status=%+v err=%v", chains, status, err)
+	}
+
+	cache.Mode = CatalogCacheRefresh
+	if _, _, err := cache.List(context.Background(), client); err == nil {
+		t.Fatal("expected --refresh to surface the API error instead of the stale cache")
+	}
+}
+
+func TestCatalogCacheOffline(t *testing.T) {
+	var hits atomic.Int64
+	var down atomic.Bool
+	server := newCatalogServer(t, &hits, &down)
+	client := NewClient(server.URL, "token")
+	dir := t.TempDir()
+
+	offline := NewCatalogCache(dir, time.Hour, CatalogCacheOffline)
+	if _, _, err := offline.List(context.Background(), client); !errors.Is(err, ErrCatalogNotCached) {
+		t.Fatalf("expected ErrCatalogNotCached, got %v", err)
+	}
+
+	if _, _, err := NewCatalogCache(dir, time.Hour, CatalogCacheRefresh).List(context.Background(), client); err != nil {
+		t.Fatalf("refresh: %v", err)
+	}
+	offline.Now = func() time.Time { return time.Now().Add(48 * time.Hour) }
+	chains, status, err := offline.List(context.Background(), client)
+	if err != nil || len(chains) != 1 || status.Source != CatalogFromCache {
+		t.Fatalf("offline list: chains=%v status=%+v err=%v", chains, status, err)
+	}
+	if hits.Load() != 1 {
+		t.Fatalf("offline mode made requests: %d", hits.Load())
+	}
+
+	// A catalog cached for another API is not served.
+	other := NewClient("http://other.invalid", "token")
+	if _, _, err := offline.List(context.Background(), other); !errors.Is(err, ErrCatalogNotCached) {
+		t.Fatalf("expected base URL mismatch to miss, got %v", err)
+	}
+}
+
+func TestEndpointsListReportsCatalogStatus(t *testing.T) {
+	var hits atomic.Int64
+	var down atomic.Bool
+	server := newCatalogServer(t, &hits, &down)
+
+	ep := NewEndpointsAPI(NewClient(server.URL, "token"))
+	ep.Cache = NewCatalogCache(t.TempDir(), time.Hour, CatalogCacheDefault)
+	var sources []string
+	ep.OnCatalog = func(status CatalogStatus) { sources = append(sources, status.Source) }
+
+	for i := 0; i < 2; i++ {
+		if _, err := ep.Get(context.Background(), "ethereum", "", "", "", ""); err != nil {
+			t.Fatalf("get: %v", err)
+		}
+	}
+	if len(sources) != 2 || sources[0] != CatalogFromNetwork || sources[1] != CatalogFromCache {
+		t.Fatalf("unexpected catalog sources: %v", sources)
+	}
+}
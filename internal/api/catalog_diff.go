@@ -0,0 +1,264 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Catalog change kinds and levels reported by DiffCatalogs.
+const (
+	ChangeAdded   = "added"
+	ChangeRemoved = "removed"
+	ChangeChanged = "changed"
+
+	LevelChain   = "chain"
+	LevelNetwork = "network"
+	LevelNode    = "node"
+)
+
+// CatalogSnapshot is a point-in-time copy of the chain catalog.
+type CatalogSnapshot struct {
+	TakenAt time.Time `json:"taken_at"`
+	Chains  []Chain   `json:"chains"`
+}
+
+// CatalogChange is one difference between two catalogs. Field, Old, and New
+// are set for changes to an existing chain, network, or node.
+type CatalogChange struct {
+	Kind     string `json:"kind"`
+	Level    string `json:"level"`
+	Chain    string `json:"chain"`
+	Network  string `json:"network,omitempty"`
+	NodeType string `json:"node_type,omitempty"`
+	Field    string `json:"field,omitempty"`
+	Old      string `json:"old,omitempty"`
+	New      string `json:"new,omitempty"`
+}
+
+// CatalogDiff summarizes the changes from one catalog snapshot to another.
+type CatalogDiff struct {
+	From     string          `json:"from"`
+	To       string          `json:"to"`
+	Baseline bool            `json:"baseline,omitempty"`
+	Added    int             `json:"added"`
+	Removed  int             `json:"removed"`
+	Changed  int             `json:"changed"`
+	Changes  []CatalogChange `json:"changes"`
+}
+
+// ReadCatalogSnapshot loads a snapshot file. Besides the snapshot format it
+// accepts a bare chain array and the JSON envelope of 'endpoints list --json'.
+func ReadCatalogSnapshot(path string) (CatalogSnapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return CatalogSnapshot{}, err
+	}
+
+	var snapshot CatalogSnapshot
+	if err := json.Unmarshal(data, &snapshot.Chains); err == nil {
+		return snapshot, nil
+	}
+	var raw struct {
+		TakenAt time.Time `json:"taken_at"`
+		Chains  []Chain   `json:"chains"`
+		Data    []Chain   `json:"data"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return CatalogSnapshot{}, fmt.Errorf("parsing snapshot %s: %w", path, err)
+	}
+	if raw.Chains == nil && raw.Data == nil {
+		return CatalogSnapshot{}, fmt.Errorf("parsing snapshot %s: no chains found", path)
+	}
+	snapshot.TakenAt = raw.TakenAt
+	snapshot.Chains = raw.Chains
+	if snapshot.Chains == nil {
+		snapshot.Chains = raw.Data
+	}
+	return snapshot, nil
+}
+
+// WriteCatalogSnapshot stores snapshot at path, creating parent directories.
+func WriteCatalogSnapshot(path string, snapshot CatalogSnapshot) error {
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			return fmt.Errorf("creating snapshot dir: %w", err)
+		}
+	}
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling snapshot: %w", err)
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+// DiffCatalogs reports chains, networks, and nodes added to, removed from, or
+// changed between old and new. Entries are matched by ID, falling back to
+// their names when the catalog carries no IDs.
+func DiffCatalogs(old, new []Chain) []CatalogChange {
+	var changes []CatalogChange
+	oldChains, newChains := indexChains(old), indexChains(new)
+
+	for _, key := range unionKeys(oldChains, newChains) {
+		before, hadBefore := oldChains[key]
+		after, hasAfter := newChains[key]
+		switch {
+		case !hadBefore:
+			changes = append(changes, CatalogChange{Kind: ChangeAdded, Level: LevelChain, Chain: after.Name})
+		case !hasAfter:
+			changes = append(changes, CatalogChange{Kind: ChangeRemoved, Level: LevelChain, Chain: before.Name})
+		default:
+			changes = append(changes, diffChain(before, after)...)
+		}
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		if changes[i].Chain != changes[j].Chain {
+			return changes[i].Chain < changes[j].Chain
+		}
+		return changes[i].Network < changes[j].Network
+	})
+	return changes
+}
+
+// NewCatalogDiff wraps changes with their totals.
+func NewCatalogDiff(from, to string, changes []CatalogChange) CatalogDiff {
+	diff := CatalogDiff{From: from, To: to, Changes: changes}
+	if diff.Changes == nil {
+		diff.Changes = []CatalogChange{}
+	}
+	for _, change := range changes {
+		switch change.Kind {
+		case ChangeAdded:
+			diff.Added++
+		case ChangeRemoved:
+			diff.Removed++
+		case ChangeChanged:
+			diff.Changed++
+		}
+	}
+	return diff
+}
+
+func diffChain(before, after Chain) []CatalogChange {
+	var changes []CatalogChange
+	changed := func(field, old, new string) {
+		if old != new {
+			changes = append(changes, CatalogChange{Kind: ChangeChanged, Level: LevelChain, Chain: after.Name, Field: field, Old: old, New: new})
+		}
+	}
+	changed("name", before.Name, after.Name)
+	changed("ecosystem", before.Ecosystem, after.Ecosystem)
+
+	oldNetworks, newNetworks := indexNetworks(before.Networks), indexNetworks(after.Networks)
+	for _, key := range unionKeys(oldNetworks, newNetworks) {
+		oldNet, hadBefore := oldNetworks[key]
+		newNet, hasAfter := newNetworks[key]
+		switch {
+		case !hadBefore:
+			changes = append(changes, CatalogChange{Kind: ChangeAdded, Level: LevelNetwork, Chain: after.Name, Network: newNet.Name})
+		case !hasAfter:
+			changes = append(changes, CatalogChange{Kind: ChangeRemoved, Level: LevelNetwork, Chain: after.Name, Network: oldNet.Name})
+		default:
+			changes = append(changes, diffNetwork(after.Name, oldNet, newNet)...)
+		}
+	}
+	return changes
+}
+
+func diffNetwork(chain string, before, after Network) []CatalogChange {
+	var changes []CatalogChange
+	if before.Name != after.Name {
+		changes = append(changes, CatalogChange{Kind: ChangeChanged, Level: LevelNetwork, Chain: chain, Network: after.Name, Field: "name", Old: before.Name, New: after.Name})
+	}
+
+	oldNodes, newNodes := indexNodes(before.Nodes), indexNodes(after.Nodes)
+	for _, key := range unionKeys(oldNodes, newNodes) {
+		oldNode, hadBefore := oldNodes[key]
+		newNode, hasAfter := newNodes[key]
+		switch {
+		case !hadBefore:
+			changes = append(changes, CatalogChange{Kind: ChangeAdded, Level: LevelNode, Chain: chain, Network: after.Name, NodeType: newNode.NodeType.Name, New: nodeURL(newNode)})
+		case !hasAfter:
+			changes = append(changes, CatalogChange{Kind: ChangeRemoved, Level: LevelNode, Chain: chain, Network: after.Name, NodeType: oldNode.NodeType.Name, Old: nodeURL(oldNode)})
+		default:
+			for _, field := range []struct{ name, old, new string }{
+				{"node_type", oldNode.NodeType.Name, newNode.NodeType.Name},
+				{"https", oldNode.HTTPS, newNode.HTTPS},
+				{"wss", oldNode.WSS, newNode.WSS},
+				{"premium", strconv.FormatBool(oldNode.Premium), strconv.FormatBool(newNode.Premium)},
+				{"premium_status", oldNode.PremiumStatus, newNode.PremiumStatus},
+			} {
+				if field.old != field.new {
+					changes = append(changes, CatalogChange{
+						Kind:     ChangeChanged,
+						Level:    LevelNode,
+						Chain:    chain,
+						Network:  after.Name,
+						NodeType: newNode.NodeType.Name,
+						Field:    field.name,
+						Old:      field.old,
+						New:      field.new,
+					})
+				}
+			}
+		}
+	}
+	return changes
+}
+
+func nodeURL(node Node) string {
+	if node.HTTPS != "" {
+		return node.HTTPS
+	}
+	return node.WSS
+}
+
+func indexChains(chains []Chain) map[string]Chain {
+	index := make(map[string]Chain, len(chains))
+	for _, chain := range chains {
+		index[catalogKey(chain.ID, ChainSlug(chain.Name))] = chain
+	}
+	return index
+}
+
+func indexNetworks(networks []Network) map[string]Network {
+	index := make(map[string]Network, len(networks))
+	for _, net := range networks {
+		index[catalogKey(net.ID, ChainSlug(net.Name))] = net
+	}
+	return index
+}
+
+func indexNodes(nodes []Node) map[string]Node {
+	index := make(map[string]Node, len(nodes))
+	for _, node := range nodes {
+		index[catalogKey(node.ID, node.NodeType.Name+" "+nodeURL(node))] = node
+	}
+	return index
+}
+
+func catalogKey(id int, fallback string) string {
+	if id != 0 {
+		return "#" + strconv.Itoa(id)
+	}
+	return fallback
+}
+
+// unionKeys returns the keys of both maps in a stable order.
+func unionKeys[V any](a, b map[string]V) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for key := range a {
+		keys = append(keys, key)
+	}
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
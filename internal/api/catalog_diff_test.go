@@ -0,0 +1,94 @@
+package api
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDiffCatalogs(t *testing.T) {
+	old := []Chain{
+		{ID: 1, Name: "Ethereum", Ecosystem: "evm", Networks: []Network{
+			{ID: 10, Name: "Mainnet", Nodes: []Node{
+				{ID: 100, HTTPS: "https://eth/<key>", NodeType: NodeType{Name: "full"}},
+				{ID: 101, HTTPS: "https://eth-archive/<key>", NodeType: NodeType{Name: "archive"}},
+			}},
+			{ID: 11, Name: "Goerli", Nodes: []Node{{ID: 110, HTTPS: "https://goerli/<key>", NodeType: NodeType{Name: "full"}}}},
+		}},
+		{ID: 2, Name: "Fantom", Ecosystem: "evm"},
+	}
+	new := []Chain{
+		{ID: 1, Name: "Ethereum", Ecosystem: "evm", Networks: []Network{
+			{ID: 10, Name: "Mainnet", Nodes: []Node{
+				{ID: 100, HTTPS: "https://eth/<key>", WSS: "wss://eth/<key>", NodeType: NodeType{Name: "full"}},
+				{ID: 101, HTTPS: "https://eth-archive/<key>", NodeType: NodeType{Name: "archive"}, Premium: true, PremiumStatus: "locked"},
+			}},
+			{ID: 12, Name: "Hoodi", Nodes: []Node{{ID: 120, HTTPS: "https://hoodi/<key>", NodeType: NodeType{Name: "full"}}}},
+		}},
+		{ID: 3, Name: "Base", Ecosystem: "evm"},
+	}
+
+	got := DiffCatalogs(old, new)
+	want := []CatalogChange{
+		{Kind: ChangeAdded, Level: LevelChain, Chain: "Base"},
+		{Kind: ChangeRemoved, Level: LevelNetwork, Chain: "Ethereum", Network: "Goerli"},
+		{Kind: ChangeAdded, Level: LevelNetwork, Chain: "Ethereum", Network: "Hoodi"},
+		{Kind: ChangeChanged, Level: LevelNode, Chain: "Ethereum", Network: "Mainnet", NodeType: "full", Field: "wss", New: "wss://eth/<key>"},
+		{Kind: ChangeChanged, Level: LevelNode, Chain: "Ethereum", Network: "Mainnet", NodeType: "archive", Field: "premium", Old: "false", New: "true"},
+		{Kind: ChangeChanged, Level: LevelNode, Chain: "Ethereum", Network: "Mainnet", NodeType: "archive", Field: "premium_status", New: "locked"},
+		{Kind: ChangeRemoved, Level: LevelChain, Chain: "Fantom"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected changes:\n got %+v\nwant %+v", got, want)
+	}
+
+	diff := NewCatalogDiff("a", "b", got)
+	if diff.Added != 2 || diff.Removed != 2 || diff.Changed != 3 {
+		t.Fatalf("unexpected totals: %+v", diff)
+	}
+	if changes := DiffCatalogs(new, new); len(changes) != 0 {
+		t.Fatalf("expected no changes for identical catalogs, got %+v", changes)
+	}
+}
+
+func TestDiffCatalogsMatchesByNameWithoutIDs(t *testing.T) {
+	old := []Chain{{Name: "Base", Networks: []Network{{Name: "Mainnet", Nodes: []Node{{HTTPS: "https://base/a", NodeType: NodeType{Name: "full"}}}}}}}
+	new := []Chain{{Name: "Base", Networks: []Network{{Name: "Mainnet", Nodes: []Node{{HTTPS: "https://base/b", NodeType: NodeType{Name: "full"}}}}}}}
+
+	got := DiffCatalogs(old, new)
+	if len(got) != 2 || got[0].Level != LevelNode || got[0].Kind == got[1].Kind {
+		t.Fatalf("expected one node removed and one added, got %+v", got)
+	}
+}
+
+func TestReadCatalogSnapshotFormats(t *testing.T) {
+	dir := t.TempDir()
+	takenAt := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	snapshotPath := filepath.Join(dir, "nested", "snapshot.json")
+	if err := WriteCatalogSnapshot(snapshotPath, CatalogSnapshot{TakenAt: takenAt, Chains: []Chain{{Name: "Base"}}}); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	arrayPath := filepath.Join(dir, "array.json")
+	envelopePath := filepath.Join(dir, "envelope.json")
+	_ = os.WriteFile(arrayPath, []byte(`[{"name": "Base"}]`), 0o600)
+	_ = os.WriteFile(envelopePath, []byte(`{"ok": true, "data": [{"name": "Base"}], "meta": {"command": "endpoints.list"}}`), 0o600)
+
+	for _, path := range []string{snapshotPath, arrayPath, envelopePath} {
+		snapshot, err := ReadCatalogSnapshot(path)
+		if err != nil || len(snapshot.Chains) != 1 || snapshot.Chains[0].Name != "Base" {
+			t.Fatalf("%s: snapshot=%+v err=%v", filepath.Base(path), snapshot, err)
+		}
+	}
+	if snapshot, _ := ReadCatalogSnapshot(snapshotPath); !snapshot.TakenAt.Equal(takenAt) {
+		t.Fatalf("expected taken_at to round-trip, got %v", snapshot.TakenAt)
+	}
+
+	badPath := filepath.Join(dir, "bad.json")
+	_ = os.WriteFile(badPath, []byte(`{"ok": false}`), 0o600)
+	if _, err := ReadCatalogSnapshot(badPath); err == nil {
+		t.Fatal("expected an error for a file without chains")
+	}
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -25,7 +26,9 @@ type Client struct {
 	baseURL        string
 	token          string
 	httpClient     *http.Client
+	Retry          RetryPolicy
 	OnTokenRefresh func(newToken string)
+	OnRetry        func(event RetryEvent)
 }
 
 func NewClient(baseURL, token string) *Client {
@@ -35,10 +38,11 @@ func NewClient(baseURL, token string) *Client {
 		httpClient: &http.Client{
 			Timeout: defaultTimeout,
 		},
+		Retry: DefaultRetryPolicy(),
 	}
 }
 
-func (c *Client) Get(path string, params map[string]string, result interface{}) error {
+func (c *Client) Get(ctx context.Context, path string, params map[string]string, result interface{}) error {
 	u, err := url.Parse(c.baseURL + path)
 	if err != nil {
 		return fmt.Errorf("parsing URL: %w", err)
@@ -51,14 +55,24 @@ func (c *Client) Get(path string, params map[string]string, result interface{})
 		u.RawQuery = q.Encode()
 	}
 
-	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
 	if err != nil {
 		return fmt.Errorf("creating request: %w", err)
 	}
-	return c.do(req, result)
+	return c.do(req, true, result)
 }
 
-func (c *Client) Post(path string, body interface{}, result interface{}) error {
+func (c *Client) Post(ctx context.Context, path string, body interface{}, result interface{}) error {
+	return c.post(ctx, path, body, false, result)
+}
+
+// PostIdempotent sends a POST whose effect is safe to repeat (read-only queries,
+// full-replacement updates), making it eligible for retries on transient failures.
+func (c *Client) PostIdempotent(ctx context.Context, path string, body interface{}, result interface{}) error {
+	return c.post(ctx, path, body, true, result)
+}
+
+func (c *Client) post(ctx context.Context, path string, body interface{}, idempotent bool, result interface{}) error {
 	var bodyReader io.Reader
 	if body != nil {
 		data, err := json.Marshal(body)
@@ -68,25 +82,25 @@ func (c *Client) Post(path string, body interface{}, result interface{}) error {
 		bodyReader = bytes.NewReader(data)
 	}
 
-	req, err := http.NewRequest(http.MethodPost, c.baseURL+path, bodyReader)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+path, bodyReader)
 	if err != nil {
 		return fmt.Errorf("creating request: %w", err)
 	}
 	if body != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
-	return c.do(req, result)
+	return c.do(req, idempotent, result)
 }
 
-func (c *Client) Delete(path string, result interface{}) error {
-	req, err := http.NewRequest(http.MethodDelete, c.baseURL+path, nil)
+func (c *Client) Delete(ctx context.Context, path string, result interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, c.baseURL+path, nil)
 	if err != nil {
 		return fmt.Errorf("creating request: %w", err)
 	}
-	return c.do(req, result)
+	return c.do(req, true, result)
 }
 
-func (c *Client) Patch(path string, body interface{}, result interface{}) error {
+func (c *Client) Patch(ctx context.Context, path string, body interface{}, result interface{}) error {
 	var bodyReader io.Reader
 	if body != nil {
 		data, err := json.Marshal(body)
@@ -96,25 +110,116 @@ func (c *Client) Patch(path string, body interface{}, result interface{}) error
 		bodyReader = bytes.NewReader(data)
 	}
 
-	req, err := http.NewRequest(http.MethodPatch, c.baseURL+path, bodyReader)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPatch, c.baseURL+path, bodyReader)
 	if err != nil {
 		return fmt.Errorf("creating request: %w", err)
 	}
 	if body != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
-	return c.do(req, result)
+	return c.do(req, false, result)
+}
+
+func (c *Client) do(req *http.Request, idempotent bool, result interface{}) error {
+	resp, err := c.send(req, idempotent)
+	if err != nil {
+		return err
+	}
+	return c.handleResponse(resp, result)
 }
 
-func (c *Client) do(req *http.Request, result interface{}) error {
+// send executes req with retries and returns the final response unread.
+func (c *Client) send(req *http.Request, idempotent bool) (*http.Response, error) {
 	req.Header.Set("Authorization", "Bearer "+c.token)
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("User-Agent", "dwellir-cli")
 
-	resp, err := c.httpClient.Do(req)
+	idempotent = idempotent || isIdempotentMethod(req.Method)
+	started := time.Now()
+	for attempt := 1; ; attempt++ {
+		resp, err := c.httpClient.Do(req)
+		delay, retry := c.Retry.retryDelay(attempt, time.Since(started), idempotent, resp, err)
+		if !retry || req.Context().Err() != nil {
+			if err != nil {
+				return nil, fmt.Errorf("request failed: %w", err)
+			}
+			return resp, nil
+		}
+
+		event := RetryEvent{
+			Method:  req.Method,
+			Path:    req.URL.Path,
+			Attempt: attempt,
+			Delay:   delay,
+			Err:     err,
+		}
+		if resp != nil {
+			event.StatusCode = resp.StatusCode
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+		if c.OnRetry != nil {
+			c.OnRetry(event)
+		}
+		if err := sleepContext(req.Context(), delay); err != nil {
+			return nil, fmt.Errorf("request failed: %w", err)
+		}
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("rewinding request body: %w", err)
+			}
+			req.Body = body
+		}
+	}
+}
+
+// Validators are the HTTP cache validators of a previously fetched response.
+type Validators struct {
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"last_modified,omitempty"`
+}
+
+// GetConditional is a GET that revalidates a cached response: it sends
+// If-None-Match / If-Modified-Since from prev and reports notModified when the
+// server answers 304, in which case result is left untouched.
+func (c *Client) GetConditional(ctx context.Context, path string, prev Validators, result interface{}) (next Validators, notModified bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return Validators{}, false, fmt.Errorf("creating request: %w", err)
+	}
+	if prev.ETag != "" {
+		req.Header.Set("If-None-Match", prev.ETag)
+	}
+	if prev.LastModified != "" {
+		req.Header.Set("If-Modified-Since", prev.LastModified)
+	}
+
+	resp, err := c.send(req, true)
 	if err != nil {
-		return fmt.Errorf("request failed: %w", err)
+		return Validators{}, false, err
+	}
+	if resp.StatusCode == http.StatusNotModified {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+		return prev, true, nil
+	}
+	next = Validators{
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+	}
+	if err := c.handleResponse(resp, result); err != nil {
+		return Validators{}, false, err
 	}
+	return next, false, nil
+}
+
+// BaseURL returns the API base URL the client talks to.
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+func (c *Client) handleResponse(resp *http.Response, result interface{}) error {
 	defer resp.Body.Close()
 
 	if refreshed := resp.Header.Get("X-Dwellir-Refreshed-Token"); refreshed != "" && c.OnTokenRefresh != nil {
@@ -1,10 +1,13 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 )
 
 func TestClientGet(t *testing.T) {
@@ -23,7 +26,7 @@ func TestClientGet(t *testing.T) {
 
 	client := NewClient(server.URL, "test-token")
 	var result []map[string]string
-	err := client.Get("/v3/chains", nil, &result)
+	err := client.Get(context.Background(), "/v3/chains", nil, &result)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -45,7 +48,7 @@ func TestClientPost(t *testing.T) {
 
 	client := NewClient(server.URL, "test-token")
 	var result map[string]bool
-	err := client.Post("/v4/organization/analytics", map[string]string{"interval": "day"}, &result)
+	err := client.Post(context.Background(), "/v4/organization/analytics", map[string]string{"interval": "day"}, &result)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -65,7 +68,7 @@ func TestClientUnauthorized(t *testing.T) {
 
 	client := NewClient(server.URL, "bad-token")
 	var result map[string]string
-	err := client.Get("/v4/user", nil, &result)
+	err := client.Get(context.Background(), "/v4/user", nil, &result)
 	if err == nil {
 		t.Fatal("expected error for 401 response")
 	}
@@ -94,10 +97,34 @@ func TestClientTokenRefresh(t *testing.T) {
 	}
 
 	var result map[string]string
-	if err := client.Get("/v4/user", nil, &result); err != nil {
+	if err := client.Get(context.Background(), "/v4/user", nil, &result); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 	if refreshedToken != "new-token-456" {
 		t.Errorf("expected refreshed token, got: '%s'", refreshedToken)
 	}
 }
+
+func TestClientGetHonorsContextCancellation(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	client := NewClient(server.URL, "token")
+	err := client.Get(ctx, "/v4/user", nil, nil)
+	if err == nil {
+		t.Fatal("expected error when context deadline elapses")
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got: %v", err)
+	}
+}
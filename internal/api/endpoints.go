@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"strings"
 	"unicode"
 )
@@ -11,6 +12,8 @@ type Chain struct {
 	ImageURL  string    `json:"image_url"`
 	Ecosystem string    `json:"ecosystem,omitempty"`
 	Networks  []Network `json:"networks"`
+	// Score is the search relevance (0-100), set only by Search with a query.
+	Score int `json:"score,omitempty"`
 }
 
 type Network struct {
@@ -35,40 +38,53 @@ type NodeType struct {
 
 type EndpointsAPI struct {
 	client *Client
+	// Cache, when set, serves the chain catalog from disk.
+	Cache *CatalogCache
+	// OnCatalog is called with the origin of every cached catalog listing.
+	OnCatalog func(CatalogStatus)
 }
 
 func NewEndpointsAPI(client *Client) *EndpointsAPI {
 	return &EndpointsAPI{client: client}
 }
 
-func (e *EndpointsAPI) List() ([]Chain, error) {
+func (e *EndpointsAPI) List(ctx context.Context) ([]Chain, error) {
+	if e.Cache != nil {
+		chains, status, err := e.Cache.List(ctx, e.client)
+		if err == nil && e.OnCatalog != nil {
+			e.OnCatalog(status)
+		}
+		return chains, err
+	}
 	var chains []Chain
-	err := e.client.Get("/v3/chains", nil, &chains)
+	err := e.client.Get(ctx, chainsPath, nil, &chains)
 	return chains, err
 }
 
-// Search filters chains by query string and optional endpoint filters.
-func (e *EndpointsAPI) Search(query string, ecosystem string, nodeType string, protocol string, network string) ([]Chain, error) {
-	chains, err := e.List()
+// Search ranks chains against a query and applies optional endpoint filters.
+// Matches are ordered by relevance: exact slug, alias, prefix, token, and
+// finally typo-tolerant matches over chain, network, ecosystem, and node type
+// names. An empty query returns every chain in catalog order.
+func (e *EndpointsAPI) Search(ctx context.Context, query string, ecosystem string, nodeType string, protocol string, network string) ([]Chain, error) {
+	chains, err := e.List(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	query = strings.ToLower(query)
-	var filtered []Chain
+	return FilterChains(rankChains(chains, query), ecosystem, nodeType, protocol, network), nil
+}
 
+// FilterChains applies the endpoint filters shared by list, search, and diff,
+// dropping chains and networks left without nodes.
+func FilterChains(chains []Chain, ecosystem string, nodeType string, protocol string, network string) []Chain {
+	var filtered []Chain
 	for _, chain := range chains {
 		if ecosystem != "" && !strings.EqualFold(chain.Ecosystem, ecosystem) {
 			continue
 		}
-		chainMatch := query == "" || strings.Contains(strings.ToLower(chain.Name), query)
 
 		var matchedNetworks []Network
 		for _, net := range chain.Networks {
-			netMatch := chainMatch || strings.Contains(strings.ToLower(net.Name), query)
-			if !netMatch {
-				continue
-			}
 			if filteredNetwork, ok := filterNetwork(net, nodeType, protocol, network); ok {
 				matchedNetworks = append(matchedNetworks, filteredNetwork)
 			}
@@ -79,13 +95,22 @@ func (e *EndpointsAPI) Search(query string, ecosystem string, nodeType string, p
 			filtered = append(filtered, chain)
 		}
 	}
+	return filtered
+}
 
-	return filtered, nil
+// Suggest returns up to limit chain slugs resembling lookup, for "did you
+// mean" hints when Get finds nothing.
+func (e *EndpointsAPI) Suggest(ctx context.Context, lookup string, limit int) ([]string, error) {
+	chains, err := e.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return SuggestChains(chains, lookup, limit), nil
 }
 
 // Get finds one specific chain by exact name/slug match and applies endpoint filters.
-func (e *EndpointsAPI) Get(chainLookup string, ecosystem string, nodeType string, protocol string, network string) ([]Chain, error) {
-	chains, err := e.List()
+func (e *EndpointsAPI) Get(ctx context.Context, chainLookup string, ecosystem string, nodeType string, protocol string, network string) ([]Chain, error) {
+	chains, err := e.List(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -183,6 +208,12 @@ func matchesChainLookup(chainName string, lookup string) bool {
 	return normalizeChainLookup(name) == normalizeChainLookup(target)
 }
 
+// ChainSlug returns the lowercase, dash-separated form of a chain or network
+// name used for lookups (e.g. "BNB Smart Chain" -> "bnb-smart-chain").
+func ChainSlug(value string) string {
+	return normalizeChainLookup(value)
+}
+
 func normalizeChainLookup(value string) string {
 	value = strings.ToLower(strings.TrimSpace(value))
 	if value == "" {
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -54,7 +55,7 @@ func TestListEndpoints(t *testing.T) {
 
 	client := NewClient(server.URL, "token")
 	ep := NewEndpointsAPI(client)
-	result, err := ep.List()
+	result, err := ep.List(context.Background())
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -96,7 +97,7 @@ func TestSearchEndpointsByNetworkFilter(t *testing.T) {
 	client := NewClient(server.URL, "token")
 	ep := NewEndpointsAPI(client)
 
-	mainnetOnly, err := ep.Search("", "", "", "", "mainnet")
+	mainnetOnly, err := ep.Search(context.Background(), "", "", "", "", "mainnet")
 	if err != nil {
 		t.Fatalf("unexpected error filtering mainnet: %v", err)
 	}
@@ -104,7 +105,7 @@ func TestSearchEndpointsByNetworkFilter(t *testing.T) {
 		t.Fatalf("expected only mainnet network, got %+v", mainnetOnly)
 	}
 
-	testnetOnly, err := ep.Search("", "", "", "", "testnet")
+	testnetOnly, err := ep.Search(context.Background(), "", "", "", "", "testnet")
 	if err != nil {
 		t.Fatalf("unexpected error filtering testnet: %v", err)
 	}
@@ -112,7 +113,7 @@ func TestSearchEndpointsByNetworkFilter(t *testing.T) {
 		t.Fatalf("expected only testnet network, got %+v", testnetOnly)
 	}
 
-	sepoliaByName, err := ep.Search("", "", "", "", "sepolia")
+	sepoliaByName, err := ep.Search(context.Background(), "", "", "", "", "sepolia")
 	if err != nil {
 		t.Fatalf("unexpected error filtering by network name: %v", err)
 	}
@@ -146,7 +147,7 @@ func TestSearchEndpointsByProtocolFilter(t *testing.T) {
 	client := NewClient(server.URL, "token")
 	ep := NewEndpointsAPI(client)
 
-	httpsOnly, err := ep.Search("base", "", "", "https", "")
+	httpsOnly, err := ep.Search(context.Background(), "base", "", "", "https", "")
 	if err != nil {
 		t.Fatalf("unexpected error filtering by https: %v", err)
 	}
@@ -160,7 +161,7 @@ func TestSearchEndpointsByProtocolFilter(t *testing.T) {
 		t.Fatalf("expected wss endpoint to be removed for --protocol https, got %q", httpsOnly[0].Networks[0].Nodes[0].WSS)
 	}
 
-	wssOnly, err := ep.Search("base", "", "", "wss", "")
+	wssOnly, err := ep.Search(context.Background(), "base", "", "", "wss", "")
 	if err != nil {
 		t.Fatalf("unexpected error filtering by wss: %v", err)
 	}
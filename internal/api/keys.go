@@ -40,20 +40,20 @@ func NewKeysAPI(client *Client) *KeysAPI {
 	return &KeysAPI{client: client}
 }
 
-func (k *KeysAPI) List() ([]APIKey, error) {
+func (k *KeysAPI) List(ctx context.Context) ([]APIKey, error) {
 	var keys []APIKey
-	err := k.client.Get(apiKeysBasePath, nil, &keys)
+	err := k.client.Get(ctx, apiKeysBasePath, nil, &keys)
 	return keys, err
 }
 
-func (k *KeysAPI) Create(input CreateKeyInput) (*APIKey, error) {
+func (k *KeysAPI) Create(ctx context.Context, input CreateKeyInput) (*APIKey, error) {
 	var key APIKey
-	err := k.client.Post(apiKeysBasePath, input, &key)
+	err := k.client.Post(ctx, apiKeysBasePath, input, &key)
 	return &key, err
 }
 
-func (k *KeysAPI) Update(apiKey string, input UpdateKeyInput) (*APIKey, error) {
-	current, err := k.lookup(apiKey)
+func (k *KeysAPI) Update(ctx context.Context, apiKey string, input UpdateKeyInput) (*APIKey, error) {
+	current, err := k.lookup(ctx, apiKey)
 	if err != nil {
 		return nil, err
 	}
@@ -85,29 +85,27 @@ func (k *KeysAPI) Update(apiKey string, input UpdateKeyInput) (*APIKey, error) {
 
 	path := fmt.Sprintf("%s/%s", apiKeysBasePath, apiKey)
 	var key APIKey
-	err = k.client.Post(path, payload, &key)
-	if err != nil && isTimeoutError(err) {
-		err = k.client.Post(path, payload, &key)
-	}
+	// The payload carries the full key state, so replaying it is safe.
+	err = k.client.PostIdempotent(ctx, path, payload, &key)
 	return &key, err
 }
 
-func (k *KeysAPI) Delete(apiKey string) error {
-	return k.client.Delete(fmt.Sprintf("%s/%s", apiKeysBasePath, apiKey), nil)
+func (k *KeysAPI) Delete(ctx context.Context, apiKey string) error {
+	return k.client.Delete(ctx, fmt.Sprintf("%s/%s", apiKeysBasePath, apiKey), nil)
 }
 
-func (k *KeysAPI) Enable(apiKey string) (*APIKey, error) {
+func (k *KeysAPI) Enable(ctx context.Context, apiKey string) (*APIKey, error) {
 	enabled := true
-	return k.Update(apiKey, UpdateKeyInput{Enabled: &enabled})
+	return k.Update(ctx, apiKey, UpdateKeyInput{Enabled: &enabled})
 }
 
-func (k *KeysAPI) Disable(apiKey string) (*APIKey, error) {
+func (k *KeysAPI) Disable(ctx context.Context, apiKey string) (*APIKey, error) {
 	enabled := false
-	return k.Update(apiKey, UpdateKeyInput{Enabled: &enabled})
+	return k.Update(ctx, apiKey, UpdateKeyInput{Enabled: &enabled})
 }
 
-func (k *KeysAPI) lookup(apiKey string) (*APIKey, error) {
-	keys, err := k.List()
+func (k *KeysAPI) lookup(ctx context.Context, apiKey string) (*APIKey, error) {
+	keys, err := k.List(ctx)
 	if err != nil {
 		return nil, err
 	}
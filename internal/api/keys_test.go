@@ -31,7 +31,7 @@ func TestListKeys(t *testing.T) {
 
 	client := NewClient(server.URL, "token")
 	ka := NewKeysAPI(client)
-	result, err := ka.List()
+	result, err := ka.List(context.Background())
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -64,7 +64,7 @@ func TestCreateKey(t *testing.T) {
 
 	client := NewClient(server.URL, "token")
 	ka := NewKeysAPI(client)
-	result, err := ka.Create(CreateKeyInput{Name: "my-key"})
+	result, err := ka.Create(context.Background(), CreateKeyInput{Name: "my-key"})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -114,7 +114,7 @@ func TestUpdateSendsFullPayload(t *testing.T) {
 	client := NewClient(server.URL, "token")
 	ka := NewKeysAPI(client)
 	name := "new-name"
-	_, err := ka.Update("abc-123", UpdateKeyInput{Name: &name})
+	_, err := ka.Update(context.Background(), "abc-123", UpdateKeyInput{Name: &name})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -145,7 +145,7 @@ func TestUpdateMissingKey(t *testing.T) {
 
 	client := NewClient(server.URL, "token")
 	ka := NewKeysAPI(client)
-	_, err := ka.Update("missing-key", UpdateKeyInput{})
+	_, err := ka.Update(context.Background(), "missing-key", UpdateKeyInput{})
 	if err == nil {
 		t.Fatal("expected error when key is not found")
 	}
@@ -185,7 +185,7 @@ func TestUpdateRetriesTimeoutOnce(t *testing.T) {
 
 	ka := NewKeysAPI(client)
 	name := "renamed"
-	key, err := ka.Update("abc-123", UpdateKeyInput{Name: &name})
+	key, err := ka.Update(context.Background(), "abc-123", UpdateKeyInput{Name: &name})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -211,7 +211,7 @@ func TestDeleteKey(t *testing.T) {
 	client := NewClient(server.URL, "token")
 	ka := NewKeysAPI(client)
 
-	if err := ka.Delete("abc-123"); err != nil {
+	if err := ka.Delete(context.Background(), "abc-123"); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
@@ -1,5 +1,29 @@
 package api
 
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// traceLogsPageSize is the page size used when scanning for a request ID.
+const traceLogsPageSize = 200
+
+const (
+	// tailIngestLag is how far behind the newest entry a tail keeps looking,
+	// so entries that reach the logs late are still picked up.
+	tailIngestLag = time.Minute
+	// tailMaxPages bounds the pages read by one tail poll.
+	tailMaxPages = 10
+)
+
+// ErrTraceScanLimit is returned by Trace when the page limit is reached
+// before the window is exhausted.
+var ErrTraceScanLimit = errors.New("stopped scanning error logs before the end of the window")
+
 type ErrorLog struct {
 	Timestamp        string `json:"timestamp"`
 	RequestID        string `json:"request_id"`
@@ -14,6 +38,23 @@ type ErrorLog struct {
 	TotalLatencyMs   int    `json:"total_latency_ms"`
 }
 
+// ErrorLogPage is one page of error logs with the cursor of the next page.
+type ErrorLogPage struct {
+	Items      []ErrorLog `json:"items"`
+	NextCursor string     `json:"next_cursor,omitempty"`
+	HasMore    bool       `json:"has_more"`
+}
+
+// RequestTrace is the error log entry recorded for one request ID, with the
+// time that the gateway added on top of the backend node.
+type RequestTrace struct {
+	ErrorLog
+	GatewayLatencyMs int    `json:"gateway_latency_ms"`
+	WindowStart      string `json:"window_start"`
+	WindowEnd        string `json:"window_end"`
+	Scanned          int    `json:"scanned"`
+}
+
 type ErrorStats struct {
 	StatusCode  int    `json:"status_code"`
 	StatusLabel string `json:"status_label,omitempty"`
@@ -48,12 +89,6 @@ type errorLogsFilter struct {
 	RPCMethods  []string `json:"rpc_methods,omitempty"`
 }
 
-type errorLogsResponse struct {
-	Items      []ErrorLog `json:"items"`
-	NextCursor string     `json:"next_cursor,omitempty"`
-	HasMore    bool       `json:"has_more"`
-}
-
 type errorClassesResponse struct {
 	Items []ErrorStats `json:"items"`
 }
@@ -85,8 +120,16 @@ func NewLogsAPI(client *Client) *LogsAPI {
 	return &LogsAPI{client: client}
 }
 
-func (l *LogsAPI) Errors(filters map[string]interface{}) ([]ErrorLog, error) {
-	req := toErrorLogsRequest(filters)
+func (l *LogsAPI) Errors(ctx context.Context, filter ErrorLogFilter) ([]ErrorLog, error) {
+	page, err := l.ErrorsPage(ctx, filter)
+	return page.Items, err
+}
+
+// ErrorsPage returns one page of error logs, newest first. The returned page
+// is never nil. Entries dropped by the filter's exclusions are removed, so a
+// page may hold fewer entries than its size.
+func (l *LogsAPI) ErrorsPage(ctx context.Context, filter ErrorLogFilter) (*ErrorLogPage, error) {
+	req := filter.request()
 	if req.PageSize == 0 {
 		req.PageSize = 50
 	}
@@ -94,30 +137,211 @@ func (l *LogsAPI) Errors(filters map[string]interface{}) ([]ErrorLog, error) {
 		req.Order = "desc"
 	}
 
-	var payload errorLogsResponse
-	err := l.client.Post("/v4/organization/logs/errors", req, &payload)
-	return payload.Items, err
+	var payload ErrorLogPage
+	err := l.client.PostIdempotent(ctx, "/v4/organization/logs/errors", req, &payload)
+	payload.Items = slices.DeleteFunc(payload.Items, filter.Excludes)
+	return &payload, err
+}
+
+// WalkErrors reads consecutive pages of error logs, starting at the filter's
+// cursor (if any), and passes each to onPage until the last page or until
+// maxRows entries were read (0 means no limit). Near maxRows the page size
+// shrinks so that no entry is skipped: the returned last page's NextCursor
+// resumes right after the final entry passed to onPage.
+func (l *LogsAPI) WalkErrors(ctx context.Context, filter ErrorLogFilter, maxRows int, onPage func(page *ErrorLogPage) error) (*ErrorLogPage, error) {
+	query := filter
+	pageSize := filter.Limit
+	if pageSize <= 0 {
+		pageSize = traceLogsPageSize
+	}
+
+	rows := 0
+	for {
+		query.Limit = pageSize
+		if maxRows > 0 {
+			query.Limit = min(pageSize, maxRows-rows)
+		}
+		page, err := l.ErrorsPage(ctx, query)
+		if err != nil {
+			return page, err
+		}
+		rows += len(page.Items)
+		if err := onPage(page); err != nil {
+			return page, err
+		}
+		// A page may be empty after exclusions; a cursor that does not move
+		// would loop forever.
+		if !page.HasMore || page.NextCursor == "" || page.NextCursor == query.Cursor || (maxRows > 0 && rows >= maxRows) {
+			return page, nil
+		}
+		query.Cursor = page.NextCursor
+	}
 }
 
-func (l *LogsAPI) Stats(filters map[string]interface{}) ([]ErrorStats, error) {
-	req := toErrorLogsRequest(filters)
+// Trace finds the error log of requestID between start and end. The logs API
+// cannot filter by request ID, so pages are scanned newest first, at most
+// maxPages of them; the filter (API keys, FQDNs, ...) narrows the scan. A nil trace
+// with a nil error means no entry matched; scanned reports how many entries
+// were checked either way.
+func (l *LogsAPI) Trace(ctx context.Context, requestID string, start, end time.Time, filter ErrorLogFilter, maxPages int) (*RequestTrace, int, error) {
+	query := filter
+	query.From = start.UTC().Format(time.RFC3339)
+	query.To = end.UTC().Format(time.RFC3339)
+	query.Limit = traceLogsPageSize
+	query.Cursor = ""
+
+	scanned := 0
+	for pages := 0; pages < maxPages; pages++ {
+		page, err := l.ErrorsPage(ctx, query)
+		if err != nil {
+			return nil, scanned, err
+		}
+		for _, entry := range page.Items {
+			scanned++
+			if strings.EqualFold(strings.TrimSpace(entry.RequestID), requestID) {
+				return &RequestTrace{
+					ErrorLog:         entry,
+					GatewayLatencyMs: max(entry.TotalLatencyMs-entry.BackendLatencyMs, 0),
+					WindowStart:      query.From,
+					WindowEnd:        query.To,
+					Scanned:          scanned,
+				}, scanned, nil
+			}
+		}
+		if !page.HasMore || page.NextCursor == "" {
+			return nil, scanned, nil
+		}
+		query.Cursor = page.NextCursor
+	}
+	return nil, scanned, ErrTraceScanLimit
+}
+
+// ErrorLogTail polls the error logs for entries it has not returned before.
+type ErrorLogTail struct {
+	logs   *LogsAPI
+	filter ErrorLogFilter
+	from   time.Time
+	seen   map[string]time.Time
+}
+
+// Tail starts following the error logs matching filter from since on. The
+// filter's time range, paging, and order fields are ignored.
+func (l *LogsAPI) Tail(filter ErrorLogFilter, since time.Time) *ErrorLogTail {
+	filter.From, filter.To, filter.Limit, filter.Cursor, filter.Order = "", "", 0, "", ""
+	return &ErrorLogTail{logs: l, filter: filter, from: since.UTC(), seen: map[string]time.Time{}}
+}
+
+// Poll returns the entries logged since the previous poll, oldest first.
+// The window is read oldest first and entries are de-duplicated by request
+// ID since consecutive windows overlap. When a burst fills more pages than
+// one poll reads, the window only advances to the newest entry read, so the
+// next poll picks up where this one stopped.
+func (t *ErrorLogTail) Poll(ctx context.Context) ([]ErrorLog, error) {
+	query := t.filter
+	query.From = t.from.Format(time.RFC3339)
+	query.Limit = traceLogsPageSize
+	query.Order = "asc"
+
+	var fresh []ErrorLog
+	truncated := false
+	for pages := 0; ; pages++ {
+		if pages == tailMaxPages {
+			truncated = true
+			break
+		}
+		page, err := t.logs.ErrorsPage(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range page.Items {
+			// Entries before the window were returned by an earlier poll and
+			// may no longer be in seen.
+			at := parseLogTimestamp(entry.Timestamp)
+			if at.IsZero() {
+				at = t.from
+			} else if at.Before(t.from) {
+				continue
+			}
+			key := tailKey(entry)
+			if _, ok := t.seen[key]; ok {
+				continue
+			}
+			t.seen[key] = at
+			fresh = append(fresh, entry)
+		}
+		if !page.HasMore || page.NextCursor == "" {
+			break
+		}
+		query.Cursor = page.NextCursor
+	}
+
+	sort.SliceStable(fresh, func(i, j int) bool {
+		return parseLogTimestamp(fresh[i].Timestamp).Before(parseLogTimestamp(fresh[j].Timestamp))
+	})
+	if n := len(fresh); n > 0 {
+		next := parseLogTimestamp(fresh[n-1].Timestamp)
+		if !truncated {
+			next = next.Add(-tailIngestLag)
+		}
+		if next = next.Truncate(time.Second); next.After(t.from) {
+			t.from = next
+		}
+	}
+	for key, at := range t.seen {
+		if at.Before(t.from) {
+			delete(t.seen, key)
+		}
+	}
+	return fresh, nil
+}
+
+// tailKey identifies an entry; entries without a request ID fall back to
+// their content.
+func tailKey(entry ErrorLog) string {
+	if entry.RequestID != "" {
+		return entry.RequestID
+	}
+	return strings.Join([]string{entry.Timestamp, entry.FQDN, entry.RPCMethods, entry.ErrorMessage}, "\x00")
+}
+
+// parseLogTimestamp parses an error log timestamp, returning the zero time
+// when it is malformed.
+func parseLogTimestamp(value string) time.Time {
+	parsed, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}
+	}
+	return parsed.UTC()
+}
+
+// Stats returns error counts per status code. Excluded statuses are dropped;
+// excluded RPC methods cannot be taken out of the API's counts and are
+// ignored.
+func (l *LogsAPI) Stats(ctx context.Context, filter ErrorLogFilter) ([]ErrorStats, error) {
+	req := filter.request()
 	if req.Order == "" {
 		req.Order = "desc"
 	}
 
 	var payload errorClassesResponse
-	err := l.client.Post("/v4/organization/logs/error-classes", req, &payload)
-	return payload.Items, err
+	err := l.client.PostIdempotent(ctx, "/v4/organization/logs/error-classes", req, &payload)
+	return slices.DeleteFunc(payload.Items, func(s ErrorStats) bool {
+		return filter.excludesStatus(s.StatusCode)
+	}), err
 }
 
-func (l *LogsAPI) Facets(filters map[string]interface{}) (*ErrorFacets, error) {
-	req := toErrorLogsRequest(filters)
+// Facets returns error counts per endpoint, RPC method, origin, and API key.
+// Excluded RPC methods are dropped from the method facet only: the other
+// facets still count their errors, since the API cannot exclude them.
+// Excluded statuses are ignored.
+func (l *LogsAPI) Facets(ctx context.Context, filter ErrorLogFilter) (*ErrorFacets, error) {
+	req := filter.request()
 	if req.Order == "" {
 		req.Order = "desc"
 	}
 
 	var payload errorFacetsResponse
-	err := l.client.Post("/v4/organization/logs/error-facets", req, &payload)
+	err := l.client.PostIdempotent(ctx, "/v4/organization/logs/error-facets", req, &payload)
 	if err != nil {
 		return nil, err
 	}
@@ -127,6 +351,9 @@ func (l *LogsAPI) Facets(filters map[string]interface{}) (*ErrorFacets, error) {
 		facets.FQDNs = append(facets.FQDNs, FacetEntry{Value: entry.FQDN, Count: entry.Count})
 	}
 	for _, entry := range payload.RPCMethods {
+		if slices.Contains(filter.ExcludeMethods, entry.RPCMethod) {
+			continue
+		}
 		facets.RPCMethods = append(facets.RPCMethods, FacetEntry{Value: entry.RPCMethod, Count: entry.Count})
 	}
 	for _, entry := range payload.Origins {
@@ -137,39 +364,3 @@ func (l *LogsAPI) Facets(filters map[string]interface{}) (*ErrorFacets, error) {
 	}
 	return facets, nil
 }
-
-func toErrorLogsRequest(filters map[string]interface{}) errorLogsRequest {
-	req := errorLogsRequest{}
-	filter := &errorLogsFilter{}
-
-	if value, ok := filters["from"].(string); ok {
-		req.StartTime = value
-	}
-	if value, ok := filters["to"].(string); ok {
-		req.EndTime = value
-	}
-	if value, ok := filters["limit"].(int); ok {
-		req.PageSize = value
-	}
-	if value, ok := filters["cursor"].(string); ok {
-		req.Cursor = value
-	}
-
-	if value, ok := filters["api_key"].(string); ok && value != "" {
-		filter.APIKeys = []string{value}
-	}
-	if value, ok := filters["fqdn"].(string); ok && value != "" {
-		filter.FQDNs = []string{value}
-	}
-	if value, ok := filters["status_code"].(int); ok && value > 0 {
-		filter.StatusCodes = []int{value}
-	}
-	if value, ok := filters["rpc_method"].(string); ok && value != "" {
-		filter.RPCMethods = []string{value}
-	}
-
-	if len(filter.APIKeys) > 0 || len(filter.FQDNs) > 0 || len(filter.StatusCodes) > 0 || len(filter.RPCMethods) > 0 {
-		req.Filter = filter
-	}
-	return req
-}
@@ -0,0 +1,149 @@
+package api
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Placeholders that replace the variable parts of an error message.
+const (
+	maskUUID    = "<uuid>"
+	maskAddress = "<address>"
+	maskHash    = "<hash>"
+	maskHex     = "<hex>"
+	maskNumber  = "<n>"
+)
+
+// Masks are applied in order, so more specific patterns win: 0x values by
+// length, then whole numbers, then base58 (Solana, Tron) and bech32 (Cosmos)
+// addresses, then bare hex strings such as transaction hashes without 0x.
+var errorMessageMasks = []struct {
+	pattern *regexp.Regexp
+	mask    string
+}{
+	{regexp.MustCompile(`(?i)\b[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}\b`), maskUUID},
+	{regexp.MustCompile(`(?i)\b0x[0-9a-f]{64}\b`), maskHash},
+	{regexp.MustCompile(`(?i)\b0x[0-9a-f]{40}\b`), maskAddress},
+	{regexp.MustCompile(`(?i)\b0x[0-9a-f]+\b`), maskHex},
+	// Units stay: "after 30.5s" becomes "after <n>s".
+	{regexp.MustCompile(`\b\d+(?:\.\d+)?((?i:ns|us|ms|s|m|h|k|kb|mb|gb)?)\b`), maskNumber + "${1}"},
+	{regexp.MustCompile(`\b[1-9A-HJ-NP-Za-km-z]{32,44}\b`), maskAddress},
+	{regexp.MustCompile(`\b[a-z]{2,10}1[02-9ac-hj-np-z]{38,58}\b`), maskAddress},
+	{regexp.MustCompile(`(?i)\b[0-9a-f]{16,}\b`), maskHex},
+}
+
+// ErrorCluster groups error logs whose messages share a template.
+type ErrorCluster struct {
+	Template    string       `json:"template"`
+	Count       int          `json:"count"`
+	FirstSeen   string       `json:"first_seen"`
+	LastSeen    string       `json:"last_seen"`
+	Example     string       `json:"example"`
+	StatusCodes []FacetEntry `json:"status_codes"`
+	FQDNs       []FacetEntry `json:"fqdns"`
+	RPCMethods  []FacetEntry `json:"rpc_methods"`
+	APIKeys     []FacetEntry `json:"api_keys"`
+}
+
+// ErrorClusterReport is the result of clustering a set of error logs.
+// Truncated is set when more logs matched than were read.
+type ErrorClusterReport struct {
+	Scanned       int            `json:"scanned"`
+	Truncated     bool           `json:"truncated,omitempty"`
+	TotalClusters int            `json:"total_clusters"`
+	Clusters      []ErrorCluster `json:"clusters"`
+}
+
+// ErrorTemplate normalizes an error message by masking the parts that vary
+// between otherwise identical errors: UUIDs, hashes, addresses, hex values,
+// and numbers.
+func ErrorTemplate(message string) string {
+	template := strings.Join(strings.Fields(message), " ")
+	for _, m := range errorMessageMasks {
+		template = m.pattern.ReplaceAllString(template, m.mask)
+	}
+	return template
+}
+
+// ClusterErrorLogs groups logs by message template, largest cluster first.
+// Facets within a cluster are ordered by count.
+func ClusterErrorLogs(logs []ErrorLog) []ErrorCluster {
+	type builder struct {
+		cluster        ErrorCluster
+		first, last    time.Time
+		statuses       map[string]int
+		fqdns, methods map[string]int
+		keys           map[string]int
+	}
+	byTemplate := map[string]*builder{}
+	var order []string
+	for _, entry := range logs {
+		template := ErrorTemplate(entry.ErrorMessage)
+		b, ok := byTemplate[template]
+		if !ok {
+			b = &builder{
+				cluster:  ErrorCluster{Template: template, Example: entry.ErrorMessage},
+				statuses: map[string]int{},
+				fqdns:    map[string]int{},
+				methods:  map[string]int{},
+				keys:     map[string]int{},
+			}
+			byTemplate[template] = b
+			order = append(order, template)
+		}
+		b.cluster.Count++
+		if at := parseLogTimestamp(entry.Timestamp); !at.IsZero() {
+			if b.first.IsZero() || at.Before(b.first) {
+				b.first, b.cluster.FirstSeen = at, entry.Timestamp
+			}
+			if at.After(b.last) {
+				b.last, b.cluster.LastSeen = at, entry.Timestamp
+			}
+		}
+		if entry.StatusCode > 0 {
+			b.statuses[strings.TrimSpace(strconv.Itoa(entry.StatusCode)+" "+entry.StatusLabel)]++
+		}
+		if entry.FQDN != "" {
+			b.fqdns[entry.FQDN]++
+		}
+		for _, method := range strings.Split(entry.RPCMethods, ",") {
+			if method = strings.TrimSpace(method); method != "" {
+				b.methods[method]++
+			}
+		}
+		if entry.APIKey != "" {
+			b.keys[entry.APIKey]++
+		}
+	}
+
+	clusters := make([]ErrorCluster, 0, len(order))
+	for _, template := range order {
+		b := byTemplate[template]
+		b.cluster.StatusCodes = facetEntries(b.statuses)
+		b.cluster.FQDNs = facetEntries(b.fqdns)
+		b.cluster.RPCMethods = facetEntries(b.methods)
+		b.cluster.APIKeys = facetEntries(b.keys)
+		clusters = append(clusters, b.cluster)
+	}
+	sort.SliceStable(clusters, func(i, j int) bool {
+		return clusters[i].Count > clusters[j].Count
+	})
+	return clusters
+}
+
+func facetEntries(counts map[string]int) []FacetEntry {
+	entries := make([]FacetEntry, 0, len(counts))
+	for value, count := range counts {
+		entries = append(entries, FacetEntry{Value: value, Count: count})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Count != entries[j].Count {
+			return entries[i].Count > entries[j].Count
+		}
+		return entries[i].Value < entries[j].Value
+	})
+	return entries
+}
@@ -0,0 +1,54 @@
+package api
+
+import "testing"
+
+func TestErrorTemplateMasksVariableParts(t *testing.T) {
+	tests := map[string]string{
+		"header not found for block 19283746": "header not found for block <n>",
+		"missing trie node 3f2a9c1b0d8e7f6a5b4c3d2e1f0a9b8c7d6e5f4a3b2c1d0e9f8a7b6c5d4e3f2a1b (path )": "missing trie node <hex> (path )",
+		"tx 0x5c504ed432cb51138bcf09aa5e8a410dd4a1e204ef84bfed1be16dfba1b22060 not found":              "tx <hash> not found",
+		"insufficient funds for 0xd8dA6BF26964aF9D7eEd9e03E53415D37aA96045":                            "insufficient funds for <address>",
+		"invalid block range 0x1a2b to 0x1a3c":                                                         "invalid block range <hex> to <hex>",
+		"request 4f7c2a9e-51d3-4b8e-9a0c-2f6d1e8b7c34 timed out after 30.5s":                           "request <uuid> timed out after <n>s",
+		"account 9xQeWvG816bUx9EPjHmaT23yvVM2ZWbrrpZb9PusVFin not found":                               "account <address> not found",
+		"limit 100ms exceeded by 20 requests":                                                          "limit <n>ms exceeded by <n> requests",
+		"  upstream   timeout  ":                                                                       "upstream timeout",
+	}
+	for message, want := range tests {
+		if got := ErrorTemplate(message); got != want {
+			t.Errorf("ErrorTemplate(%q) = %q, want %q", message, got, want)
+		}
+	}
+}
+
+func TestClusterErrorLogs(t *testing.T) {
+	logs := []ErrorLog{
+		{Timestamp: "2026-02-26T10:05:00Z", ErrorMessage: "header not found for block 100", FQDN: "a.example", RPCMethods: "eth_getBlockByNumber", APIKey: "k1", StatusCode: 500},
+		{Timestamp: "2026-02-26T10:01:00Z", ErrorMessage: "rate limit exceeded", FQDN: "a.example", RPCMethods: "eth_call", APIKey: "k2", StatusCode: 429, StatusLabel: "Too Many Requests"},
+		{Timestamp: "2026-02-26T10:00:00Z", ErrorMessage: "header not found for block 99", FQDN: "b.example", RPCMethods: "eth_getBlockByNumber,eth_getLogs", APIKey: "k1", StatusCode: 500},
+		{Timestamp: "2026-02-26T10:09:00Z", ErrorMessage: "header not found for block 104", FQDN: "a.example", RPCMethods: "eth_getLogs", APIKey: "k1", StatusCode: 500},
+	}
+	clusters := ClusterErrorLogs(logs)
+	if len(clusters) != 2 {
+		t.Fatalf("expected 2 clusters, got %+v", clusters)
+	}
+	top := clusters[0]
+	if top.Template != "header not found for block <n>" || top.Count != 3 || top.Example != "header not found for block 100" {
+		t.Fatalf("unexpected top cluster: %+v", top)
+	}
+	if top.FirstSeen != "2026-02-26T10:00:00Z" || top.LastSeen != "2026-02-26T10:09:00Z" {
+		t.Fatalf("unexpected first/last seen: %s %s", top.FirstSeen, top.LastSeen)
+	}
+	if len(top.FQDNs) != 2 || top.FQDNs[0] != (FacetEntry{Value: "a.example", Count: 2}) {
+		t.Fatalf("unexpected fqdns: %+v", top.FQDNs)
+	}
+	if len(top.RPCMethods) != 2 || top.RPCMethods[0].Count != 2 || top.RPCMethods[1].Count != 2 || top.RPCMethods[0].Value != "eth_getBlockByNumber" {
+		t.Fatalf("unexpected methods: %+v", top.RPCMethods)
+	}
+	if len(top.APIKeys) != 1 || top.APIKeys[0].Count != 3 {
+		t.Fatalf("unexpected keys: %+v", top.APIKeys)
+	}
+	if status := clusters[1].StatusCodes; len(status) != 1 || status[0].Value != "429 Too Many Requests" {
+		t.Fatalf("unexpected statuses: %+v", status)
+	}
+}
@@ -0,0 +1,145 @@
+package api
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// HTTP status codes accepted in status filters.
+const (
+	minStatusCode = 100
+	maxStatusCode = 599
+)
+
+// ErrorLogFilter selects error logs. Values within a field are alternatives
+// and fields are combined, as the logs API does. The exclusions are applied
+// client-side to the entries returned, since the API cannot negate filters.
+type ErrorLogFilter struct {
+	APIKeys         []string
+	FQDNs           []string
+	Statuses        []StatusRange
+	RPCMethods      []string
+	ExcludeStatuses []StatusRange
+	ExcludeMethods  []string
+
+	From   string
+	To     string
+	Limit  int
+	Cursor string
+	// Order is "asc" or "desc" (the default) by timestamp.
+	Order string
+}
+
+// StatusRange is an inclusive range of HTTP status codes; a single code has
+// Min == Max.
+type StatusRange struct {
+	Min int
+	Max int
+}
+
+// ParseStatusRange parses a status code ("429"), a class ("5xx"), or an
+// inclusive range ("400-499").
+func ParseStatusRange(value string) (StatusRange, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	var r StatusRange
+	switch {
+	case len(value) == 3 && strings.HasSuffix(value, "xx"):
+		class, err := strconv.Atoi(value[:1])
+		if err != nil {
+			return r, fmt.Errorf("invalid status %q", value)
+		}
+		r = StatusRange{Min: class * 100, Max: class*100 + 99}
+	case strings.Contains(value, "-"):
+		low, high, _ := strings.Cut(value, "-")
+		lowCode, lowErr := strconv.Atoi(strings.TrimSpace(low))
+		highCode, highErr := strconv.Atoi(strings.TrimSpace(high))
+		if lowErr != nil || highErr != nil || lowCode > highCode {
+			return r, fmt.Errorf("invalid status range %q", value)
+		}
+		r = StatusRange{Min: lowCode, Max: highCode}
+	default:
+		code, err := strconv.Atoi(value)
+		if err != nil {
+			return r, fmt.Errorf("invalid status %q", value)
+		}
+		r = StatusRange{Min: code, Max: code}
+	}
+	if r.Min < minStatusCode || r.Max > maxStatusCode {
+		return r, fmt.Errorf("status %q is outside %d-%d", value, minStatusCode, maxStatusCode)
+	}
+	return r, nil
+}
+
+// Contains reports whether code falls within the range.
+func (r StatusRange) Contains(code int) bool {
+	return code >= r.Min && code <= r.Max
+}
+
+func (r StatusRange) String() string {
+	if r.Min == r.Max {
+		return strconv.Itoa(r.Min)
+	}
+	return fmt.Sprintf("%d-%d", r.Min, r.Max)
+}
+
+// Excludes reports whether entry is dropped by the exclusions: its status is
+// excluded, or it includes an excluded RPC method.
+func (f ErrorLogFilter) Excludes(entry ErrorLog) bool {
+	if f.excludesStatus(entry.StatusCode) {
+		return true
+	}
+	if len(f.ExcludeMethods) == 0 {
+		return false
+	}
+	for _, method := range strings.Split(entry.RPCMethods, ",") {
+		if slices.Contains(f.ExcludeMethods, strings.TrimSpace(method)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f ErrorLogFilter) excludesStatus(code int) bool {
+	for _, r := range f.ExcludeStatuses {
+		if r.Contains(code) {
+			return true
+		}
+	}
+	return false
+}
+
+// statusCodes expands the status ranges into the codes the API filters on.
+func (f ErrorLogFilter) statusCodes() []int {
+	var codes []int
+	for _, r := range f.Statuses {
+		for code := r.Min; code <= r.Max; code++ {
+			if !slices.Contains(codes, code) {
+				codes = append(codes, code)
+			}
+		}
+	}
+	slices.Sort(codes)
+	return codes
+}
+
+func (f ErrorLogFilter) request() errorLogsRequest {
+	req := errorLogsRequest{
+		StartTime: f.From,
+		EndTime:   f.To,
+		PageSize:  f.Limit,
+		Cursor:    f.Cursor,
+		Order:     f.Order,
+	}
+	filter := &errorLogsFilter{
+		APIKeys:     f.APIKeys,
+		FQDNs:       f.FQDNs,
+		StatusCodes: f.statusCodes(),
+		RPCMethods:  f.RPCMethods,
+	}
+	if len(filter.APIKeys) > 0 || len(filter.FQDNs) > 0 || len(filter.StatusCodes) > 0 || len(filter.RPCMethods) > 0 {
+		req.Filter = filter
+	}
+	return req
+}
@@ -0,0 +1,100 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+func TestParseStatusRange(t *testing.T) {
+	tests := map[string]StatusRange{
+		"429":       {429, 429},
+		"5xx":       {500, 599},
+		"4XX":       {400, 499},
+		"400-404":   {400, 404},
+		" 500-503 ": {500, 503},
+	}
+	for value, want := range tests {
+		if got, err := ParseStatusRange(value); err != nil || got != want {
+			t.Errorf("ParseStatusRange(%q) = %v, %v; want %v", value, got, err, want)
+		}
+	}
+	for _, value := range []string{"", "abc", "9xx", "0xx", "499-400", "42", "600", "x-500"} {
+		if _, err := ParseStatusRange(value); err == nil {
+			t.Errorf("ParseStatusRange(%q): expected an error", value)
+		}
+	}
+}
+
+func TestErrorLogFilterRequestAndExclusions(t *testing.T) {
+	filter := ErrorLogFilter{
+		APIKeys:         []string{"key-1", "key-2"},
+		Statuses:        []StatusRange{{429, 429}, {500, 503}, {502, 502}},
+		ExcludeStatuses: []StatusRange{{503, 503}},
+		ExcludeMethods:  []string{"eth_call"},
+	}
+	req := filter.request()
+	if req.Filter == nil || !slices.Equal(req.Filter.StatusCodes, []int{429, 500, 501, 502, 503}) || len(req.Filter.APIKeys) != 2 {
+		t.Fatalf("unexpected request filter: %+v", req.Filter)
+	}
+	if (ErrorLogFilter{}).request().Filter != nil {
+		t.Fatal("expected no filter object for an empty filter")
+	}
+
+	tests := []struct {
+		entry ErrorLog
+		want  bool
+	}{
+		{ErrorLog{StatusCode: 500, RPCMethods: "eth_getLogs"}, false},
+		{ErrorLog{StatusCode: 503, RPCMethods: "eth_getLogs"}, true},
+		{ErrorLog{StatusCode: 500, RPCMethods: "eth_getLogs, eth_call"}, true},
+		{ErrorLog{StatusCode: 500}, false},
+	}
+	for _, tt := range tests {
+		if got := filter.Excludes(tt.entry); got != tt.want {
+			t.Errorf("Excludes(%+v) = %v, want %v", tt.entry, got, tt.want)
+		}
+	}
+}
+
+func TestLogsAPIAppliesExclusions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v4/organization/logs/errors":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"items": []map[string]interface{}{
+				{"request_id": "a", "response_status_code": 429, "request_rpc_methods": "eth_call"},
+				{"request_id": "b", "response_status_code": 500, "request_rpc_methods": "eth_getLogs"},
+				{"request_id": "c", "response_status_code": 500, "request_rpc_methods": "eth_call"},
+			}})
+		case "/v4/organization/logs/error-classes":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"items": []map[string]interface{}{
+				{"status_code": 429, "count": 7},
+				{"status_code": 500, "count": 3},
+			}})
+		case "/v4/organization/logs/error-facets":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"rpc_methods": []map[string]interface{}{
+				{"rpc_method": "eth_call", "count": 7},
+				{"rpc_method": "eth_getLogs", "count": 3},
+			}})
+		}
+	}))
+	defer server.Close()
+	logs := NewLogsAPI(NewClient(server.URL, "token"))
+	ctx := context.Background()
+
+	page, err := logs.ErrorsPage(ctx, ErrorLogFilter{ExcludeStatuses: []StatusRange{{400, 499}}, ExcludeMethods: []string{"eth_call"}})
+	if err != nil || len(page.Items) != 1 || page.Items[0].RequestID != "b" {
+		t.Fatalf("expected only entry b, got %+v (%v)", page, err)
+	}
+	stats, err := logs.Stats(ctx, ErrorLogFilter{ExcludeStatuses: []StatusRange{{429, 429}}})
+	if err != nil || len(stats) != 1 || stats[0].StatusCode != 500 {
+		t.Fatalf("expected only the 500 row, got %+v (%v)", stats, err)
+	}
+	facets, err := logs.Facets(ctx, ErrorLogFilter{ExcludeMethods: []string{"eth_call"}})
+	if err != nil || len(facets.RPCMethods) != 1 || facets.RPCMethods[0].Value != "eth_getLogs" {
+		t.Fatalf("expected only eth_getLogs, got %+v (%v)", facets, err)
+	}
+}
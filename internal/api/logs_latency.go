@@ -0,0 +1,138 @@
+package api
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dwellir-public/cli/internal/stats"
+)
+
+// Groupings supported by LatencyByGroup.
+const (
+	LatencyByFQDN   = "fqdn"
+	LatencyByMethod = "method"
+	LatencyByStatus = "status"
+)
+
+// LatencyGroup summarizes the latency of the error logs sharing a group value
+// (and time bucket, when bucketing). Gateway figures are total minus backend
+// latency: the time spent in Dwellir's gateway rather than on the node.
+type LatencyGroup struct {
+	Bucket          string  `json:"bucket,omitempty"`
+	Group           string  `json:"group"`
+	Count           int     `json:"count"`
+	P50Ms           float64 `json:"p50_ms"`
+	P90Ms           float64 `json:"p90_ms"`
+	P99Ms           float64 `json:"p99_ms"`
+	MaxMs           float64 `json:"max_ms"`
+	BackendP50Ms    float64 `json:"backend_p50_ms"`
+	GatewayP50Ms    float64 `json:"gateway_p50_ms"`
+	GatewayShare    float64 `json:"gateway_share"`
+	GatewayDominant bool    `json:"gateway_dominant"`
+}
+
+// ErrorLatencyReport is the result of 'logs latency'.
+type ErrorLatencyReport struct {
+	GroupBy   string         `json:"group_by"`
+	Interval  string         `json:"interval,omitempty"`
+	Scanned   int            `json:"scanned"`
+	Truncated bool           `json:"truncated,omitempty"`
+	Groups    []LatencyGroup `json:"groups"`
+}
+
+// LatencyByGroup computes latency percentiles of logs per groupBy value, and
+// per interval bucket when interval is positive. A log listing several RPC
+// methods counts towards each of them. Groups are ordered by bucket, then by
+// count. The gateway dominates a group when it accounts for more than half of
+// the total time.
+func LatencyByGroup(logs []ErrorLog, groupBy string, interval time.Duration) []LatencyGroup {
+	type key struct{ bucket, group string }
+	type samples struct{ total, backend, gateway []float64 }
+	byKey := map[key]*samples{}
+	for _, entry := range logs {
+		bucket := ""
+		if interval > 0 {
+			at := parseLogTimestamp(entry.Timestamp)
+			if at.IsZero() {
+				continue
+			}
+			bucket = at.Truncate(interval).Format(time.RFC3339)
+		}
+		for _, group := range latencyGroupValues(entry, groupBy) {
+			k := key{bucket, group}
+			s, ok := byKey[k]
+			if !ok {
+				s = &samples{}
+				byKey[k] = s
+			}
+			s.total = append(s.total, float64(entry.TotalLatencyMs))
+			s.backend = append(s.backend, float64(entry.BackendLatencyMs))
+			s.gateway = append(s.gateway, float64(max(entry.TotalLatencyMs-entry.BackendLatencyMs, 0)))
+		}
+	}
+
+	groups := make([]LatencyGroup, 0, len(byKey))
+	for k, s := range byKey {
+		p := stats.Percentiles(s.total, 50, 90, 99, 100)
+		group := LatencyGroup{
+			Bucket:       k.bucket,
+			Group:        k.group,
+			Count:        len(s.total),
+			P50Ms:        p[0],
+			P90Ms:        p[1],
+			P99Ms:        p[2],
+			MaxMs:        p[3],
+			BackendP50Ms: stats.Percentile(s.backend, 50),
+			GatewayP50Ms: stats.Percentile(s.gateway, 50),
+		}
+		if total := sumFloats(s.total); total > 0 {
+			group.GatewayShare = sumFloats(s.gateway) / total
+			group.GatewayDominant = group.GatewayShare > 0.5
+		}
+		groups = append(groups, group)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		a, b := groups[i], groups[j]
+		if a.Bucket != b.Bucket {
+			return a.Bucket < b.Bucket
+		}
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return a.Group < b.Group
+	})
+	return groups
+}
+
+func latencyGroupValues(entry ErrorLog, groupBy string) []string {
+	switch groupBy {
+	case LatencyByMethod:
+		var methods []string
+		for _, method := range strings.Split(entry.RPCMethods, ",") {
+			if method = strings.TrimSpace(method); method != "" {
+				methods = append(methods, method)
+			}
+		}
+		if len(methods) == 0 {
+			return []string{"(none)"}
+		}
+		return methods
+	case LatencyByStatus:
+		return []string{strconv.Itoa(entry.StatusCode)}
+	default:
+		if entry.FQDN == "" {
+			return []string{"(none)"}
+		}
+		return []string{entry.FQDN}
+	}
+}
+
+func sumFloats(values []float64) float64 {
+	total := 0.0
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
@@ -0,0 +1,55 @@
+package api
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLatencyByGroup(t *testing.T) {
+	logs := []ErrorLog{
+		{Timestamp: "2026-02-26T10:05:00Z", FQDN: "a.example", RPCMethods: "eth_call", StatusCode: 500, TotalLatencyMs: 100, BackendLatencyMs: 90},
+		{Timestamp: "2026-02-26T10:20:00Z", FQDN: "a.example", RPCMethods: "eth_call,eth_getLogs", StatusCode: 500, TotalLatencyMs: 300, BackendLatencyMs: 250},
+		{Timestamp: "2026-02-26T11:10:00Z", FQDN: "a.example", RPCMethods: "eth_getLogs", StatusCode: 429, TotalLatencyMs: 200, BackendLatencyMs: 0},
+		{Timestamp: "2026-02-26T11:15:00Z", FQDN: "b.example", StatusCode: 502, TotalLatencyMs: 50, BackendLatencyMs: 60},
+	}
+
+	byFQDN := LatencyByGroup(logs, LatencyByFQDN, 0)
+	if len(byFQDN) != 2 || byFQDN[0].Group != "a.example" || byFQDN[0].Count != 3 {
+		t.Fatalf("unexpected fqdn groups: %+v", byFQDN)
+	}
+	a := byFQDN[0]
+	if a.P50Ms != 200 || a.P99Ms != 300 || a.MaxMs != 300 || a.BackendP50Ms != 90 || a.GatewayP50Ms != 50 {
+		t.Fatalf("unexpected percentiles: %+v", a)
+	}
+	// Gateway time is 10+50+200 of 600 ms.
+	if a.GatewayShare != 260.0/600 || a.GatewayDominant {
+		t.Fatalf("unexpected gateway share: %+v", a)
+	}
+	// Backend latency above total must not produce negative gateway time.
+	if b := byFQDN[1]; b.GatewayP50Ms != 0 || b.GatewayShare != 0 {
+		t.Fatalf("unexpected gateway figures for b.example: %+v", b)
+	}
+
+	byMethod := LatencyByGroup(logs, LatencyByMethod, 0)
+	counts := map[string]int{}
+	for _, g := range byMethod {
+		counts[g.Group] = g.Count
+	}
+	if counts["eth_call"] != 2 || counts["eth_getLogs"] != 2 || counts["(none)"] != 1 {
+		t.Fatalf("unexpected method groups: %+v", byMethod)
+	}
+
+	byStatus := LatencyByGroup(logs, LatencyByStatus, time.Hour)
+	if len(byStatus) != 3 {
+		t.Fatalf("expected 3 bucketed groups, got %+v", byStatus)
+	}
+	if byStatus[0].Bucket != "2026-02-26T10:00:00Z" || byStatus[0].Group != "500" || byStatus[0].Count != 2 {
+		t.Fatalf("unexpected first bucket: %+v", byStatus[0])
+	}
+	if byStatus[1].Bucket != "2026-02-26T11:00:00Z" || byStatus[2].Bucket != "2026-02-26T11:00:00Z" {
+		t.Fatalf("unexpected bucket order: %+v", byStatus)
+	}
+	if byStatus[1].Group != "429" || !byStatus[1].GatewayDominant {
+		t.Fatalf("expected the 429 group to be gateway dominated: %+v", byStatus[1])
+	}
+}
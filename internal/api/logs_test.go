@@ -1,10 +1,15 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
+	"time"
 )
 
 func TestLogsErrorsResponseWrapper(t *testing.T) {
@@ -43,7 +48,7 @@ func TestLogsErrorsResponseWrapper(t *testing.T) {
 	defer server.Close()
 
 	api := NewLogsAPI(NewClient(server.URL, "token"))
-	logs, err := api.Errors(map[string]interface{}{"api_key": "key-1", "limit": 5})
+	logs, err := api.Errors(context.Background(), ErrorLogFilter{APIKeys: []string{"key-1"}, Limit: 5})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -66,7 +71,7 @@ func TestLogsStatsEndpoint(t *testing.T) {
 	defer server.Close()
 
 	api := NewLogsAPI(NewClient(server.URL, "token"))
-	stats, err := api.Stats(map[string]interface{}{})
+	stats, err := api.Stats(context.Background(), ErrorLogFilter{})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -92,7 +97,7 @@ func TestLogsFacetsEndpoint(t *testing.T) {
 	defer server.Close()
 
 	api := NewLogsAPI(NewClient(server.URL, "token"))
-	facets, err := api.Facets(map[string]interface{}{})
+	facets, err := api.Facets(context.Background(), ErrorLogFilter{})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -103,3 +108,226 @@ func TestLogsFacetsEndpoint(t *testing.T) {
 		t.Fatalf("unexpected rpc facets: %+v", facets.RPCMethods)
 	}
 }
+
+func TestLogsTraceScansPagesForRequestID(t *testing.T) {
+	var requests []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		requests = append(requests, body)
+		page := map[string]interface{}{
+			"items":       []map[string]interface{}{{"request_id": "req-1"}, {"request_id": "req-2"}},
+			"has_more":    true,
+			"next_cursor": "page-2",
+		}
+		if body["cursor"] == "page-2" {
+			page = map[string]interface{}{
+				"items": []map[string]interface{}{
+					{"request_id": "req-3"},
+					{"request_id": "REQ-4", "error_message": "execution reverted", "backend_latency_ms": 40, "total_latency_ms": 55},
+				},
+				"has_more": false,
+			}
+		}
+		_ = json.NewEncoder(w).Encode(page)
+	}))
+	defer server.Close()
+
+	logs := NewLogsAPI(NewClient(server.URL, "token"))
+	start := time.Date(2026, 2, 26, 13, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	trace, scanned, err := logs.Trace(context.Background(), "req-4", start, end, ErrorLogFilter{FQDNs: []string{"example.com"}, Cursor: "stale"}, 5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if trace == nil || trace.ErrorMessage != "execution reverted" || trace.GatewayLatencyMs != 15 || scanned != 4 || trace.Scanned != 4 {
+		t.Fatalf("unexpected trace: %+v (scanned %d)", trace, scanned)
+	}
+	if len(requests) != 2 || requests[0]["cursor"] != nil || requests[0]["start_time"] != "2026-02-26T13:00:00Z" || requests[0]["end_time"] != "2026-02-26T14:00:00Z" {
+		t.Fatalf("unexpected requests: %v", requests)
+	}
+	if filter := requests[1]["filter"].(map[string]interface{}); filter["fqdns"] == nil {
+		t.Fatalf("expected the fqdn filter on every page, got %v", requests[1])
+	}
+
+	if trace, scanned, err := logs.Trace(context.Background(), "missing", start, end, ErrorLogFilter{}, 5); trace != nil || scanned != 4 || err != nil {
+		t.Fatalf("expected no match after 4 entries, got %+v %d %v", trace, scanned, err)
+	}
+	if _, _, err := logs.Trace(context.Background(), "missing", start, end, ErrorLogFilter{}, 1); !errors.Is(err, ErrTraceScanLimit) {
+		t.Fatalf("expected ErrTraceScanLimit, got %v", err)
+	}
+}
+
+func TestErrorLogTailDeduplicatesAndAdvances(t *testing.T) {
+	var (
+		requests []map[string]interface{}
+		served   []map[string]interface{}
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		requests = append(requests, body)
+		page := map[string]interface{}{"items": served, "has_more": false}
+		if len(served) > 2 && body["cursor"] == nil {
+			page = map[string]interface{}{"items": served[:2], "has_more": true, "next_cursor": "more"}
+		} else if len(served) > 2 {
+			page = map[string]interface{}{"items": served[2:], "has_more": false}
+		}
+		_ = json.NewEncoder(w).Encode(page)
+	}))
+	defer server.Close()
+
+	since := time.Date(2026, 2, 26, 12, 0, 0, 0, time.UTC)
+	tail := NewLogsAPI(NewClient(server.URL, "token")).Tail(ErrorLogFilter{Statuses: []StatusRange{{Min: 429, Max: 429}}, Cursor: "stale", To: "x"}, since)
+
+	served = []map[string]interface{}{
+		{"request_id": "b", "timestamp": "2026-02-26T12:10:00Z"},
+		{"request_id": "a", "timestamp": "2026-02-26T12:05:00Z"},
+	}
+	first, err := tail.Poll(context.Background())
+	if err != nil || len(first) != 2 || first[0].RequestID != "a" || first[1].RequestID != "b" {
+		t.Fatalf("expected a then b, got %+v (%v)", first, err)
+	}
+	if requests[0]["start_time"] != "2026-02-26T12:00:00Z" || requests[0]["cursor"] != nil || requests[0]["end_time"] != nil || requests[0]["order"] != "asc" {
+		t.Fatalf("unexpected first request: %v", requests[0])
+	}
+	if filter := requests[0]["filter"].(map[string]interface{}); filter["status_codes"] == nil {
+		t.Fatalf("expected the status filter, got %v", requests[0])
+	}
+
+	// The next window overlaps the last one by the ingest lag; repeats are
+	// dropped and entries spread over several pages are all returned.
+	served = []map[string]interface{}{
+		{"request_id": "d", "timestamp": "2026-02-26T12:11:30Z"},
+		{"request_id": "b", "timestamp": "2026-02-26T12:10:00Z"},
+		{"request_id": "c", "timestamp": "2026-02-26T12:09:45Z"},
+	}
+	second, err := tail.Poll(context.Background())
+	if err != nil || len(second) != 2 || second[0].RequestID != "c" || second[1].RequestID != "d" {
+		t.Fatalf("expected c then d, got %+v (%v)", second, err)
+	}
+	if requests[1]["start_time"] != "2026-02-26T12:09:00Z" || requests[2]["cursor"] != "more" {
+		t.Fatalf("unexpected follow-up requests: %v", requests[1:])
+	}
+
+	third, err := tail.Poll(context.Background())
+	if err != nil || len(third) != 0 {
+		t.Fatalf("expected nothing new, got %+v (%v)", third, err)
+	}
+	if requests[3]["start_time"] != "2026-02-26T12:10:30Z" {
+		t.Fatalf("expected the window to follow the newest entry, got %v", requests[3])
+	}
+}
+
+func TestErrorLogTailCatchesUpOnBursts(t *testing.T) {
+	since := time.Date(2026, 2, 26, 12, 0, 0, 0, time.UTC)
+	burst := tailMaxPages*traceLogsPageSize + 100
+	var starts []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		if body["order"] != "asc" {
+			t.Fatalf("expected the tail to read oldest first, got %v", body)
+		}
+		from, _ := time.Parse(time.RFC3339, body["start_time"].(string))
+		offset := int(from.Sub(since) / time.Second)
+		if cursor, ok := body["cursor"].(string); ok {
+			offset, _ = strconv.Atoi(cursor)
+		} else {
+			starts = append(starts, body["start_time"].(string))
+		}
+		end := min(offset+traceLogsPageSize, burst)
+		items := []map[string]interface{}{}
+		for i := offset; i < end; i++ {
+			items = append(items, map[string]interface{}{
+				"request_id": strconv.Itoa(i),
+				"timestamp":  since.Add(time.Duration(i) * time.Second).Format(time.RFC3339),
+			})
+		}
+		page := map[string]interface{}{"items": items, "has_more": end < burst}
+		if end < burst {
+			page["next_cursor"] = strconv.Itoa(end)
+		}
+		_ = json.NewEncoder(w).Encode(page)
+	}))
+	defer server.Close()
+
+	tail := NewLogsAPI(NewClient(server.URL, "token")).Tail(ErrorLogFilter{}, since)
+	received := 0
+	for poll := 0; poll < 2; poll++ {
+		entries, err := tail.Poll(context.Background())
+		if err != nil {
+			t.Fatalf("Poll: %v", err)
+		}
+		for _, entry := range entries {
+			if entry.RequestID != strconv.Itoa(received) {
+				t.Fatalf("expected entry %d, got %s", received, entry.RequestID)
+			}
+			received++
+		}
+	}
+	if received != burst {
+		t.Fatalf("expected all %d entries of the burst, got %d", burst, received)
+	}
+	// The second poll resumes at the newest entry read rather than skipping
+	// ahead.
+	if len(starts) != 2 || starts[1] != since.Add(time.Duration(tailMaxPages*traceLogsPageSize-1)*time.Second).Format(time.RFC3339) {
+		t.Fatalf("unexpected poll windows: %v", starts)
+	}
+}
+
+func TestLogsWalkErrorsFollowsCursorsUpToMaxRows(t *testing.T) {
+	var requests []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		requests = append(requests, body)
+		offset := 0
+		if cursor, ok := body["cursor"].(string); ok {
+			offset, _ = strconv.Atoi(cursor)
+		}
+		size := int(body["page_size"].(float64))
+		var items []map[string]interface{}
+		for i := offset; i < min(offset+size, 7); i++ {
+			items = append(items, map[string]interface{}{"request_id": fmt.Sprintf("req-%d", i)})
+		}
+		next := offset + len(items)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"items": items, "has_more": next < 7, "next_cursor": strconv.Itoa(next)})
+	}))
+	defer server.Close()
+	logs := NewLogsAPI(NewClient(server.URL, "token"))
+
+	var ids []string
+	collect := func(page *ErrorLogPage) error {
+		for _, item := range page.Items {
+			ids = append(ids, item.RequestID)
+		}
+		return nil
+	}
+	last, err := logs.WalkErrors(context.Background(), ErrorLogFilter{Limit: 3}, 0, collect)
+	if err != nil || len(ids) != 7 || last.HasMore || len(requests) != 3 {
+		t.Fatalf("expected all 7 rows over 3 pages, got %v (last %+v, %d requests, err %v)", ids, last, len(requests), err)
+	}
+
+	ids, requests = nil, nil
+	last, err = logs.WalkErrors(context.Background(), ErrorLogFilter{Limit: 3}, 5, collect)
+	if err != nil || len(ids) != 5 || !last.HasMore || last.NextCursor != "5" {
+		t.Fatalf("expected 5 rows and a cursor at 5, got %v (last %+v, err %v)", ids, last, err)
+	}
+	if size := requests[1]["page_size"]; size != float64(2) {
+		t.Fatalf("expected the last page to shrink to 2, got %v", size)
+	}
+
+	ids = nil
+	if _, err := logs.WalkErrors(context.Background(), ErrorLogFilter{Cursor: last.NextCursor}, 0, collect); err != nil || len(ids) != 2 || ids[0] != "req-5" {
+		t.Fatalf("expected to resume at req-5, got %v (%v)", ids, err)
+	}
+}
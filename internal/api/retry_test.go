@@ -0,0 +1,133 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func fastRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		MaxElapsed:  time.Second,
+		BaseDelay:   time.Millisecond,
+		MaxDelay:    5 * time.Millisecond,
+	}
+}
+
+func TestClientRetriesServiceUnavailableForGet(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+	}))
+	defer server.Close()
+
+	var events []RetryEvent
+	client := NewClient(server.URL, "token")
+	client.Retry = fastRetryPolicy()
+	client.OnRetry = func(event RetryEvent) {
+		events = append(events, event)
+	}
+
+	var result map[string]string
+	if err := client.Get(context.Background(), "/v4/user", nil, &result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result["status"] != "ok" {
+		t.Fatalf("unexpected result: %v", result)
+	}
+	if len(events) != 2 || events[0].StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("expected 2 retry events for 503, got %+v", events)
+	}
+}
+
+func TestClientDoesNotRetryPlainPostOnServerError(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "token")
+	client.Retry = fastRetryPolicy()
+
+	err := client.Post(context.Background(), "/v4/organization/apikeys", map[string]string{"name": "x"}, nil)
+	if err == nil {
+		t.Fatal("expected error for 502 response")
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Fatalf("expected a single attempt for non-idempotent POST, got %d", got)
+	}
+}
+
+func TestClientRetriesIdempotentPostWithBody(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body["interval"] != "day" {
+			t.Errorf("expected replayed body, got %v (err %v)", body, err)
+		}
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusGatewayTimeout)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]bool{"ok": true})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "token")
+	client.Retry = fastRetryPolicy()
+
+	var result map[string]bool
+	if err := client.PostIdempotent(context.Background(), "/v4/organization/analytics", map[string]string{"interval": "day"}, &result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Fatalf("expected 2 attempts, got %d", got)
+	}
+}
+
+func TestClientRetryHonorsRetryAfterBudget(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Retry-After", "120")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "token")
+	client.Retry = fastRetryPolicy()
+
+	err := client.Post(context.Background(), "/v4/organization/apikeys", nil, nil)
+	apiErr, ok := err.(*APIError)
+	if !ok || apiErr.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("expected 429 APIError, got %v", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Fatalf("expected Retry-After beyond budget to stop retries, got %d attempts", got)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	if d, ok := parseRetryAfter("7", now); !ok || d != 7*time.Second {
+		t.Fatalf("parseRetryAfter(seconds) = %v, %v", d, ok)
+	}
+	date := now.Add(90 * time.Second).Format(http.TimeFormat)
+	if d, ok := parseRetryAfter(date, now); !ok || d != 90*time.Second {
+		t.Fatalf("parseRetryAfter(date) = %v, %v", d, ok)
+	}
+	if _, ok := parseRetryAfter("soon", now); ok {
+		t.Fatal("expected invalid Retry-After to be rejected")
+	}
+}
@@ -0,0 +1,219 @@
+package api
+
+import (
+	"sort"
+	"strings"
+)
+
+// Relevance scores for search matches, highest first.
+const (
+	scoreExact     = 100
+	scoreAlias     = 95
+	scorePrefix    = 80
+	scoreToken     = 70
+	scoreTokenPre  = 60
+	scoreSubstring = 50
+	scoreFuzzy     = 40
+	scoreEcosystem = 30
+	scoreNodeType  = 30
+)
+
+// chainAliases maps common tickers and short names to the chain slug they
+// refer to. A chain matches an alias when its slug equals the target or starts
+// with "<target>-" (so "arb" finds both Arbitrum One and Arbitrum Nova).
+var chainAliases = map[string]string{
+	"eth":   "ethereum",
+	"matic": "polygon",
+	"pol":   "polygon",
+	"op":    "optimism",
+	"arb":   "arbitrum",
+	"bsc":   "bnb",
+	"bnb":   "bnb",
+	"avax":  "avalanche",
+	"ftm":   "fantom",
+	"xdai":  "gnosis",
+	"dot":   "polkadot",
+	"ksm":   "kusama",
+	"atom":  "cosmos",
+	"sol":   "solana",
+	"trx":   "tron",
+	"hype":  "hyperliquid",
+}
+
+// rankChains scores chains against query and returns the matching ones, best
+// first. A chain-level match keeps every network; otherwise only matching
+// networks (or the nodes of a matching node type) are kept. Ties keep the
+// catalog order.
+func rankChains(chains []Chain, query string) []Chain {
+	q := normalizeChainLookup(query)
+	if q == "" {
+		return chains
+	}
+
+	var ranked []Chain
+	for _, chain := range chains {
+		chainSlug := normalizeChainLookup(chain.Name)
+		score := chainScore(q, chain)
+
+		networks := chain.Networks
+		if score == 0 {
+			networks = nil
+			for _, net := range chain.Networks {
+				netSlug := normalizeChainLookup(net.Name)
+				netScore := max(
+					matchScore(q, netSlug),
+					matchScore(q, chainSlug+"-"+strings.TrimPrefix(netSlug, chainSlug+"-")),
+				)
+				if netScore > 0 {
+					networks = append(networks, net)
+					score = max(score, netScore)
+					continue
+				}
+				if nodes := nodesOfType(net.Nodes, q); len(nodes) > 0 {
+					net.Nodes = nodes
+					networks = append(networks, net)
+					score = max(score, scoreNodeType)
+				}
+			}
+		}
+		if score == 0 || len(networks) == 0 {
+			continue
+		}
+		chain.Networks = networks
+		chain.Score = score
+		ranked = append(ranked, chain)
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool { return ranked[i].Score > ranked[j].Score })
+	return ranked
+}
+
+func chainScore(q string, chain Chain) int {
+	chainSlug := normalizeChainLookup(chain.Name)
+	score := matchScore(q, chainSlug)
+	if target, ok := chainAliases[q]; ok && (chainSlug == target || strings.HasPrefix(chainSlug, target+"-")) {
+		score = max(score, scoreAlias)
+	}
+	if q == normalizeChainLookup(chain.Ecosystem) {
+		score = max(score, scoreEcosystem)
+	}
+	return score
+}
+
+func nodesOfType(nodes []Node, q string) []Node {
+	var matched []Node
+	for _, node := range nodes {
+		if q == normalizeChainLookup(node.NodeType.Name) {
+			matched = append(matched, node)
+		}
+	}
+	return matched
+}
+
+// matchScore rates how well the normalized query q matches slug: exact,
+// prefix, whole dash-separated token, token prefix, substring, and finally a
+// typo-tolerant edit distance. It returns 0 for no match.
+func matchScore(q, slug string) int {
+	switch {
+	case slug == "":
+		return 0
+	case q == slug:
+		return scoreExact
+	case strings.HasPrefix(slug, q):
+		return scorePrefix
+	}
+
+	tokens := strings.Split(slug, "-")
+	best := 0
+	for _, token := range tokens {
+		switch {
+		case token == q:
+			best = max(best, scoreToken)
+		case strings.HasPrefix(token, q):
+			best = max(best, scoreTokenPre)
+		}
+	}
+	if best > 0 {
+		return best
+	}
+	if strings.Contains(slug, q) {
+		return scoreSubstring
+	}
+
+	// Typos: allow one edit per four characters, and never on very short queries
+	// where almost anything would be within reach.
+	if len(q) < 4 {
+		return 0
+	}
+	// A near miss of the whole slug ranks above a near miss of one of its tokens.
+	limit := max(1, len(q)/4)
+	if distance := editDistance(q, slug); distance <= limit {
+		return scoreFuzzy - 5*(distance-1)
+	}
+	tokenDistance := limit + 1
+	for _, token := range tokens {
+		tokenDistance = min(tokenDistance, editDistance(q, token))
+	}
+	if tokenDistance > limit {
+		return 0
+	}
+	return scoreFuzzy - 5*(tokenDistance-1) - 2
+}
+
+// editDistance is the optimal string alignment distance between a and b:
+// insertions, deletions, substitutions, and adjacent transpositions each cost 1.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev2 := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				cur[j] = min(cur[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+	return prev[len(rb)]
+}
+
+// SuggestChains returns up to limit chain slugs that resemble lookup, best
+// first, for "did you mean" hints. A chain whose slug is lookup itself is not
+// suggested.
+func SuggestChains(chains []Chain, lookup string, limit int) []string {
+	q := normalizeChainLookup(lookup)
+	if q == "" || limit <= 0 {
+		return nil
+	}
+	type candidate struct {
+		name  string
+		score int
+	}
+	var candidates []candidate
+	for _, chain := range chains {
+		slug := normalizeChainLookup(chain.Name)
+		if score := chainScore(q, chain); score > scoreEcosystem && slug != q {
+			candidates = append(candidates, candidate{slug, score})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].score > candidates[j].score })
+
+	var names []string
+	for _, c := range candidates {
+		if len(names) == limit {
+			break
+		}
+		names = append(names, c.name)
+	}
+	return names
+}
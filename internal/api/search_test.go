@@ -0,0 +1,117 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+func searchCatalog() []Chain {
+	node := func(nodeType string) Node {
+		return Node{HTTPS: "https://" + nodeType + ".example", NodeType: NodeType{Name: nodeType}}
+	}
+	return []Chain{
+		{Name: "Ethereum Classic", Ecosystem: "evm", Networks: []Network{{Name: "Mainnet", Nodes: []Node{node("full")}}}},
+		{Name: "Arbitrum Nova", Ecosystem: "evm", Networks: []Network{{Name: "Mainnet", Nodes: []Node{node("full")}}}},
+		{Name: "Ethereum", Ecosystem: "evm", Networks: []Network{
+			{Name: "Mainnet", Nodes: []Node{node("full"), node("archive")}},
+			{Name: "Sepolia Testnet", Nodes: []Node{node("full")}},
+		}},
+		{Name: "Arbitrum One", Ecosystem: "evm", Networks: []Network{{Name: "Mainnet", Nodes: []Node{node("archive")}}}},
+		{Name: "Polygon", Ecosystem: "evm", Networks: []Network{{Name: "Amoy", Nodes: []Node{node("full")}}}},
+		{Name: "Polkadot", Ecosystem: "substrate", Networks: []Network{{Name: "Polkadot", Nodes: []Node{node("full")}}}},
+	}
+}
+
+func chainNames(chains []Chain) []string {
+	var names []string
+	for _, chain := range chains {
+		names = append(names, chain.Name)
+	}
+	return names
+}
+
+func TestRankChains(t *testing.T) {
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"ethereum", []string{"Ethereum", "Ethereum Classic"}},
+		{"eth", []string{"Ethereum Classic", "Ethereum"}},
+		{"arb", []string{"Arbitrum Nova", "Arbitrum One"}},
+		{"matic", []string{"Polygon"}},
+		{"etherum", []string{"Ethereum", "Ethereum Classic"}},
+		{"polkdot", []string{"Polkadot"}},
+		{"one", []string{"Arbitrum One"}},
+		{"sepolia", []string{"Ethereum"}},
+		{"polygon amoy", []string{"Polygon"}},
+		{"substrate", []string{"Polkadot"}},
+		{"xyz", nil},
+	}
+	for _, tc := range tests {
+		got := chainNames(rankChains(searchCatalog(), tc.query))
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("rankChains(%q) = %v, want %v", tc.query, got, tc.want)
+		}
+	}
+}
+
+func TestRankChainsScoresAndNarrowing(t *testing.T) {
+	ranked := rankChains(searchCatalog(), "ethereum")
+	if ranked[0].Score != scoreExact || ranked[1].Score != scorePrefix {
+		t.Fatalf("unexpected scores: %d, %d", ranked[0].Score, ranked[1].Score)
+	}
+	if len(ranked[0].Networks) != 2 {
+		t.Fatalf("chain match should keep every network, got %+v", ranked[0].Networks)
+	}
+
+	sepolia := rankChains(searchCatalog(), "sepolia")
+	if len(sepolia[0].Networks) != 1 || sepolia[0].Networks[0].Name != "Sepolia Testnet" {
+		t.Fatalf("network match should keep only that network, got %+v", sepolia[0].Networks)
+	}
+
+	archive := rankChains(searchCatalog(), "archive")
+	if got := chainNames(archive); !reflect.DeepEqual(got, []string{"Ethereum", "Arbitrum One"}) {
+		t.Fatalf("node type search = %v", got)
+	}
+	if nodes := archive[0].Networks[0].Nodes; len(nodes) != 1 || nodes[0].NodeType.Name != "archive" {
+		t.Fatalf("node type match should keep only archive nodes, got %+v", nodes)
+	}
+
+	if all := rankChains(searchCatalog(), ""); len(all) != 6 || all[0].Score != 0 {
+		t.Fatalf("empty query should return the catalog unranked, got %v", chainNames(all))
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"ethereum", "ethereum", 0},
+		{"etherum", "ethereum", 1},
+		{"etehreum", "ethereum", 1},
+		{"polkdot", "polkadot", 1},
+		{"base", "bsc", 2},
+		{"", "abc", 3},
+	}
+	for _, tc := range tests {
+		if got := editDistance(tc.a, tc.b); got != tc.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
+
+func TestSuggestChains(t *testing.T) {
+	if got := SuggestChains(searchCatalog(), "etherium", 3); !reflect.DeepEqual(got, []string{"ethereum", "ethereum-classic"}) {
+		t.Fatalf("SuggestChains(etherium) = %v", got)
+	}
+	if got := SuggestChains(searchCatalog(), "arb", 1); !reflect.DeepEqual(got, []string{"arbitrum-nova"}) {
+		t.Fatalf("SuggestChains(arb) = %v", got)
+	}
+	if got := SuggestChains(searchCatalog(), "ethereum", 3); !reflect.DeepEqual(got, []string{"ethereum-classic"}) {
+		t.Fatalf("exact lookup should not suggest itself, got %v", got)
+	}
+	if got := SuggestChains(searchCatalog(), "evm", 3); got != nil {
+		t.Fatalf("ecosystem-only matches should not be suggested, got %v", got)
+	}
+}
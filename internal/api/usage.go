@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"sort"
 	"strings"
@@ -117,13 +118,13 @@ func NewUsageAPI(client *Client) *UsageAPI {
 	return &UsageAPI{client: client}
 }
 
-func (u *UsageAPI) Summary() (*UsageSummary, error) {
+func (u *UsageAPI) Summary(ctx context.Context) (*UsageSummary, error) {
 	var summary UsageSummary
-	err := u.client.Get("/v4/organization/analytics/monthly_summary", nil, &summary)
+	err := u.client.Get(ctx, "/v4/organization/analytics/monthly_summary", nil, &summary)
 	return &summary, err
 }
 
-func (u *UsageAPI) History(interval string, from string, to string, apiKey string, fqdn string, method string) ([]UsageHistory, error) {
+func (u *UsageAPI) History(ctx context.Context, interval string, from string, to string, apiKey string, fqdn string, method string) ([]UsageHistory, error) {
 	body := analyticsRequest{
 		Interval: interval,
 		Limit:    1000,
@@ -150,7 +151,7 @@ func (u *UsageAPI) History(interval string, from string, to string, apiKey strin
 	maxRows := 50000
 	for {
 		var page []UsageHistory
-		if err := u.client.Post("/v4/organization/analytics", body, &page); err != nil {
+		if err := u.client.PostIdempotent(ctx, "/v4/organization/analytics", body, &page); err != nil {
 			return nil, err
 		}
 		history = append(history, page...)
@@ -256,15 +257,15 @@ func intervalSeconds(interval string) float64 {
 	}
 }
 
-func (u *UsageAPI) RPS(interval string, from string, to string, apiKey string, fqdn string) ([]RPSData, error) {
-	history, err := u.History(interval, from, to, apiKey, fqdn, "")
+func (u *UsageAPI) RPS(ctx context.Context, interval string, from string, to string, apiKey string, fqdn string) ([]RPSData, error) {
+	history, err := u.History(ctx, interval, from, to, apiKey, fqdn, "")
 	if err != nil {
 		return nil, err
 	}
 	return BuildRPSTimeSeries(history, interval), nil
 }
 
-func (u *UsageAPI) OrganizationRPS(interval string, from string, to string, apiKey string, fqdn string) (*OrganizationRPS, error) {
+func (u *UsageAPI) OrganizationRPS(ctx context.Context, interval string, from string, to string, apiKey string, fqdn string) (*OrganizationRPS, error) {
 	body := analyticsRequest{
 		Interval: interval,
 	}
@@ -286,7 +287,7 @@ func (u *UsageAPI) OrganizationRPS(interval string, from string, to string, apiK
 	}
 
 	var stats OrganizationRPS
-	if err := u.client.Post("/v4/organization/analytics/rps", body, &stats); err != nil {
+	if err := u.client.PostIdempotent(ctx, "/v4/organization/analytics/rps", body, &stats); err != nil {
 		return nil, err
 	}
 	return &stats, nil
@@ -311,40 +312,40 @@ func UsageTimestamp(row UsageHistory) string {
 	return row.Timestamp
 }
 
-func (u *UsageAPI) MethodBreakdown(interval string, from string, to string, apiKey string, fqdn string) ([]UsageBreakdown, error) {
-	history, err := u.History(interval, from, to, apiKey, fqdn, "")
+func (u *UsageAPI) MethodBreakdown(ctx context.Context, interval string, from string, to string, apiKey string, fqdn string) ([]UsageBreakdown, error) {
+	history, err := u.History(ctx, interval, from, to, apiKey, fqdn, "")
 	if err != nil {
 		return nil, err
 	}
 	return BuildUsageBreakdown(history, UsageMethod), nil
 }
 
-func (u *UsageAPI) EndpointBreakdown(interval string, from string, to string, apiKey string, fqdn string, method string) ([]UsageBreakdown, error) {
-	history, err := u.History(interval, from, to, apiKey, fqdn, method)
+func (u *UsageAPI) EndpointBreakdown(ctx context.Context, interval string, from string, to string, apiKey string, fqdn string, method string) ([]UsageBreakdown, error) {
+	history, err := u.History(ctx, interval, from, to, apiKey, fqdn, method)
 	if err != nil {
 		return nil, err
 	}
 	return BuildUsageBreakdown(history, UsageDomain), nil
 }
 
-func (u *UsageAPI) APIKeyBreakdown(interval string, from string, to string, fqdn string, method string) ([]UsageBreakdown, error) {
-	history, err := u.History(interval, from, to, "", fqdn, method)
+func (u *UsageAPI) APIKeyBreakdown(ctx context.Context, interval string, from string, to string, fqdn string, method string) ([]UsageBreakdown, error) {
+	history, err := u.History(ctx, interval, from, to, "", fqdn, method)
 	if err != nil {
 		return nil, err
 	}
 	return BuildUsageBreakdown(history, UsageAPIKey), nil
 }
 
-func (u *UsageAPI) TimeBreakdown(interval string, from string, to string, apiKey string, fqdn string, method string) ([]UsageBreakdown, error) {
-	history, err := u.History(interval, from, to, apiKey, fqdn, method)
+func (u *UsageAPI) TimeBreakdown(ctx context.Context, interval string, from string, to string, apiKey string, fqdn string, method string) ([]UsageBreakdown, error) {
+	history, err := u.History(ctx, interval, from, to, apiKey, fqdn, method)
 	if err != nil {
 		return nil, err
 	}
 	return BuildUsageBreakdown(history, UsageTimestamp), nil
 }
 
-func (u *UsageAPI) RawHistory(interval string, from string, to string, apiKey string, fqdn string, method string) ([]UsageHistory, error) {
-	return u.History(interval, from, to, apiKey, fqdn, method)
+func (u *UsageAPI) RawHistory(ctx context.Context, interval string, from string, to string, apiKey string, fqdn string, method string) ([]UsageHistory, error) {
+	return u.History(ctx, interval, from, to, apiKey, fqdn, method)
 }
 
 func CurrentBillingCycleRange(now time.Time, currentSub *CurrentSubscriptionWindow) (time.Time, time.Time) {
@@ -0,0 +1,355 @@
+package api
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dwellir-public/cli/internal/stats"
+)
+
+// Anomaly scoring methods.
+const (
+	AnomalyMAD    = "mad"
+	AnomalyZScore = "zscore"
+)
+
+// Anomaly kinds.
+const (
+	AnomalySpike = "spike"
+	AnomalyDrop  = "drop"
+)
+
+// Usage dimensions whose series are scanned for anomalies.
+const (
+	DimensionAPIKey   = "api_key"
+	DimensionEndpoint = "endpoint"
+	DimensionMethod   = "method"
+)
+
+// UsageDimensions lists the dimensions in report order.
+var UsageDimensions = []string{DimensionAPIKey, DimensionEndpoint, DimensionMethod}
+
+const (
+	// madScale makes the median absolute deviation comparable to a standard
+	// deviation for normally distributed data.
+	madScale = 1.4826
+	// anomalyMinBaseline is the fewest earlier buckets a score is based on.
+	anomalyMinBaseline = 3
+	// anomalyMinSpreadShare is the smallest spread assumed, as a share of the
+	// baseline, so a perfectly flat history does not turn small wiggles into
+	// anomalies.
+	anomalyMinSpreadShare = 0.1
+	// anomalyInvolvedTop is how many groups of the other dimensions are listed
+	// with an anomaly.
+	anomalyInvolvedTop = 3
+)
+
+// DefaultAnomalyThreshold returns the customary cutoff of a scoring method:
+// a modified z-score of 3.5 for MAD and a z-score of 3 otherwise.
+func DefaultAnomalyThreshold(method string) float64 {
+	if method == AnomalyMAD {
+		return 3.5
+	}
+	return 3
+}
+
+// UsageAnomalyOptions configures DetectUsageAnomalies.
+type UsageAnomalyOptions struct {
+	// Interval is the bucket size, "hour" or "day".
+	Interval string
+	// Method is AnomalyMAD or AnomalyZScore.
+	Method string
+	// Dimensions are the dimensions to scan; empty means all of them.
+	Dimensions []string
+	// Baseline is how many preceding buckets each bucket is compared with.
+	Baseline int
+	// Threshold is the absolute score at which a bucket is anomalous.
+	Threshold float64
+	// Kind limits the report to AnomalySpike or AnomalyDrop; empty reports
+	// both.
+	Kind string
+	// MinResponses skips buckets where neither the observed responses nor
+	// the baseline reach it, to ignore noise in quiet series.
+	MinResponses int
+	// HistoryStart is where the history begins; buckets before it are
+	// unknown rather than empty.
+	HistoryStart time.Time
+	// From and To bound the scanned window. Earlier history only feeds
+	// the baselines, and buckets not complete by To are skipped.
+	From, To time.Time
+}
+
+// UsageAnomalyReport lists the anomalies found in a usage window.
+type UsageAnomalyReport struct {
+	Interval    string         `json:"interval"`
+	Method      string         `json:"method"`
+	Threshold   float64        `json:"threshold"`
+	Baseline    int            `json:"baseline_buckets"`
+	WindowStart string         `json:"window_start"`
+	WindowEnd   string         `json:"window_end"`
+	Series      int            `json:"series_scanned"`
+	Spikes      int            `json:"spikes"`
+	Drops       int            `json:"drops"`
+	Anomalies   []UsageAnomaly `json:"anomalies"`
+}
+
+// UsageAnomaly is a run of consecutive buckets in which one group's
+// responses spiked above or dropped below its rolling baseline. Expected is
+// the baseline summed over the run, Change the relative difference from it,
+// and Score the most extreme bucket's score. The API keys, endpoints, and
+// methods that carried the group's responses during the run are listed.
+type UsageAnomaly struct {
+	Dimension   string   `json:"dimension"`
+	Group       string   `json:"group"`
+	Kind        string   `json:"kind"`
+	WindowStart string   `json:"window_start"`
+	WindowEnd   string   `json:"window_end"`
+	Buckets     int      `json:"buckets"`
+	Responses   int      `json:"responses"`
+	Expected    int      `json:"expected"`
+	Change      *float64 `json:"change,omitempty"`
+	Score       float64  `json:"score"`
+	APIKeys     []string `json:"api_keys,omitempty"`
+	Endpoints   []string `json:"endpoints,omitempty"`
+	Methods     []string `json:"methods,omitempty"`
+}
+
+// anomalySeries is one group's responses per bucket.
+type anomalySeries struct {
+	dimension string
+	group     string
+	values    []float64
+	rows      []UsageHistory
+}
+
+// anomalyRun accumulates consecutive anomalous buckets of a series.
+type anomalyRun struct {
+	kind      string
+	start     int
+	end       int
+	observed  float64
+	expected  float64
+	peakScore float64
+}
+
+// DetectUsageAnomalies splits history into one series per group of each
+// dimension and scores every complete bucket of the scanned window against
+// the buckets before it: with MAD as (x - median) / (1.4826 * MAD), with
+// z-scores as (x - mean) / standard deviation. The spread is at least the
+// Poisson noise of the baseline and a tenth of it. Buckets scoring beyond
+// the threshold are spikes or drops, and consecutive ones are merged.
+func DetectUsageAnomalies(history []UsageHistory, opts UsageAnomalyOptions) UsageAnomalyReport {
+	step := time.Hour
+	if opts.Interval == "day" {
+		step = 24 * time.Hour
+	}
+	if opts.Threshold <= 0 {
+		opts.Threshold = DefaultAnomalyThreshold(opts.Method)
+	}
+	if len(opts.Dimensions) == 0 {
+		opts.Dimensions = UsageDimensions
+	}
+
+	scanStart := opts.From.UTC().Truncate(step)
+	gridStart := scanStart.Add(-time.Duration(opts.Baseline) * step)
+	known := 0
+	if opts.HistoryStart.After(gridStart) {
+		known = int(opts.HistoryStart.UTC().Sub(gridStart) / step)
+		if opts.HistoryStart.UTC().Truncate(step).Before(opts.HistoryStart) {
+			// A partly fetched bucket is not a fair baseline.
+			known++
+		}
+	}
+	buckets := int(opts.To.UTC().Sub(gridStart) / step)
+	first := int(scanStart.Sub(gridStart) / step)
+
+	report := UsageAnomalyReport{
+		Interval:    opts.Interval,
+		Method:      opts.Method,
+		Threshold:   opts.Threshold,
+		Baseline:    opts.Baseline,
+		WindowStart: scanStart.Format(time.RFC3339),
+		WindowEnd:   gridStart.Add(time.Duration(max(buckets, first)) * step).Format(time.RFC3339),
+		Anomalies:   []UsageAnomaly{},
+	}
+	if buckets <= first {
+		return report
+	}
+
+	series := buildAnomalySeries(history, opts.Dimensions, gridStart, step, buckets, first)
+	report.Series = len(series)
+	for _, s := range series {
+		var run *anomalyRun
+		flush := func() {
+			if run != nil {
+				report.Anomalies = append(report.Anomalies, newUsageAnomaly(s, run, gridStart, step))
+				run = nil
+			}
+		}
+		for i := first; i < buckets; i++ {
+			baseline := s.values[min(max(known, i-opts.Baseline), i):i]
+			if len(baseline) < anomalyMinBaseline {
+				flush()
+				continue
+			}
+			center, spread := anomalyBaseline(baseline, opts.Method)
+			x := s.values[i]
+			if math.Max(x, center) < float64(opts.MinResponses) {
+				flush()
+				continue
+			}
+			score := (x - center) / spread
+			kind := ""
+			switch {
+			case score >= opts.Threshold:
+				kind = AnomalySpike
+			case score <= -opts.Threshold:
+				kind = AnomalyDrop
+			}
+			if opts.Kind != "" && kind != opts.Kind {
+				kind = ""
+			}
+			if run != nil && run.kind != kind {
+				flush()
+			}
+			if kind == "" {
+				continue
+			}
+			if run == nil {
+				run = &anomalyRun{kind: kind, start: i}
+			}
+			run.end = i
+			run.observed += x
+			run.expected += center
+			if math.Abs(score) > math.Abs(run.peakScore) {
+				run.peakScore = score
+			}
+		}
+		flush()
+	}
+
+	for _, anomaly := range report.Anomalies {
+		if anomaly.Kind == AnomalySpike {
+			report.Spikes++
+		} else {
+			report.Drops++
+		}
+	}
+	sort.SliceStable(report.Anomalies, func(i, j int) bool {
+		return report.Anomalies[i].WindowStart < report.Anomalies[j].WindowStart
+	})
+	return report
+}
+
+// anomalyBaseline returns the center and spread of a baseline window.
+func anomalyBaseline(values []float64, method string) (center, spread float64) {
+	if method == AnomalyMAD {
+		median, mad := stats.MedianAbsDeviation(values)
+		center, spread = median, madScale*mad
+	} else {
+		center, spread = stats.Mean(values), stats.StdDev(values)
+	}
+	spread = math.Max(spread, math.Sqrt(center))
+	spread = math.Max(spread, anomalyMinSpreadShare*center)
+	return center, math.Max(spread, 1)
+}
+
+// buildAnomalySeries buckets responses per group of each dimension, keeping
+// the rows of the scanned window to describe anomalies with. Series come in
+// dimension order and by group.
+func buildAnomalySeries(history []UsageHistory, dimensions []string, gridStart time.Time, step time.Duration, buckets, first int) []*anomalySeries {
+	var out []*anomalySeries
+	for _, dimension := range dimensions {
+		keyFn := anomalyDimensionKey(dimension)
+		if keyFn == nil {
+			continue
+		}
+		byGroup := map[string]*anomalySeries{}
+		for _, row := range history {
+			ts, err := time.Parse(time.RFC3339, row.Timestamp)
+			if err != nil || ts.Before(gridStart) {
+				continue
+			}
+			i := int(ts.Sub(gridStart) / step)
+			if i >= buckets {
+				continue
+			}
+			group := strings.TrimSpace(keyFn(row))
+			if group == "" {
+				group = "unknown"
+			}
+			s, ok := byGroup[group]
+			if !ok {
+				s = &anomalySeries{dimension: dimension, group: group, values: make([]float64, buckets)}
+				byGroup[group] = s
+			}
+			s.values[i] += float64(row.Responses)
+			if i >= first {
+				s.rows = append(s.rows, row)
+			}
+		}
+		groups := make([]*anomalySeries, 0, len(byGroup))
+		for _, s := range byGroup {
+			groups = append(groups, s)
+		}
+		sort.Slice(groups, func(i, j int) bool { return groups[i].group < groups[j].group })
+		out = append(out, groups...)
+	}
+	return out
+}
+
+func anomalyDimensionKey(dimension string) func(UsageHistory) string {
+	switch dimension {
+	case DimensionAPIKey:
+		return UsageAPIKey
+	case DimensionEndpoint:
+		return UsageDomain
+	case DimensionMethod:
+		return UsageMethod
+	}
+	return nil
+}
+
+func newUsageAnomaly(s *anomalySeries, run *anomalyRun, gridStart time.Time, step time.Duration) UsageAnomaly {
+	start := gridStart.Add(time.Duration(run.start) * step)
+	end := gridStart.Add(time.Duration(run.end+1) * step)
+	anomaly := UsageAnomaly{
+		Dimension:   s.dimension,
+		Group:       s.group,
+		Kind:        run.kind,
+		WindowStart: start.Format(time.RFC3339),
+		WindowEnd:   end.Format(time.RFC3339),
+		Buckets:     run.end - run.start + 1,
+		Responses:   int(math.Round(run.observed)),
+		Expected:    int(math.Round(run.expected)),
+		Score:       math.Round(run.peakScore*100) / 100,
+	}
+	if run.expected > 0 {
+		change := math.Round((run.observed-run.expected)/run.expected*1000) / 1000
+		anomaly.Change = &change
+	}
+
+	var rows []UsageHistory
+	for _, row := range s.rows {
+		if ts, err := time.Parse(time.RFC3339, row.Timestamp); err == nil && !ts.Before(start) && ts.Before(end) {
+			rows = append(rows, row)
+		}
+	}
+	anomaly.APIKeys = topAnomalyGroups(rows, UsageAPIKey)
+	anomaly.Endpoints = topAnomalyGroups(rows, UsageDomain)
+	anomaly.Methods = topAnomalyGroups(rows, UsageMethod)
+	return anomaly
+}
+
+// topAnomalyGroups names the groups that carried the most responses.
+func topAnomalyGroups(rows []UsageHistory, keyFn func(UsageHistory) string) []string {
+	var groups []string
+	for _, entry := range topUsage(BuildUsageBreakdown(rows, keyFn), anomalyInvolvedTop) {
+		if entry.Responses > 0 && entry.Group != "unknown" {
+			groups = append(groups, entry.Group)
+		}
+	}
+	return groups
+}
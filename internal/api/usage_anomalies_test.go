@@ -0,0 +1,88 @@
+package api
+
+import (
+	"testing"
+	"time"
+)
+
+func anomalyTestHistory(start time.Time) []UsageHistory {
+	var rows []UsageHistory
+	for h := 0; h < 49; h++ {
+		at := start.Add(time.Duration(h) * time.Hour).Format(time.RFC3339)
+		runaway, indexer, dev := 200+h%3*10, 5000, 5
+		switch {
+		case h >= 30 && h < 34:
+			runaway = 20000
+		case h == 40 || h == 41:
+			indexer = 0
+		case h == 44:
+			dev = 50
+		}
+		rows = append(rows,
+			UsageHistory{Timestamp: at, APIKeyName: "steady", Domain: "a.example", Method: "eth_call", Requests: 1000 + h%5*20, Responses: 1000 + h%5*20},
+			UsageHistory{Timestamp: at, APIKeyName: "runaway", Domain: "b.example", Method: "eth_getLogs", Requests: runaway, Responses: runaway},
+			UsageHistory{Timestamp: at, APIKeyName: "indexer", Domain: "c.example", Method: "eth_blockNumber", Requests: indexer, Responses: indexer},
+			UsageHistory{Timestamp: at, APIKeyName: "dev", Domain: "a.example", Method: "eth_call", Requests: dev, Responses: dev},
+		)
+	}
+	return rows
+}
+
+func TestDetectUsageAnomalies(t *testing.T) {
+	start := time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC)
+	opts := UsageAnomalyOptions{
+		Interval:     "hour",
+		Method:       AnomalyMAD,
+		Baseline:     24,
+		MinResponses: 100,
+		HistoryStart: start,
+		From:         start.Add(24 * time.Hour),
+		// The running hour at 00:03 is incomplete and not scanned.
+		To: start.Add(48*time.Hour + 3*time.Minute),
+	}
+	report := DetectUsageAnomalies(anomalyTestHistory(start), opts)
+
+	if report.Threshold != 3.5 || report.Series != 10 || report.WindowEnd != "2026-03-11T00:00:00Z" {
+		t.Fatalf("unexpected report header: %+v", report)
+	}
+	if report.Spikes != 3 || report.Drops != 3 || len(report.Anomalies) != 6 {
+		t.Fatalf("expected 3 spikes and 3 drops, got %+v", report.Anomalies)
+	}
+	spike := report.Anomalies[0]
+	if spike.Dimension != DimensionAPIKey || spike.Group != "runaway" || spike.Kind != AnomalySpike ||
+		spike.WindowStart != "2026-03-10T06:00:00Z" || spike.WindowEnd != "2026-03-10T10:00:00Z" || spike.Buckets != 4 || spike.Responses != 80000 {
+		t.Fatalf("unexpected runaway key spike: %+v", spike)
+	}
+	if spike.Change == nil || *spike.Change < 90 || len(spike.Endpoints) != 1 || spike.Endpoints[0] != "b.example" || spike.Methods[0] != "eth_getLogs" {
+		t.Fatalf("expected the spike's change and involved endpoint and method, got %+v", spike)
+	}
+	drop := report.Anomalies[3]
+	if drop.Group != "indexer" || drop.Kind != AnomalyDrop || drop.Responses != 0 || drop.Expected != 10000 || *drop.Change != -1 || drop.Score >= -3.5 {
+		t.Fatalf("unexpected indexer drop: %+v", drop)
+	}
+
+	opts.Kind = AnomalyDrop
+	opts.Method = AnomalyZScore
+	opts.Dimensions = []string{DimensionEndpoint}
+	drops := DetectUsageAnomalies(anomalyTestHistory(start), opts)
+	if drops.Spikes != 0 || drops.Drops != 1 || drops.Series != 3 || drops.Anomalies[0].Group != "c.example" {
+		t.Fatalf("expected only the endpoint drop, got %+v", drops)
+	}
+}
+
+func TestDetectUsageAnomaliesNeedsABaseline(t *testing.T) {
+	start := time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC)
+	report := DetectUsageAnomalies(anomalyTestHistory(start), UsageAnomalyOptions{
+		Interval: "hour",
+		Method:   AnomalyMAD,
+		Baseline: 24,
+		// History fetched from 05:30 starts with the runaway spike at 06:00,
+		// leaving it no baseline to stand out from.
+		HistoryStart: start.Add(29*time.Hour + 30*time.Minute),
+		From:         start.Add(24 * time.Hour),
+		To:           start.Add(34 * time.Hour),
+	})
+	if len(report.Anomalies) != 0 || report.Series != 10 {
+		t.Fatalf("expected no scorable buckets, got %+v", report)
+	}
+}
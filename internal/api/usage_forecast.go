@@ -0,0 +1,360 @@
+package api
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/dwellir-public/cli/internal/stats"
+)
+
+// Forecast models, from the simplest.
+const (
+	ForecastMean    = "mean"
+	ForecastLinear  = "linear"
+	ForecastWeekday = "weekday"
+)
+
+const (
+	// ForecastConfidence is the coverage of the forecast bands.
+	ForecastConfidence = 0.8
+	// forecastBandZ is the normal quantile of an 80% two-sided band.
+	forecastBandZ = 1.2816
+	// forecastMinTrendDays is the history needed before a trend is fitted;
+	// with less the daily mean is projected.
+	forecastMinTrendDays = 3
+	// forecastMinWeekdayDays is the history needed for weekday factors: two
+	// of every weekday.
+	forecastMinWeekdayDays = 14
+	// forecastSparseSpread is the daily spread assumed while there are too
+	// few days to measure it, as a share of the daily mean.
+	forecastSparseSpread = 0.25
+)
+
+// ErrForecastNoHistory is returned when the cycle has no complete day of
+// usage to forecast from.
+var ErrForecastNoHistory = errors.New("the billing cycle has no complete day of usage yet")
+
+// UsageForecast projects the current billing cycle's usage to its end.
+// Low and high values bound the ForecastConfidence band.
+type UsageForecast struct {
+	PlanName        string            `json:"plan_name"`
+	CycleStart      string            `json:"cycle_start"`
+	CycleEnd        string            `json:"cycle_end"`
+	AsOf            string            `json:"as_of"`
+	DaysObserved    int               `json:"days_observed"`
+	DaysInCycle     int               `json:"days_in_cycle"`
+	Model           string            `json:"model"`
+	Confidence      float64           `json:"confidence"`
+	ResponsesToDate int               `json:"responses_to_date"`
+	Projected       ForecastResponses `json:"projected_responses"`
+	MonthlyQuota    int               `json:"monthly_quota"`
+	Overage         ForecastResponses `json:"projected_overage"`
+	QuotaExhaustion *QuotaExhaustion  `json:"quota_exhaustion,omitempty"`
+	CostSupported   bool              `json:"cost_supported"`
+	Cost            *ForecastCost     `json:"projected_cost,omitempty"`
+	Models          []ForecastFit     `json:"models"`
+	Daily           []ForecastDay     `json:"daily"`
+}
+
+// ForecastResponses is a projected response count with its band.
+type ForecastResponses struct {
+	Expected int `json:"expected"`
+	Low      int `json:"low"`
+	High     int `json:"high"`
+}
+
+// ForecastCost is the projected cost of the whole cycle in USD, including
+// the plan's base cost, with its band.
+type ForecastCost struct {
+	Expected float64 `json:"expected"`
+	Low      float64 `json:"low"`
+	High     float64 `json:"high"`
+}
+
+// QuotaExhaustion is when cumulative responses are projected to reach the
+// monthly quota. Earliest follows the high band and Latest the low band;
+// Expected and Latest are empty when their curve stays within the quota.
+type QuotaExhaustion struct {
+	Expected        string `json:"expected,omitempty"`
+	Earliest        string `json:"earliest"`
+	Latest          string `json:"latest,omitempty"`
+	AlreadyExceeded bool   `json:"already_exceeded,omitempty"`
+}
+
+// ForecastFit is a model's in-sample root mean square error per day.
+type ForecastFit struct {
+	Model string  `json:"model"`
+	RMSE  float64 `json:"rmse"`
+}
+
+// ForecastDay is one day of the cycle: observed responses up to today and
+// the projection from today on, with cumulative totals.
+type ForecastDay struct {
+	Date           string `json:"date"`
+	Responses      *int   `json:"responses,omitempty"`
+	Projected      *int   `json:"projected,omitempty"`
+	Cumulative     int    `json:"cumulative"`
+	CumulativeLow  int    `json:"cumulative_low"`
+	CumulativeHigh int    `json:"cumulative_high"`
+}
+
+// dailyModel predicts responses on day index x of the cycle.
+type dailyModel struct {
+	name    string
+	predict func(x int) float64
+	sigma   float64
+}
+
+// ForecastUsage projects the current billing cycle at now from its daily
+// history. A mean, a linear trend, and (with two weeks of history) a linear
+// trend scaled by weekday factors are fitted to the complete days; the one
+// with the lowest error is used, the weekday model only when it beats the
+// linear one by 10%. Bands assume independent daily errors. Costs come from
+// CalculateUsageCostReport over the observed and projected days.
+func ForecastUsage(
+	plan SubscriptionInfo,
+	monthlyQuota int,
+	discount *DiscountInfo,
+	currentSub *CurrentSubscriptionWindow,
+	now time.Time,
+	history []UsageHistory,
+) (UsageForecast, error) {
+	now = now.UTC()
+	cycleStart, _ := CurrentBillingCycleRange(now, currentSub)
+	_, cycleEnd := currentBillingCycleWindow(now, currentSub)
+	// A cycle renewing mid-day includes that day.
+	cycleEnd = startOfUTCDay(cycleEnd.Add(24*time.Hour - time.Nanosecond))
+	days := daysInRange(cycleStart, cycleEnd)
+	today := min(int(now.Sub(cycleStart)/(24*time.Hour)), days-1)
+
+	forecast := UsageForecast{
+		PlanName:      plan.EffectivePlanName(),
+		CycleStart:    cycleStart.Format(time.RFC3339),
+		CycleEnd:      cycleEnd.Format(time.RFC3339),
+		AsOf:          now.Format(time.RFC3339),
+		DaysObserved:  today,
+		DaysInCycle:   days,
+		Confidence:    ForecastConfidence,
+		MonthlyQuota:  monthlyQuota,
+		CostSupported: PlanAllowsOverages(plan.ID),
+	}
+	if today < 1 {
+		return forecast, ErrForecastNoHistory
+	}
+
+	daily := make([]float64, days)
+	for _, row := range history {
+		ts, err := time.Parse(time.RFC3339, row.Timestamp)
+		if err != nil || ts.Before(cycleStart) || !ts.Before(cycleEnd) {
+			continue
+		}
+		daily[int(ts.Sub(cycleStart)/(24*time.Hour))] += float64(row.Responses)
+	}
+	for _, responses := range daily[:today+1] {
+		forecast.ResponsesToDate += int(responses)
+	}
+
+	model := fitDailyModel(daily[:today], cycleStart, &forecast)
+	forecast.Model = model.name
+
+	// The running day counts for the part of it that is left.
+	todayLeft := 1 - now.Sub(cycleStart.AddDate(0, 0, today)).Hours()/24
+	expected := make([]float64, days)
+	low := make([]float64, days)
+	high := make([]float64, days)
+	cumulative, horizon := 0.0, 0.0
+	for d := 0; d < days; d++ {
+		day := cycleStart.AddDate(0, 0, d)
+		entry := ForecastDay{Date: day.Format("2006-01-02")}
+		if d <= today {
+			responses := int(daily[d])
+			entry.Responses = &responses
+			cumulative += daily[d]
+		}
+		if d >= today {
+			projected := math.Max(model.predict(d), 0)
+			remaining := projected
+			horizon++
+			if d == today {
+				remaining = math.Max(projected-daily[d], 0)
+				horizon = todayLeft
+				projected = daily[d] + remaining
+			}
+			cumulative += remaining
+			rounded := int(math.Round(projected))
+			entry.Projected = &rounded
+		}
+		spread := forecastBandZ * model.sigma * math.Sqrt(horizon)
+		expected[d] = cumulative
+		low[d] = math.Max(cumulative-spread, float64(forecast.ResponsesToDate))
+		high[d] = cumulative + spread
+		if d < today {
+			low[d], high[d] = cumulative, cumulative
+		}
+		entry.Cumulative = int(math.Round(expected[d]))
+		entry.CumulativeLow = int(math.Round(low[d]))
+		entry.CumulativeHigh = int(math.Round(high[d]))
+		forecast.Daily = append(forecast.Daily, entry)
+	}
+
+	last := days - 1
+	forecast.Projected = ForecastResponses{
+		Expected: int(math.Round(expected[last])),
+		Low:      int(math.Round(low[last])),
+		High:     int(math.Round(high[last])),
+	}
+	if monthlyQuota > 0 {
+		forecast.Overage = ForecastResponses{
+			Expected: max(0, forecast.Projected.Expected-monthlyQuota),
+			Low:      max(0, forecast.Projected.Low-monthlyQuota),
+			High:     max(0, forecast.Projected.High-monthlyQuota),
+		}
+		forecast.QuotaExhaustion = quotaExhaustion(float64(monthlyQuota), cycleStart, now, today, float64(forecast.ResponsesToDate), expected, low, high)
+	}
+
+	if forecast.CostSupported {
+		forecast.Cost = &ForecastCost{
+			Expected: projectedCycleCost(plan, monthlyQuota, discount, currentSub, cycleStart, cycleEnd, history, daily, today, expected),
+			Low:      projectedCycleCost(plan, monthlyQuota, discount, currentSub, cycleStart, cycleEnd, history, daily, today, low),
+			High:     projectedCycleCost(plan, monthlyQuota, discount, currentSub, cycleStart, cycleEnd, history, daily, today, high),
+		}
+	}
+	return forecast, nil
+}
+
+// fitDailyModel fits the candidate models to the observed days, records
+// their errors on forecast, and returns the one to project with.
+func fitDailyModel(observed []float64, cycleStart time.Time, forecast *UsageForecast) dailyModel {
+	n := len(observed)
+	mean := stats.Mean(observed)
+	candidates := []dailyModel{{name: ForecastMean, predict: func(int) float64 { return mean }}}
+
+	if n >= forecastMinTrendDays {
+		intercept, slope := stats.LinearFit(observed)
+		trend := func(x int) float64 { return intercept + slope*float64(x) }
+		candidates = append(candidates, dailyModel{name: ForecastLinear, predict: trend})
+
+		if n >= forecastMinWeekdayDays {
+			var ratios [7][]float64
+			for x, y := range observed {
+				if t := trend(x); t > 0 {
+					weekday := cycleStart.AddDate(0, 0, x).Weekday()
+					ratios[weekday] = append(ratios[weekday], y/t)
+				}
+			}
+			var factors [7]float64
+			for weekday := range factors {
+				factors[weekday] = 1
+				if len(ratios[weekday]) > 0 {
+					factors[weekday] = stats.Mean(ratios[weekday])
+				}
+			}
+			candidates = append(candidates, dailyModel{name: ForecastWeekday, predict: func(x int) float64 {
+				return trend(x) * factors[cycleStart.AddDate(0, 0, x).Weekday()]
+			}})
+		}
+	}
+
+	params := map[string]int{ForecastMean: 1, ForecastLinear: 2, ForecastWeekday: 9}
+	best := -1
+	for i := range candidates {
+		c := &candidates[i]
+		sse := 0.0
+		for x, y := range observed {
+			residual := y - c.predict(x)
+			sse += residual * residual
+		}
+		forecast.Models = append(forecast.Models, ForecastFit{Model: c.name, RMSE: math.Sqrt(sse / float64(n))})
+		c.sigma = math.Sqrt(sse / float64(max(1, n-params[c.name])))
+		if n < forecastMinTrendDays {
+			c.sigma = math.Max(c.sigma, forecastSparseSpread*mean)
+		}
+		switch {
+		case best < 0:
+			best = i
+		case c.name == ForecastWeekday:
+			if forecast.Models[i].RMSE < 0.9*forecast.Models[best].RMSE {
+				best = i
+			}
+		case forecast.Models[i].RMSE < forecast.Models[best].RMSE:
+			best = i
+		}
+	}
+	return candidates[best]
+}
+
+// quotaExhaustion finds when each cumulative curve first reaches quota,
+// interpolating within the day. Within today the observed responses fill
+// the time up to now and the projection the rest of the day.
+func quotaExhaustion(quota float64, cycleStart, now time.Time, today int, toDate float64, expected, low, high []float64) *QuotaExhaustion {
+	crossing := func(curve []float64) string {
+		previous := 0.0
+		for d, total := range curve {
+			if total >= quota {
+				from, to := cycleStart.AddDate(0, 0, d), cycleStart.AddDate(0, 0, d+1)
+				if d == today {
+					if toDate >= quota {
+						to, total = now, toDate
+					} else {
+						from, previous = now, toDate
+					}
+				}
+				share := 1.0
+				if total > previous {
+					share = (quota - previous) / (total - previous)
+				}
+				at := from.Add(time.Duration(share * float64(to.Sub(from))))
+				return at.Truncate(time.Minute).Format(time.RFC3339)
+			}
+			previous = total
+		}
+		return ""
+	}
+	exhaustion := &QuotaExhaustion{
+		Expected:        crossing(expected),
+		Earliest:        crossing(high),
+		Latest:          crossing(low),
+		AlreadyExceeded: toDate >= quota,
+	}
+	if exhaustion.Earliest == "" {
+		return nil
+	}
+	return exhaustion
+}
+
+// projectedCycleCost prices the observed history plus the projection implied
+// by a cumulative curve from today on.
+func projectedCycleCost(
+	plan SubscriptionInfo,
+	monthlyQuota int,
+	discount *DiscountInfo,
+	currentSub *CurrentSubscriptionWindow,
+	cycleStart, cycleEnd time.Time,
+	history []UsageHistory,
+	daily []float64,
+	today int,
+	cumulative []float64,
+) float64 {
+	rows := append([]UsageHistory(nil), history...)
+	previous := 0.0
+	if today > 0 {
+		previous = cumulative[today-1]
+	}
+	for d := today; d < len(cumulative); d++ {
+		projected := cumulative[d] - previous
+		if d == today {
+			// Today's observed responses are already in history.
+			projected -= daily[d]
+		}
+		previous = cumulative[d]
+		if projected <= 0 {
+			continue
+		}
+		rows = append(rows, UsageHistory{
+			Timestamp: cycleStart.AddDate(0, 0, d).Format(time.RFC3339),
+			Responses: int(math.Round(projected)),
+		})
+	}
+	return CalculateUsageCostReport(plan, monthlyQuota, discount, currentSub, cycleStart, cycleEnd, rows, rows).TotalCost
+}
@@ -0,0 +1,99 @@
+package api
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func forecastHistory(start time.Time, daily []int) []UsageHistory {
+	rows := make([]UsageHistory, 0, len(daily))
+	for d, responses := range daily {
+		rows = append(rows, UsageHistory{Timestamp: start.AddDate(0, 0, d).Format(time.RFC3339), Requests: responses, Responses: responses})
+	}
+	return rows
+}
+
+func TestForecastUsageSteadyUsage(t *testing.T) {
+	cycle := &CurrentSubscriptionWindow{RenewalDate: "2026-04-01T00:00:00Z"}
+	cycleStart := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2026, 3, 11, 12, 0, 0, 0, time.UTC)
+	daily := []int{1_000_000, 1_000_000, 1_000_000, 1_000_000, 1_000_000, 1_000_000, 1_000_000, 1_000_000, 1_000_000, 1_000_000, 400_000}
+	plan := SubscriptionInfo{ID: 2, Name: "Developer"}
+
+	forecast, err := ForecastUsage(plan, 25_000_000, nil, cycle, now, forecastHistory(cycleStart, daily))
+	if err != nil {
+		t.Fatalf("ForecastUsage: %v", err)
+	}
+	if forecast.DaysInCycle != 31 || forecast.DaysObserved != 10 || forecast.ResponsesToDate != 10_400_000 {
+		t.Fatalf("unexpected cycle position: %+v", forecast)
+	}
+	want := ForecastResponses{Expected: 31_000_000, Low: 31_000_000, High: 31_000_000}
+	if forecast.Model != ForecastMean || forecast.Projected != want {
+		t.Fatalf("expected a flat projection of 31M, got %s %+v", forecast.Model, forecast.Projected)
+	}
+	if forecast.Overage.Expected != 6_000_000 {
+		t.Fatalf("expected 6M overage, got %+v", forecast.Overage)
+	}
+	if e := forecast.QuotaExhaustion; e == nil || e.Expected != "2026-03-26T00:00:00Z" || e.Earliest != e.Expected || e.AlreadyExceeded {
+		t.Fatalf("unexpected quota exhaustion: %+v", forecast.QuotaExhaustion)
+	}
+	if len(forecast.Daily) != 31 || forecast.Daily[10].Responses == nil || *forecast.Daily[10].Projected != 1_000_000 || forecast.Daily[11].Responses != nil {
+		t.Fatalf("unexpected daily entries: %+v", forecast.Daily[9:12])
+	}
+
+	full := make([]int, 31)
+	for d := range full {
+		full[d] = 1_000_000
+	}
+	rows := forecastHistory(cycleStart, full)
+	wantCost := CalculateUsageCostReport(plan, 25_000_000, nil, cycle, cycleStart, cycleStart.AddDate(0, 1, 0), rows, rows).TotalCost
+	if forecast.Cost == nil || math.Abs(forecast.Cost.Expected-wantCost) > 0.01 || forecast.Cost.Low != forecast.Cost.Expected {
+		t.Fatalf("expected projected cost %.2f, got %+v", wantCost, forecast.Cost)
+	}
+}
+
+func TestForecastUsageWeekdaySeasonality(t *testing.T) {
+	cycle := &CurrentSubscriptionWindow{RenewalDate: "2026-04-01T00:00:00Z"}
+	cycleStart := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2026, 3, 21, 6, 0, 0, 0, time.UTC)
+	daily := make([]int, 21)
+	for d := range daily {
+		level := 1_000_000 + 20_000*d
+		switch cycleStart.AddDate(0, 0, d).Weekday() {
+		case time.Saturday, time.Sunday:
+			level /= 2
+		}
+		daily[d] = level
+	}
+	daily[20] /= 4
+
+	forecast, err := ForecastUsage(SubscriptionInfo{ID: 1}, 0, nil, cycle, now, forecastHistory(cycleStart, daily))
+	if err != nil {
+		t.Fatalf("ForecastUsage: %v", err)
+	}
+	if forecast.Model != ForecastWeekday || len(forecast.Models) != 3 {
+		t.Fatalf("expected the weekday model, got %s %+v", forecast.Model, forecast.Models)
+	}
+	p := forecast.Projected
+	if !(p.Low < p.Expected && p.Expected < p.High) || p.Low < forecast.ResponsesToDate {
+		t.Fatalf("expected an ordered band above responses to date, got %+v", p)
+	}
+	// 2026-03-28 is a Saturday.
+	if saturday := forecast.Daily[27]; *saturday.Projected > *forecast.Daily[26].Projected*3/4 {
+		t.Fatalf("expected a weekend dip, got %d after %d", *saturday.Projected, *forecast.Daily[26].Projected)
+	}
+	if forecast.QuotaExhaustion != nil || forecast.Cost != nil || forecast.CostSupported {
+		t.Fatalf("expected no quota or cost on a free plan, got %+v", forecast)
+	}
+}
+
+func TestForecastUsageNeedsACompleteDay(t *testing.T) {
+	cycle := &CurrentSubscriptionWindow{RenewalDate: "2026-04-01T00:00:00Z"}
+	now := time.Date(2026, 3, 1, 18, 0, 0, 0, time.UTC)
+	_, err := ForecastUsage(SubscriptionInfo{ID: 2}, 0, nil, cycle, now, forecastHistory(now.Truncate(24*time.Hour), []int{500}))
+	if !errors.Is(err, ErrForecastNoHistory) {
+		t.Fatalf("expected ErrForecastNoHistory, got %v", err)
+	}
+}
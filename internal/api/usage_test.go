@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -44,6 +45,7 @@ func TestUsageHistoryRequestShape(t *testing.T) {
 
 	api := NewUsageAPI(NewClient(server.URL, "token"))
 	_, err := api.History(
+		context.Background(),
 		"day",
 		"2026-02-01T00:00:00Z",
 		"2026-02-02T00:00:00Z",
@@ -76,7 +78,7 @@ func TestUsageHistoryMapsStartTimeToTimestamp(t *testing.T) {
 	defer server.Close()
 
 	api := NewUsageAPI(NewClient(server.URL, "token"))
-	items, err := api.History("day", "", "", "", "", "")
+	items, err := api.History(context.Background(), "day", "", "", "", "", "")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -114,7 +116,7 @@ func TestUsageHistoryStopsWhenBackendIgnoresPagination(t *testing.T) {
 	defer server.Close()
 
 	api := NewUsageAPI(NewClient(server.URL, "token"))
-	items, err := api.History("day", "", "", "", "", "")
+	items, err := api.History(context.Background(), "day", "", "", "", "", "")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -149,7 +151,7 @@ func TestUsageRPSBuildsTimeSeriesFromHistory(t *testing.T) {
 	defer server.Close()
 
 	api := NewUsageAPI(NewClient(server.URL, "token"))
-	items, err := api.RPS("minute", "", "", "", "")
+	items, err := api.RPS(context.Background(), "minute", "", "", "", "")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -175,7 +177,7 @@ func TestUsageOrganizationRPSMapsLimitedRequestsCamelCase(t *testing.T) {
 	defer server.Close()
 
 	api := NewUsageAPI(NewClient(server.URL, "token"))
-	agg, err := api.OrganizationRPS("day", "2026-01-28T00:00:00Z", "2026-02-28T00:00:00Z", "", "")
+	agg, err := api.OrganizationRPS(context.Background(), "day", "2026-01-28T00:00:00Z", "2026-02-28T00:00:00Z", "", "")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
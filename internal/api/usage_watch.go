@@ -0,0 +1,95 @@
+package api
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// UsageSnapshot is one refresh of 'usage watch': organization-wide RPS
+// figures and the minute-level usage of the trailing window.
+type UsageSnapshot struct {
+	Timestamp    string           `json:"timestamp"`
+	WindowStart  string           `json:"window_start"`
+	WindowEnd    string           `json:"window_end"`
+	CurrentRPS   float64          `json:"current_rps"`
+	AverageRPS   float64          `json:"average_rps"`
+	PeakRPS      float64          `json:"peak_rps"`
+	Requests     int              `json:"requests"`
+	Responses    int              `json:"responses"`
+	RateLimited  int              `json:"rate_limited"`
+	Series       []UsagePoint     `json:"series"`
+	TopEndpoints []UsageBreakdown `json:"top_endpoints"`
+	TopMethods   []UsageBreakdown `json:"top_methods"`
+}
+
+// UsagePoint is the request rate and rate-limited count of one minute.
+type UsagePoint struct {
+	Timestamp   string  `json:"timestamp"`
+	RPS         float64 `json:"rps"`
+	RateLimited int     `json:"rate_limited"`
+}
+
+// Snapshot reads the organization RPS figures and minute-level history
+// between start and end and summarizes them, keeping the top busiest
+// endpoints and methods.
+func (u *UsageAPI) Snapshot(ctx context.Context, start, end time.Time, apiKey, fqdn string, top int) (*UsageSnapshot, error) {
+	from, to := start.UTC().Format(time.RFC3339), end.UTC().Format(time.RFC3339)
+	stats, err := u.OrganizationRPS(ctx, "minute", from, to, apiKey, fqdn)
+	if err != nil {
+		return nil, err
+	}
+	history, err := u.History(ctx, "minute", from, to, apiKey, fqdn, "")
+	if err != nil {
+		return nil, err
+	}
+	snapshot := BuildUsageSnapshot(stats, history, end, top)
+	snapshot.WindowStart, snapshot.WindowEnd = from, to
+	return &snapshot, nil
+}
+
+// BuildUsageSnapshot summarizes minute-level history at now. The current
+// rate is that of the newest complete minute, since the running minute is
+// still filling up; the peak is the API's when it reports one and otherwise
+// the busiest minute.
+func BuildUsageSnapshot(stats *OrganizationRPS, history []UsageHistory, now time.Time, top int) UsageSnapshot {
+	snapshot := UsageSnapshot{
+		Timestamp:    now.UTC().Format(time.RFC3339),
+		Series:       []UsagePoint{},
+		TopEndpoints: topUsage(BuildUsageBreakdown(history, UsageDomain), top),
+		TopMethods:   topUsage(BuildUsageBreakdown(history, UsageMethod), top),
+	}
+	limited := map[string]int{}
+	for _, row := range history {
+		snapshot.Requests += row.Requests
+		snapshot.Responses += row.Responses
+		limited[row.Timestamp] += max(0, row.Requests-row.Responses)
+	}
+	snapshot.RateLimited = max(0, snapshot.Requests-snapshot.Responses)
+
+	running := now.UTC().Truncate(time.Minute)
+	for _, point := range BuildRPSTimeSeries(history, "minute") {
+		snapshot.Series = append(snapshot.Series, UsagePoint{Timestamp: point.Timestamp, RPS: point.RPS, RateLimited: limited[point.Timestamp]})
+		snapshot.PeakRPS = math.Max(snapshot.PeakRPS, point.RPS)
+		if at, err := time.Parse(time.RFC3339, point.Timestamp); err != nil || at.Before(running) {
+			snapshot.CurrentRPS = point.RPS
+		}
+	}
+	if stats != nil {
+		snapshot.AverageRPS = stats.RPS
+		if stats.PeakRPS > 0 {
+			snapshot.PeakRPS = stats.PeakRPS
+		}
+		if limitedRequests := int(math.Round(stats.LimitedRequests)); limitedRequests > 0 {
+			snapshot.RateLimited = limitedRequests
+		}
+	}
+	return snapshot
+}
+
+func topUsage(breakdown []UsageBreakdown, top int) []UsageBreakdown {
+	if top > 0 && len(breakdown) > top {
+		return breakdown[:top]
+	}
+	return breakdown
+}
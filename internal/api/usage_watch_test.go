@@ -0,0 +1,41 @@
+package api
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBuildUsageSnapshot(t *testing.T) {
+	now := time.Date(2026, 3, 15, 14, 3, 20, 0, time.UTC)
+	history := []UsageHistory{
+		{Timestamp: "2026-03-15T14:01:00Z", Domain: "a.example", Method: "eth_call", Requests: 600, Responses: 590},
+		{Timestamp: "2026-03-15T14:01:00Z", Domain: "b.example", Method: "eth_getLogs", Requests: 60, Responses: 60},
+		{Timestamp: "2026-03-15T14:02:00Z", Domain: "a.example", Method: "eth_call", Requests: 1200, Responses: 1200},
+		// The running minute is still filling up.
+		{Timestamp: "2026-03-15T14:03:00Z", Domain: "a.example", Method: "eth_call", Requests: 30, Responses: 30},
+	}
+
+	snapshot := BuildUsageSnapshot(nil, history, now, 1)
+	if snapshot.Timestamp != "2026-03-15T14:03:20Z" || snapshot.Requests != 1890 || snapshot.Responses != 1880 || snapshot.RateLimited != 10 {
+		t.Fatalf("unexpected totals: %+v", snapshot)
+	}
+	if snapshot.CurrentRPS != 20 || snapshot.PeakRPS != 20 {
+		t.Fatalf("expected current and peak RPS of the 14:02 minute, got %+v", snapshot)
+	}
+	if len(snapshot.Series) != 3 || snapshot.Series[0].RPS != 11 || snapshot.Series[0].RateLimited != 10 {
+		t.Fatalf("unexpected series: %+v", snapshot.Series)
+	}
+	if len(snapshot.TopEndpoints) != 1 || snapshot.TopEndpoints[0].Group != "a.example" || len(snapshot.TopMethods) != 1 || snapshot.TopMethods[0].Group != "eth_call" {
+		t.Fatalf("unexpected top lists: %+v %+v", snapshot.TopEndpoints, snapshot.TopMethods)
+	}
+
+	withStats := BuildUsageSnapshot(&OrganizationRPS{RPS: 10.5, PeakRPS: 42, LimitedRequests: 12}, history, now, 5)
+	if withStats.AverageRPS != 10.5 || withStats.PeakRPS != 42 || withStats.RateLimited != 12 || len(withStats.TopEndpoints) != 2 {
+		t.Fatalf("expected the API's RPS figures to win, got %+v", withStats)
+	}
+
+	empty := BuildUsageSnapshot(nil, nil, now, 5)
+	if empty.Series == nil || empty.CurrentRPS != 0 || len(empty.TopEndpoints) != 0 {
+		t.Fatalf("unexpected empty snapshot: %+v", empty)
+	}
+}
@@ -19,7 +19,7 @@ var accountInfoCmd = &cobra.Command{
 		if err != nil {
 			return getFormatter().Error("not_authenticated", err.Error(), "")
 		}
-		info, err := api.NewAccountAPI(client).Info()
+		info, err := api.NewAccountAPI(client).Info(cmd.Context())
 		if err != nil {
 			return formatCommandError(err)
 		}
@@ -35,7 +35,7 @@ var accountSubscriptionCmd = &cobra.Command{
 		if err != nil {
 			return getFormatter().Error("not_authenticated", err.Error(), "")
 		}
-		sub, err := api.NewAccountAPI(client).Subscription()
+		sub, err := api.NewAccountAPI(client).Subscription(cmd.Context())
 		if err != nil {
 			return formatCommandError(err)
 		}
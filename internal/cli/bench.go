@@ -0,0 +1,150 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dwellir-public/cli/internal/api"
+	"github.com/dwellir-public/cli/internal/rpc"
+)
+
+var (
+	benchMethod      string
+	benchParams      string
+	benchRPS         int
+	benchDuration    time.Duration
+	benchMaxInFlight int
+	benchTimeout     time.Duration
+	benchNetwork     string
+	benchNodeType    string
+	benchEcosystem   string
+	benchKeyName     string
+)
+
+var benchCmd = &cobra.Command{
+	Use:   "bench <chain>",
+	Short: "Load-test a chain endpoint at a fixed request rate",
+	Long: `Send one JSON-RPC method to a chain endpoint at a fixed rate and report the
+achieved throughput, latency percentiles, 429 responses, and error classes.
+
+The load is open-loop: a request starts every 1/--rps seconds whether or not
+earlier ones have finished, so an overloaded endpoint shows up as latency and
+errors rather than as a quietly lower rate. Requests due while --max-in-flight
+are outstanding are skipped and counted.
+
+Before starting, the target rate is compared with your plan's rate and burst
+limits ('dwellir account subscription') and a warning is printed when it
+exceeds them. Every request counts against your quota.
+
+Examples:
+  dwellir bench ethereum --method eth_blockNumber --rps 200 --duration 60s
+  dwellir bench base --method eth_getBlockByNumber --params '["latest", false]' --rps 50
+  dwellir bench polkadot --method chain_getHeader --rps 20 --duration 30s --json`,
+	Args: benchArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		params, err := rpc.ParseParams([]string{benchParams})
+		if err != nil {
+			return getFormatter().Error("validation_error", err.Error(), "Quote JSON params, e.g. --params '[\"latest\", false]'.")
+		}
+
+		client, err := newAPIClient()
+		if err != nil {
+			return getFormatter().Error("not_authenticated", err.Error(), "")
+		}
+		target, err := resolveEndpointTarget(cmd.Context(), client, args[0], benchEcosystem, benchNodeType, "https", benchNetwork, benchKeyName)
+		if err != nil {
+			return formatEndpointResolveError(err)
+		}
+
+		// The limits are advisory: a failed lookup must not block the run.
+		var sub *api.SubscriptionInfo
+		if info, err := api.NewAccountAPI(client).Subscription(cmd.Context()); err == nil {
+			sub = info
+		}
+		warnings := benchLimitWarnings(benchRPS, sub)
+		if !quiet && isHumanOutput() {
+			stderr := cmd.ErrOrStderr()
+			for _, warning := range warnings {
+				_, _ = fmt.Fprintf(stderr, "Warning: %s\n", warning)
+			}
+			_, _ = fmt.Fprintf(stderr, "Sending %s to %s %s at %d req/s for %s...\n", benchMethod, target.Chain, target.Network, benchRPS, benchDuration)
+		}
+
+		result := rpc.Bench(cmd.Context(), target.URL, rpc.BenchOptions{
+			Method:      benchMethod,
+			Params:      params,
+			RPS:         benchRPS,
+			Duration:    benchDuration,
+			Timeout:     benchTimeout,
+			MaxInFlight: benchMaxInFlight,
+		})
+		result.Chain = target.Chain
+		result.Network = target.Network
+		result.NodeType = target.NodeType
+		result.Endpoint = target.Template
+		result.Warnings = warnings
+		if sub != nil {
+			result.RateLimit = sub.RateLimit
+			result.BurstLimit = sub.BurstLimit
+		}
+		return getFormatter().Success("bench", result)
+	},
+}
+
+// benchLimitWarnings compares the target rate with the plan's sustained and
+// burst limits. A zero limit means the plan does not publish one.
+func benchLimitWarnings(rps int, sub *api.SubscriptionInfo) []string {
+	if sub == nil {
+		return nil
+	}
+	plan := sub.EffectivePlanName()
+	switch {
+	case sub.BurstLimit > 0 && rps > sub.BurstLimit:
+		return []string{fmt.Sprintf("%d req/s exceeds the %s plan's burst limit of %d req/s; expect most requests above it to be rate limited (429).", rps, plan, sub.BurstLimit)}
+	case sub.RateLimit > 0 && rps > sub.RateLimit:
+		if sub.BurstLimit > sub.RateLimit {
+			return []string{fmt.Sprintf("%d req/s exceeds the %s plan's rate limit of %d req/s; it fits the burst limit of %d req/s only briefly.", rps, plan, sub.RateLimit, sub.BurstLimit)}
+		}
+		return []string{fmt.Sprintf("%d req/s exceeds the %s plan's rate limit of %d req/s; expect rate limiting (429).", rps, plan, sub.RateLimit)}
+	}
+	return nil
+}
+
+func benchArgs(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return getFormatter().Error(
+			"validation_error",
+			fmt.Sprintf("bench expects exactly one chain (got %d).", len(args)),
+			"Example: dwellir bench ethereum --method eth_blockNumber --rps 200 --duration 60s",
+		)
+	}
+	switch {
+	case benchMethod == "":
+		return getFormatter().Error("validation_error", "--method must not be empty.", "Example: --method eth_blockNumber")
+	case benchRPS < 1 || benchRPS > rpc.MaxBenchRPS:
+		return getFormatter().Error("validation_error", fmt.Sprintf("--rps must be between 1 and %d.", rpc.MaxBenchRPS), "")
+	case benchDuration < time.Second:
+		return getFormatter().Error("validation_error", "--duration must be at least 1s.", "")
+	case benchMaxInFlight < 1:
+		return getFormatter().Error("validation_error", "--max-in-flight must be at least 1.", "")
+	}
+	return nil
+}
+
+func init() {
+	benchCmd.Flags().StringVar(&benchMethod, "method", "eth_blockNumber", "JSON-RPC method to send")
+	benchCmd.Flags().StringVar(&benchParams, "params", "[]", "JSON params sent with every request")
+	benchCmd.Flags().IntVar(&benchRPS, "rps", 10, "Requests started per second")
+	benchCmd.Flags().DurationVar(&benchDuration, "duration", 10*time.Second, "How long to send requests for")
+	benchCmd.Flags().IntVar(&benchMaxInFlight, "max-in-flight", 1000, "Outstanding requests before further ones are skipped")
+	benchCmd.Flags().DurationVar(&benchTimeout, "request-timeout", 10*time.Second, "Timeout for each request")
+	benchCmd.Flags().StringVar(&benchNetwork, "network", "", "Network (mainnet, testnet, or network name; defaults to mainnet)")
+	benchCmd.Flags().StringVar(&benchNodeType, "node-type", "", "Node type (full, archive; default: full when a network has both)")
+	benchCmd.Flags().StringVar(&benchEcosystem, "ecosystem", "", "Ecosystem (evm, substrate, cosmos, move, hyperliquid, other)")
+	benchCmd.Flags().StringVar(&benchKeyName, "key", "", "API key name or value to use")
+	benchCmd.ValidArgsFunction = completeChainNames
+	addCatalogFlags(benchCmd)
+	rootCmd.AddCommand(benchCmd)
+}
@@ -0,0 +1,31 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dwellir-public/cli/internal/api"
+)
+
+func TestBenchLimitWarnings(t *testing.T) {
+	sub := &api.SubscriptionInfo{PlanName: "Developer", RateLimit: 20, BurstLimit: 100}
+	tests := []struct {
+		rps  int
+		sub  *api.SubscriptionInfo
+		want string
+	}{
+		{10, sub, ""},
+		{50, sub, "rate limit of 20 req/s; it fits the burst limit of 100 req/s only briefly"},
+		{200, sub, "burst limit of 100 req/s"},
+		{50, &api.SubscriptionInfo{RateLimit: 20}, "rate limit of 20 req/s; expect rate limiting"},
+		{500, &api.SubscriptionInfo{}, ""},
+		{500, nil, ""},
+	}
+	for _, tt := range tests {
+		warnings := benchLimitWarnings(tt.rps, tt.sub)
+		got := strings.Join(warnings, "\n")
+		if tt.want == "" && got != "" || !strings.Contains(got, tt.want) {
+			t.Errorf("benchLimitWarnings(%d, %+v) = %q, want %q", tt.rps, tt.sub, got, tt.want)
+		}
+	}
+}
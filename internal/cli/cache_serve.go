@@ -0,0 +1,187 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/net/websocket"
+
+	"github.com/dwellir-public/cli/internal/api"
+	"github.com/dwellir-public/cli/internal/config"
+	"github.com/dwellir-public/cli/internal/proxy"
+	"github.com/dwellir-public/cli/internal/rpc"
+)
+
+// cacheUsageTimeout bounds the usage lookups made after the forwarder stops.
+const cacheUsageTimeout = 10 * time.Second
+
+var (
+	cacheChain         string
+	cacheNetwork       string
+	cacheNodeType      string
+	cacheEcosystem     string
+	cacheKeyName       string
+	cacheListen        string
+	cacheAllowedOrigin []string
+	cacheStore         string
+	cacheDir           string
+	cacheMaxSizeMB     int
+	cacheConfirmations uint64
+)
+
+var cacheServeCmd = &cobra.Command{
+	Use:   "cache-serve",
+	Short: "Serve a local JSON-RPC endpoint that caches immutable calls",
+	Long: `Serve a local JSON-RPC endpoint like 'dwellir proxy', answering calls whose
+result can never change from a local cache instead of spending quota on them.
+
+Cached methods:
+  eth_chainId, net_version                   always
+  eth_getBlockByHash                         once the block exists
+  eth_getBlockByNumber                       for finalized blocks (not tags like latest)
+  eth_getTransactionReceipt,
+  eth_getTransactionByHash                   once the transaction's block is finalized
+
+The finalized block comes from the "finalized" block tag, or from the head
+minus --confirmations on chains without it. Everything else, including
+WebSocket traffic, is forwarded unchanged. A batch is answered locally only
+when every call in it is cached.
+
+The cache lives in memory, or with --store disk under the config directory
+(or --cache-dir) where it survives restarts; --max-size-mb caps either. When
+the forwarder stops, it reports the hit ratio and the requests saved next to
+this billing cycle's 'usage summary' total and your monthly quota.
+
+Examples:
+  dwellir cache-serve --chain ethereum --network mainnet
+  dwellir cache-serve --chain base --store disk --max-size-mb 1024
+  dwellir cache-serve --chain ethereum --listen 127.0.0.1:9545 --json`,
+	Args: cacheServeArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := newAPIClient()
+		if err != nil {
+			return getFormatter().Error("not_authenticated", err.Error(), "")
+		}
+		httpTarget, wsTarget, err := resolveProxyTargets(cmd.Context(), client, cacheChain, cacheEcosystem, cacheNodeType, cacheNetwork, cacheKeyName)
+		if err != nil {
+			return formatEndpointResolveError(err)
+		}
+		if httpTarget.URL == "" {
+			return getFormatter().Error("not_found", fmt.Sprintf("No HTTPS endpoint found for '%s'.", cacheChain), "Use 'dwellir proxy' to forward WebSocket-only chains.")
+		}
+
+		maxBytes := int64(cacheMaxSizeMB) << 20
+		var store proxy.CacheStore = proxy.NewMemoryStore(maxBytes)
+		storeLabel := "memory"
+		if cacheStore == "disk" {
+			dir := cacheDir
+			if dir == "" {
+				dir = filepath.Join(config.DefaultConfigDir(), "cache", "rpc", api.ChainSlug(httpTarget.Chain)+"-"+api.ChainSlug(httpTarget.Network))
+			}
+			diskStore, err := proxy.OpenDiskStore(dir, maxBytes)
+			if err != nil {
+				return getFormatter().Error("write_failed", fmt.Sprintf("Could not open cache directory: %v", err), "Choose another directory with --cache-dir.")
+			}
+			store, storeLabel = diskStore, "disk ("+dir+")"
+		}
+
+		metrics := proxy.NewMetrics("dwellir_cache")
+		backend := proxy.NewCachingBackend(proxy.NewHTTPBackend(httpTarget.URL), store, metrics)
+		backend.Confirmations = cacheConfirmations
+		handler := &proxy.Handler{HTTP: backend, Metrics: metrics, AllowedOrigins: cacheAllowedOrigin}
+		if wsTarget.URL != "" {
+			wsURL := wsTarget.URL
+			handler.DialWebSocket = func(ctx context.Context) (*websocket.Conn, error) {
+				return rpc.DialWebSocket(ctx, wsURL)
+			}
+		}
+
+		listen, err := serveLocalEndpoint(cmd, cacheListen, handler, httpTarget.Template)
+		if err != nil {
+			return err
+		}
+
+		summary := proxy.NewCacheSummary(backend)
+		summary.Chain = httpTarget.Chain
+		summary.Network = httpTarget.Network
+		summary.Listen = listen
+		summary.Store = storeLabel
+		summary.UptimeSeconds = metrics.Uptime().Round(time.Millisecond).Seconds()
+		summary.Methods = metrics.Snapshot()
+		addCacheUsage(client, &summary)
+		return getFormatter().Success("cache-serve", summary)
+	},
+}
+
+// addCacheUsage puts the saved requests next to this cycle's usage and the
+// monthly quota. The command context is already cancelled when the forwarder
+// stops, so the lookups get their own deadline; failures leave the fields out.
+func addCacheUsage(client *api.Client, summary *proxy.CacheSummary) {
+	ctx, cancel := context.WithTimeout(context.Background(), cacheUsageTimeout)
+	defer cancel()
+	saved := float64(max(summary.SavedRequests, 0))
+
+	if usage, err := api.NewUsageAPI(client).Summary(ctx); err == nil && usage != nil {
+		summary.CycleRequests = &usage.TotalRequests
+		if total := float64(usage.TotalRequests) + saved; total > 0 {
+			pct := saved / total * 100
+			summary.SavedPctOfCycle = &pct
+		}
+	}
+	if sub, err := api.NewAccountAPI(client).Subscription(ctx); err == nil && sub != nil && sub.MonthlyQuota != nil {
+		summary.MonthlyQuota = sub.MonthlyQuota
+		if *sub.MonthlyQuota > 0 {
+			pct := saved / float64(*sub.MonthlyQuota) * 100
+			summary.SavedPctOfQuota = &pct
+		}
+	}
+}
+
+func cacheServeArgs(cmd *cobra.Command, args []string) error {
+	if len(args) > 0 {
+		return getFormatter().Error(
+			"validation_error",
+			fmt.Sprintf("Unexpected arguments for cache-serve (got %d).", len(args)),
+			"Pass the chain with --chain, e.g. dwellir cache-serve --chain ethereum",
+		)
+	}
+	if strings.TrimSpace(cacheChain) == "" {
+		return getFormatter().Error(
+			"validation_error",
+			"Missing required flag --chain.",
+			"Example: dwellir cache-serve --chain ethereum --network mainnet",
+		)
+	}
+	cacheStore = strings.ToLower(strings.TrimSpace(cacheStore))
+	if cacheStore != "memory" && cacheStore != "disk" {
+		return getFormatter().Error("validation_error", fmt.Sprintf("Invalid --store %q.", cacheStore), "Supported stores: memory, disk")
+	}
+	if cacheDir != "" && cacheStore != "disk" {
+		return getFormatter().Error("validation_error", "--cache-dir needs --store disk.", "")
+	}
+	if cacheMaxSizeMB < 1 {
+		return getFormatter().Error("validation_error", "--max-size-mb must be at least 1.", "")
+	}
+	return nil
+}
+
+func init() {
+	cacheServeCmd.Flags().StringVar(&cacheChain, "chain", "", "Chain to serve (required)")
+	cacheServeCmd.Flags().StringVar(&cacheNetwork, "network", "", "Network (mainnet, testnet, or network name; defaults to mainnet)")
+	cacheServeCmd.Flags().StringVar(&cacheNodeType, "node-type", "", "Node type (full, archive; default: full when a network has both)")
+	cacheServeCmd.Flags().StringVar(&cacheEcosystem, "ecosystem", "", "Ecosystem (evm, substrate, cosmos, move, hyperliquid, other)")
+	cacheServeCmd.Flags().StringVar(&cacheKeyName, "key", "", "API key name or value to use")
+	cacheServeCmd.Flags().StringVar(&cacheListen, "listen", "127.0.0.1:8545", "Local address to listen on")
+	cacheServeCmd.Flags().StringArrayVar(&cacheAllowedOrigin, "allow-origin", nil, "Browser origin allowed to use the forwarder (repeatable; * allows any)")
+	cacheServeCmd.Flags().StringVar(&cacheStore, "store", "memory", "Where to keep cached results: memory, disk")
+	cacheServeCmd.Flags().StringVar(&cacheDir, "cache-dir", "", "Directory for --store disk (default: under the config directory)")
+	cacheServeCmd.Flags().IntVar(&cacheMaxSizeMB, "max-size-mb", 256, "Maximum size of cached results in MiB")
+	cacheServeCmd.Flags().Uint64Var(&cacheConfirmations, "confirmations", 64, "Blocks behind head treated as final on chains without a finalized tag")
+	_ = cacheServeCmd.RegisterFlagCompletionFunc("chain", completeChainNames)
+	addCatalogFlags(cacheServeCmd)
+	rootCmd.AddCommand(cacheServeCmd)
+}
@@ -0,0 +1,104 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dwellir-public/cli/internal/api"
+	"github.com/dwellir-public/cli/internal/config"
+	"github.com/dwellir-public/cli/internal/output"
+)
+
+var (
+	catalogRefresh bool
+	catalogOffline bool
+)
+
+// catalogStatus records where the chain catalog of the current run came from
+// so structured output can report it in the envelope meta.
+var catalogStatus atomic.Pointer[api.CatalogStatus]
+
+// newEndpointsAPI returns an EndpointsAPI backed by the on-disk catalog cache,
+// honouring --refresh and --offline.
+func newEndpointsAPI(client *api.Client) *api.EndpointsAPI {
+	configDir := config.DefaultConfigDir()
+	cfg, _ := config.Load(configDir)
+
+	mode := api.CatalogCacheDefault
+	switch {
+	case catalogOffline:
+		mode = api.CatalogCacheOffline
+	case catalogRefresh:
+		mode = api.CatalogCacheRefresh
+	}
+
+	ep := api.NewEndpointsAPI(client)
+	ep.Cache = api.NewCatalogCache(configDir, cfg.CatalogTTL(), mode)
+	ep.OnCatalog = func(status api.CatalogStatus) {
+		catalogStatus.Store(&status)
+		if status.Source != api.CatalogFromStale || quiet || !isHumanOutput() {
+			return
+		}
+		_, _ = fmt.Fprintf(
+			config.Stderr(),
+			"Dwellir API unreachable; using the chain catalog cached %s ago.\n",
+			status.Age.Round(time.Second),
+		)
+	}
+	return ep
+}
+
+// catalogMeta converts the recorded catalog status into envelope metadata.
+// Catalogs fetched during this run are not reported.
+func catalogMeta() *output.CacheMeta {
+	status := catalogStatus.Load()
+	if status == nil || status.Source == api.CatalogFromNetwork {
+		return nil
+	}
+	return &output.CacheMeta{
+		Source:     status.Source,
+		FetchedAt:  status.FetchedAt.UTC().Format(time.RFC3339),
+		AgeSeconds: int64(status.Age / time.Second),
+	}
+}
+
+// completeChainNames completes chain slugs from the cached catalog only, so
+// shell completion never waits on the network.
+func completeChainNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	client, err := newAPIClient()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	cache := api.NewCatalogCache(config.DefaultConfigDir(), 0, api.CatalogCacheOffline)
+	chains, _, err := cache.List(cmd.Context(), client)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	prefix := strings.ToLower(toComplete)
+	var names []string
+	for _, chain := range chains {
+		slug := api.ChainSlug(chain.Name)
+		if strings.HasPrefix(slug, prefix) {
+			names = append(names, slug)
+		}
+	}
+	sort.Strings(names)
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+// addCatalogFlags registers --refresh and --offline on a command group that
+// reads the chain catalog.
+func addCatalogFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().BoolVar(&catalogRefresh, "refresh", false, "Ignore the cached chain catalog and fetch a fresh copy")
+	cmd.PersistentFlags().BoolVar(&catalogOffline, "offline", false, "Use the cached chain catalog without contacting the API")
+	cmd.MarkFlagsMutuallyExclusive("refresh", "offline")
+}
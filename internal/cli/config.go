@@ -16,7 +16,7 @@ var configCmd = &cobra.Command{
 var configSetCmd = &cobra.Command{
 	Use:   "set <key> <value>",
 	Short: "Set a config value",
-	Long:  "Set a CLI configuration value.\n\nValid keys: output (json|human|toon), default_profile (<name>)",
+	Long:  "Set a CLI configuration value.\n\nValid keys: output (json|human|toon), default_profile (<name>),\nretry_max_attempts (<n>, 1 disables retries), retry_max_elapsed (<duration>, e.g. 30s),\ncatalog_cache_ttl (<duration>, e.g. 1h; 0 always revalidates)",
 	Args:  cobra.ExactArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cfg, err := config.Load(config.DefaultConfigDir())
@@ -50,7 +50,7 @@ var configGetCmd = &cobra.Command{
 			return f.Error(
 				"validation_error",
 				fmt.Sprintf("Unknown config key %q.", args[0]),
-				"Valid keys: output, default_profile\nExamples:\n  dwellir config get output\n  dwellir config get",
+				"Valid keys: output, default_profile, retry_max_attempts, retry_max_elapsed, catalog_cache_ttl\nExamples:\n  dwellir config get output\n  dwellir config get",
 			)
 		}
 		return f.Success("config.get", map[string]string{args[0]: val})
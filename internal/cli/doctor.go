@@ -91,7 +91,8 @@ var doctorCmd = &cobra.Command{
 					baseURL = "https://dashboard.dwellir.com/marly-api"
 				}
 				client := api.NewClient(baseURL, token)
-				if _, err := api.NewAccountAPI(client).Info(); err != nil {
+				configureRetries(client)
+				if _, err := api.NewAccountAPI(client).Info(cmd.Context()); err != nil {
 					checks = append(checks, map[string]interface{}{
 						"name":    "api_verification",
 						"status":  "error",
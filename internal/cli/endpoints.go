@@ -1,9 +1,11 @@
 package cli
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
+	"slices"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -55,12 +57,12 @@ var endpointsListCmd = &cobra.Command{
 			f := getFormatter()
 			return f.Error("not_authenticated", err.Error(), "")
 		}
-		ep := api.NewEndpointsAPI(client)
-		chains, err := ep.Search("", epEcosystem, epNodeType, epProtocol, epNetwork)
+		ep := newEndpointsAPI(client)
+		chains, err := ep.Search(cmd.Context(), "", epEcosystem, epNodeType, epProtocol, epNetwork)
 		if err != nil {
 			return formatCommandError(err)
 		}
-		chains = applyPremiumEndpointAccess(client, chains)
+		chains = applyPremiumEndpointAccess(cmd.Context(), client, chains)
 		chains, err = applyEndpointKey(cmd, client, chains, selectorOverride)
 		if err != nil {
 			return formatEndpointKeyError(err)
@@ -86,12 +88,12 @@ var endpointsSearchCmd = &cobra.Command{
 			f := getFormatter()
 			return f.Error("not_authenticated", err.Error(), "")
 		}
-		ep := api.NewEndpointsAPI(client)
-		chains, err := ep.Search(query, epEcosystem, epNodeType, epProtocol, epNetwork)
+		ep := newEndpointsAPI(client)
+		chains, err := ep.Search(cmd.Context(), query, epEcosystem, epNodeType, epProtocol, epNetwork)
 		if err != nil {
 			return formatCommandError(err)
 		}
-		chains = applyPremiumEndpointAccess(client, chains)
+		chains = applyPremiumEndpointAccess(cmd.Context(), client, chains)
 		chains, err = applyEndpointKey(cmd, client, chains, selectorOverride)
 		if err != nil {
 			return formatEndpointKeyError(err)
@@ -117,20 +119,20 @@ var endpointsGetCmd = &cobra.Command{
 			f := getFormatter()
 			return f.Error("not_authenticated", err.Error(), "")
 		}
-		ep := api.NewEndpointsAPI(client)
-		chains, err := ep.Get(chainLookup, epEcosystem, epNodeType, epProtocol, epNetwork)
+		ep := newEndpointsAPI(client)
+		chains, err := ep.Get(cmd.Context(), chainLookup, epEcosystem, epNodeType, epProtocol, epNetwork)
 		if err != nil {
 			return formatCommandError(err)
 		}
-		chains = applyPremiumEndpointAccess(client, chains)
+		if len(chains) == 0 {
+			return chainNotFoundError(cmd.Context(), ep, chainLookup)
+		}
+		chains = applyPremiumEndpointAccess(cmd.Context(), client, chains)
 		chains, err = applyEndpointKey(cmd, client, chains, selectorOverride)
 		if err != nil {
 			return formatEndpointKeyError(err)
 		}
 		f := getFormatter()
-		if len(chains) == 0 {
-			return f.Error("not_found", "No endpoints found for '"+chainLookup+"'.", "Run 'dwellir endpoints list' to see all available chains.")
-		}
 		return f.Success("endpoints.get", chains)
 	},
 }
@@ -144,6 +146,8 @@ func init() {
 	if keyFlag := endpointsCmd.PersistentFlags().Lookup("key"); keyFlag != nil {
 		keyFlag.NoOptDefVal = endpointAutoKeySentinel
 	}
+	addCatalogFlags(endpointsCmd)
+	endpointsGetCmd.ValidArgsFunction = completeChainNames
 	endpointsCmd.AddCommand(endpointsListCmd, endpointsSearchCmd, endpointsGetCmd)
 	rootCmd.AddCommand(endpointsCmd)
 }
@@ -163,6 +167,7 @@ func newAPIClient() (*api.Client, error) {
 	}
 
 	client := api.NewClient(baseURL, token)
+	configureRetries(client)
 
 	client.OnTokenRefresh = func(newToken string) {
 		ctx := resolveProfileContext(profile, cwd, configDir)
@@ -190,16 +195,11 @@ func applyEndpointKey(cmd *cobra.Command, client *api.Client, chains []api.Chain
 		return chains, nil
 	}
 
-	keys, err := api.NewKeysAPI(client).List()
-	if err != nil {
-		return nil, err
-	}
-
 	selector := strings.TrimSpace(epKeyName)
 	if strings.TrimSpace(selectorOverride) != "" {
 		selector = strings.TrimSpace(selectorOverride)
 	}
-	selectedKey, err := selectEndpointKey(keys, selector)
+	selectedKey, err := endpointKeyForChains(cmd.Context(), client, chains, selector)
 	if err != nil {
 		return nil, err
 	}
@@ -207,6 +207,58 @@ func applyEndpointKey(cmd *cobra.Command, client *api.Client, chains []api.Chain
 	return injectEndpointKey(chains, selectedKey), nil
 }
 
+// endpointKeyForChains selects the API key to inject into the chains' URLs,
+// or returns "" without listing keys when no URL needs one. Keys can only be
+// listed online, so under --offline it fails rather than return keyless URLs.
+func endpointKeyForChains(ctx context.Context, client *api.Client, chains []api.Chain, keySelector string) (string, error) {
+	if !chainsNeedKey(chains) {
+		return "", nil
+	}
+	if catalogOffline {
+		return "", endpointKeyError{
+			message: "API keys cannot be fetched with --offline.",
+			help:    "Drop --offline to fetch the API key for the endpoint URLs.",
+		}
+	}
+	keys, err := api.NewKeysAPI(client).List(ctx)
+	if err != nil {
+		return "", err
+	}
+	return selectEndpointKey(keys, keySelector)
+}
+
+// keyedEndpointChains returns a copy of chains with the API key chosen by
+// keySelector injected, leaving the <key> templates of chains for display.
+func keyedEndpointChains(ctx context.Context, client *api.Client, chains []api.Chain, keySelector string) ([]api.Chain, error) {
+	keyValue, err := endpointKeyForChains(ctx, client, chains, keySelector)
+	if err != nil {
+		return nil, err
+	}
+	keyed := make([]api.Chain, len(chains))
+	for i, chain := range chains {
+		chain.Networks = slices.Clone(chain.Networks)
+		for j := range chain.Networks {
+			chain.Networks[j].Nodes = slices.Clone(chain.Networks[j].Nodes)
+		}
+		keyed[i] = chain
+	}
+	return injectEndpointKey(keyed, keyValue), nil
+}
+
+// chainsNeedKey reports whether any URL of chains has a <key> placeholder.
+func chainsNeedKey(chains []api.Chain) bool {
+	for _, chain := range chains {
+		for _, network := range chain.Networks {
+			for _, node := range network.Nodes {
+				if strings.Contains(node.HTTPS, "<key>") || strings.Contains(node.WSS, "<key>") {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
 func endpointsListArgs(cmd *cobra.Command, args []string) error {
 	_, err := endpointOptionalKeySelectorFromArgs(cmd, args)
 	return err
@@ -329,6 +381,171 @@ func injectEndpointKey(chains []api.Chain, keyValue string) []api.Chain {
 	return chains
 }
 
+// endpointTarget is a single node URL resolved from the catalog for a chain.
+type endpointTarget struct {
+	Chain    string
+	Network  string
+	NodeType string
+	// Template is the published URL with its <key> placeholder, safe to display.
+	Template string
+	// URL is the dialable URL with the selected API key injected.
+	URL string
+}
+
+type endpointResolveError struct {
+	code        string
+	message     string
+	help        string
+	suggestions []string
+}
+
+func (e endpointResolveError) Error() string {
+	return e.message
+}
+
+// resolveEndpointTarget looks up a chain, narrows it to a single node for the
+// given protocol, and injects the API key chosen by keySelector.
+func resolveEndpointTarget(ctx context.Context, client *api.Client, chainLookup string, ecosystem string, nodeType string, protocol string, network string, keySelector string) (endpointTarget, error) {
+	ep := newEndpointsAPI(client)
+	chains, err := ep.Get(ctx, chainLookup, ecosystem, nodeType, protocol, network)
+	if err != nil {
+		return endpointTarget{}, err
+	}
+	if len(chains) == 0 {
+		suggestions, _ := ep.Suggest(ctx, chainLookup, chainSuggestionLimit)
+		return endpointTarget{}, endpointResolveError{
+			code:        "not_found",
+			message:     fmt.Sprintf("No %s endpoints found for '%s'.", strings.ToUpper(protocol), chainLookup),
+			help:        chainSuggestionHelp(suggestions, "Run 'dwellir endpoints search <query>' to find the chain name and available networks."),
+			suggestions: suggestions,
+		}
+	}
+	chain := chains[0]
+
+	net, err := selectTargetNetwork(chain, network)
+	if err != nil {
+		return endpointTarget{}, err
+	}
+	node, err := selectTargetNode(chain, net)
+	if err != nil {
+		return endpointTarget{}, err
+	}
+
+	// Key only the chosen node, so keys are listed only when its URL needs one.
+	chain.Networks = []api.Network{net}
+	chain.Networks[0].Nodes = []api.Node{node}
+	keyed, err := keyedEndpointChains(ctx, client, []api.Chain{chain}, keySelector)
+	if err != nil {
+		return endpointTarget{}, err
+	}
+	keyedNode := keyed[0].Networks[0].Nodes[0]
+
+	template, url := node.HTTPS, keyedNode.HTTPS
+	if strings.EqualFold(protocol, "wss") {
+		template, url = node.WSS, keyedNode.WSS
+	}
+	return endpointTarget{
+		Chain:    chain.Name,
+		Network:  net.Name,
+		NodeType: node.NodeType.Name,
+		Template: template,
+		URL:      url,
+	}, nil
+}
+
+func selectTargetNetwork(chain api.Chain, network string) (api.Network, error) {
+	if len(chain.Networks) == 1 {
+		return chain.Networks[0], nil
+	}
+	if strings.TrimSpace(network) == "" {
+		var mainnets []api.Network
+		for _, net := range chain.Networks {
+			if matchesMainnet(net.Name) {
+				mainnets = append(mainnets, net)
+			}
+		}
+		if len(mainnets) == 1 {
+			return mainnets[0], nil
+		}
+	}
+
+	names := make([]string, 0, len(chain.Networks))
+	for _, net := range chain.Networks {
+		names = append(names, net.Name)
+	}
+	return api.Network{}, endpointResolveError{
+		code:    "validation_error",
+		message: fmt.Sprintf("Multiple %s networks matched: %s.", chain.Name, strings.Join(names, ", ")),
+		help:    "Choose one with --network <name>.",
+	}
+}
+
+// selectTargetNode picks the node of a network that the catalog lookup left.
+// When a network has several, the full node is the default; without one the
+// caller has to choose with --node-type.
+func selectTargetNode(chain api.Chain, net api.Network) (api.Node, error) {
+	if len(net.Nodes) == 1 {
+		return net.Nodes[0], nil
+	}
+	for _, node := range net.Nodes {
+		if strings.EqualFold(node.NodeType.Name, "full") {
+			return node, nil
+		}
+	}
+
+	types := make([]string, 0, len(net.Nodes))
+	for _, node := range net.Nodes {
+		types = append(types, node.NodeType.Name)
+	}
+	return api.Node{}, endpointResolveError{
+		code:    "validation_error",
+		message: fmt.Sprintf("Multiple %s %s node types matched: %s.", chain.Name, net.Name, strings.Join(types, ", ")),
+		help:    "Choose one with --node-type <type>.",
+	}
+}
+
+func matchesMainnet(networkName string) bool {
+	return strings.Contains(strings.ToLower(networkName), "mainnet")
+}
+
+func formatEndpointResolveError(err error) error {
+	var resolveErr endpointResolveError
+	if errors.As(err, &resolveErr) {
+		if len(resolveErr.suggestions) > 0 {
+			return getFormatter().ErrorWithDetails(resolveErr.code, resolveErr.message, resolveErr.help, chainSuggestions{resolveErr.suggestions})
+		}
+		return getFormatter().Error(resolveErr.code, resolveErr.message, resolveErr.help)
+	}
+	return formatEndpointKeyError(err)
+}
+
+// chainSuggestionLimit caps the "did you mean" hints of a failed chain lookup.
+const chainSuggestionLimit = 3
+
+// chainSuggestions is the error detail attached to a failed chain lookup.
+type chainSuggestions struct {
+	Suggestions []string `json:"suggestions"`
+}
+
+// chainNotFoundError renders the not_found error for a chain lookup, with "did
+// you mean" suggestions from the catalog when any chain looks similar.
+func chainNotFoundError(ctx context.Context, ep *api.EndpointsAPI, chainLookup string) error {
+	message := "No endpoints found for '" + chainLookup + "'."
+	suggestions, _ := ep.Suggest(ctx, chainLookup, chainSuggestionLimit)
+	help := chainSuggestionHelp(suggestions, "Run 'dwellir endpoints list' to see all available chains.")
+	if len(suggestions) == 0 {
+		return getFormatter().Error("not_found", message, help)
+	}
+	return getFormatter().ErrorWithDetails("not_found", message, help, chainSuggestions{suggestions})
+}
+
+func chainSuggestionHelp(suggestions []string, fallback string) string {
+	if len(suggestions) == 0 {
+		return fallback
+	}
+	return "Did you mean: " + strings.Join(suggestions, ", ") + "?\n" + fallback
+}
+
 func formatEndpointKeyError(err error) error {
 	var keyErr endpointKeyError
 	if errors.As(err, &keyErr) {
@@ -337,12 +554,13 @@ func formatEndpointKeyError(err error) error {
 	return formatCommandError(err)
 }
 
-func applyPremiumEndpointAccess(client *api.Client, chains []api.Chain) []api.Chain {
-	if len(chains) == 0 {
+func applyPremiumEndpointAccess(ctx context.Context, client *api.Client, chains []api.Chain) []api.Chain {
+	// Premium labels need the account API, which --offline promises not to touch.
+	if len(chains) == 0 || catalogOffline {
 		return chains
 	}
 
-	info, err := api.NewAccountAPI(client).Info()
+	info, err := api.NewAccountAPI(client).Info(ctx)
 	if err != nil {
 		return chains
 	}
@@ -1,9 +1,16 @@
 package cli
 
 import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/spf13/cobra"
+
+	"github.com/dwellir-public/cli/internal/api"
 )
 
 func TestEndpointOptionalKeySelectorFromArgs(t *testing.T) {
@@ -27,3 +34,56 @@ func TestEndpointOptionalKeySelectorFromArgs(t *testing.T) {
 		t.Fatalf("expected my-key, got %q", selector)
 	}
 }
+
+// offlineKeyTestServer fails the test on any API call, since --offline
+// promises not to touch the API.
+func offlineKeyTestServer(t *testing.T) *api.Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected API call with --offline: %s", r.URL.Path)
+	}))
+	t.Cleanup(server.Close)
+	catalogOffline = true
+	t.Cleanup(func() { catalogOffline = false })
+	return api.NewClient(server.URL, "token")
+}
+
+func offlineKeyTestChains() []api.Chain {
+	return []api.Chain{{Name: "Base", Ecosystem: "evm", Networks: []api.Network{
+		{Name: "Mainnet", Nodes: []api.Node{{HTTPS: "https://base.example/<key>", NodeType: api.NodeType{Name: "full"}}}},
+	}}}
+}
+
+func TestApplyEndpointKeyRejectsOffline(t *testing.T) {
+	client := offlineKeyTestServer(t)
+
+	cmd := &cobra.Command{}
+	cmd.Flags().String("key", "", "test")
+	if err := cmd.Flags().Set("key", "my-key"); err != nil {
+		t.Fatalf("failed to set key flag: %v", err)
+	}
+
+	_, err := applyEndpointKey(cmd, client, offlineKeyTestChains(), "")
+	var keyErr endpointKeyError
+	if !errors.As(err, &keyErr) || !strings.Contains(keyErr.message, "--offline") {
+		t.Fatalf("expected a validation error about --offline, got %v", err)
+	}
+}
+
+func TestBuildProbeTargetsRejectsOffline(t *testing.T) {
+	client := offlineKeyTestServer(t)
+
+	_, err := buildProbeTargets(context.Background(), client, offlineKeyTestChains(), "foo")
+	var keyErr endpointKeyError
+	if !errors.As(err, &keyErr) || !strings.Contains(keyErr.message, "--offline") {
+		t.Fatalf("expected a validation error about --offline, got %v", err)
+	}
+
+	// URLs without a placeholder need no key and still work offline.
+	chains := offlineKeyTestChains()
+	chains[0].Networks[0].Nodes[0].HTTPS = "https://base.example/public"
+	targets, err := buildProbeTargets(context.Background(), client, chains, "")
+	if err != nil || len(targets) != 1 || targets[0].URL != "https://base.example/public" {
+		t.Fatalf("expected the keyless target, got %+v (%v)", targets, err)
+	}
+}
@@ -0,0 +1,230 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dwellir-public/cli/internal/api"
+	"github.com/dwellir-public/cli/internal/config"
+)
+
+var (
+	epDiffSave     string
+	epDiffNoUpdate bool
+	epDiffExitCode bool
+)
+
+var endpointsDiffCmd = &cobra.Command{
+	Use:   "diff [old-snapshot] [new-snapshot]",
+	Short: "Report catalog changes since the last snapshot",
+	Long: `Compare the endpoint catalog with an earlier snapshot and report added,
+removed, and changed chains, networks, and nodes (URLs, node types, and
+premium status).
+
+With no arguments the live catalog is compared with the snapshot saved by the
+previous run, which is then replaced. The first run only saves a baseline.
+With one argument the live catalog is compared with that snapshot file; with
+two arguments the two files are compared without contacting the API.
+
+Snapshot files are written by --save, and the output of
+'dwellir endpoints list --json' is accepted as well. The endpoints filters
+(--ecosystem, --network, ...) narrow the comparison.
+
+Premium status comes from the account API. The diff fails when it cannot be
+looked up; with --offline it is left out of the comparison and the saved
+snapshot is not replaced.
+
+Pass --exit-code to fail with a catalog_changed error when anything changed,
+e.g. in a scheduled CI job.
+
+Examples:
+  dwellir endpoints diff
+  dwellir endpoints diff --ecosystem evm --exit-code
+  dwellir endpoints diff --save catalog-$(date +%F).json
+  dwellir endpoints diff catalog-2026-01-01.json catalog-2026-02-01.json`,
+	Args: endpointsDiffArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		snapshotPath := filepath.Join(config.DefaultConfigDir(), "snapshots", "endpoints.json")
+
+		var from, to api.CatalogSnapshot
+		var fromLabel, toLabel string
+		baseline := false
+		if len(args) == 2 {
+			var err error
+			if from, err = readDiffSnapshot(args[0]); err != nil {
+				return err
+			}
+			if to, err = readDiffSnapshot(args[1]); err != nil {
+				return err
+			}
+			fromLabel, toLabel = args[0], args[1]
+		} else {
+			client, err := newAPIClient()
+			if err != nil {
+				return getFormatter().Error("not_authenticated", err.Error(), "")
+			}
+			if to, err = liveDiffSnapshot(cmd.Context(), client); err != nil {
+				return err
+			}
+			toLabel = "live catalog"
+
+			if len(args) == 1 {
+				if from, err = readDiffSnapshot(args[0]); err != nil {
+					return err
+				}
+				fromLabel = args[0]
+			} else {
+				from, err = api.ReadCatalogSnapshot(snapshotPath)
+				switch {
+				case errors.Is(err, fs.ErrNotExist) && catalogOffline:
+					return getFormatter().Error("validation_error", "No saved snapshot to compare with, and --offline cannot save a baseline.", "Run 'dwellir endpoints diff' once without --offline to save the baseline.")
+				case errors.Is(err, fs.ErrNotExist):
+					baseline = true
+					from = to
+				case err != nil:
+					return getFormatter().Error("invalid_snapshot", err.Error(), "Delete "+snapshotPath+" to start over with a new baseline.")
+				}
+				fromLabel = "saved snapshot"
+				if !from.TakenAt.IsZero() {
+					fromLabel = "snapshot of " + from.TakenAt.Format(time.RFC3339)
+				}
+			}
+		}
+
+		fromChains, toChains := filterDiffChains(from.Chains), filterDiffChains(to.Chains)
+		if catalogOffline {
+			fromChains, toChains = withoutPremium(fromChains), withoutPremium(toChains)
+		}
+		changes := api.DiffCatalogs(fromChains, toChains)
+		diff := api.NewCatalogDiff(fromLabel, toLabel, changes)
+		diff.Baseline = baseline
+
+		// An offline catalog has no premium labels; saving it would make the
+		// next run report every premium node as changed.
+		updated := len(args) == 0 && !epDiffNoUpdate && !catalogOffline
+		if updated {
+			if err := api.WriteCatalogSnapshot(snapshotPath, to); err != nil {
+				return getFormatter().Error("write_failed", fmt.Sprintf("Could not save snapshot: %v", err), "")
+			}
+		}
+		if epDiffSave != "" {
+			if err := api.WriteCatalogSnapshot(epDiffSave, to); err != nil {
+				return getFormatter().Error("write_failed", fmt.Sprintf("Could not write %s: %v", epDiffSave, err), "")
+			}
+		}
+
+		if !epDiffExitCode || len(diff.Changes) == 0 {
+			return getFormatter().Success("endpoints.diff", diff)
+		}
+		if isHumanOutput() {
+			if err := getFormatter().Success("endpoints.diff", diff); err != nil {
+				return err
+			}
+			_, _ = fmt.Fprintln(rootCmd.OutOrStdout())
+		}
+		help := "The saved snapshot was not updated; rerun without --no-update or --offline to acknowledge the changes."
+		if updated {
+			help = "The saved snapshot now matches the live catalog, so the next run only reports newer changes."
+		} else if len(args) > 0 {
+			help = ""
+		}
+		return getFormatter().ErrorWithDetails(
+			"catalog_changed",
+			fmt.Sprintf("The endpoint catalog changed: %d added, %d removed, %d changed.", diff.Added, diff.Removed, diff.Changed),
+			help,
+			diff,
+		)
+	},
+}
+
+// liveDiffSnapshot fetches the live catalog with premium labels. Without the
+// labels every premium node would show up as changed, so a failed account
+// lookup fails the diff instead of falling back to an unlabelled catalog.
+func liveDiffSnapshot(ctx context.Context, client *api.Client) (api.CatalogSnapshot, error) {
+	ep := newEndpointsAPI(client)
+	// Always revalidate: a diff against a catalog cached an hour ago would
+	// hide the changes it is meant to report.
+	ep.Cache.TTL = 0
+	chains, err := ep.List(ctx)
+	if err != nil {
+		return api.CatalogSnapshot{}, formatCommandError(err)
+	}
+	if !catalogOffline && len(chains) > 0 {
+		info, err := api.NewAccountAPI(client).Info(ctx)
+		if err != nil {
+			if code, message, help, ok := classifyContextError(err); ok {
+				return api.CatalogSnapshot{}, getFormatter().Error(code, message, help)
+			}
+			return api.CatalogSnapshot{}, getFormatter().Error(
+				"premium_lookup_failed",
+				fmt.Sprintf("Could not look up premium endpoint access: %v", err),
+				"Premium status is part of the diff. Try again, or pass --offline to compare without it.",
+			)
+		}
+		chains = api.ApplyPremiumEndpointLabels(chains, info)
+	}
+	return api.CatalogSnapshot{TakenAt: time.Now().UTC(), Chains: chains}, nil
+}
+
+// withoutPremium returns a copy of chains without premium labels, for
+// comparing catalogs when the labels of one side are unknown.
+func withoutPremium(chains []api.Chain) []api.Chain {
+	out := make([]api.Chain, len(chains))
+	for i, chain := range chains {
+		chain.Networks = slices.Clone(chain.Networks)
+		for j := range chain.Networks {
+			nodes := slices.Clone(chain.Networks[j].Nodes)
+			for k := range nodes {
+				nodes[k].Premium, nodes[k].PremiumStatus, nodes[k].TrialEndsAt = false, "", ""
+			}
+			chain.Networks[j].Nodes = nodes
+		}
+		out[i] = chain
+	}
+	return out
+}
+
+// filterDiffChains applies the endpoints filters. Without filters the catalog
+// is compared as is, so chains announced without nodes still show up.
+func filterDiffChains(chains []api.Chain) []api.Chain {
+	if epEcosystem == "" && epNodeType == "" && epProtocol == "" && epNetwork == "" {
+		return chains
+	}
+	return api.FilterChains(chains, epEcosystem, epNodeType, epProtocol, epNetwork)
+}
+
+func readDiffSnapshot(path string) (api.CatalogSnapshot, error) {
+	snapshot, err := api.ReadCatalogSnapshot(path)
+	if err != nil {
+		return api.CatalogSnapshot{}, getFormatter().Error("invalid_snapshot", fmt.Sprintf("Could not read snapshot: %v", err), "Snapshots are written by 'dwellir endpoints diff --save <file>'.")
+	}
+	return snapshot, nil
+}
+
+func endpointsDiffArgs(cmd *cobra.Command, args []string) error {
+	if len(args) > 2 {
+		return getFormatter().Error(
+			"validation_error",
+			fmt.Sprintf("Too many arguments for endpoints diff (got %d).", len(args)),
+			"Usage: dwellir endpoints diff [old-snapshot] [new-snapshot]",
+		)
+	}
+	if len(args) == 2 && epDiffSave != "" {
+		return getFormatter().Error("validation_error", "--save needs the live catalog; it cannot be combined with two snapshot files.", "")
+	}
+	return nil
+}
+
+func init() {
+	endpointsDiffCmd.Flags().StringVar(&epDiffSave, "save", "", "Also write the current catalog to this snapshot file")
+	endpointsDiffCmd.Flags().BoolVar(&epDiffNoUpdate, "no-update", false, "Compare without replacing the saved snapshot")
+	endpointsDiffCmd.Flags().BoolVar(&epDiffExitCode, "exit-code", false, "Fail with catalog_changed when anything changed")
+	endpointsCmd.AddCommand(endpointsDiffCmd)
+}
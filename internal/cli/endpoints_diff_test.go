@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/dwellir-public/cli/internal/api"
+)
+
+func TestEndpointsDiffKeepsSnapshotWhenPremiumLookupFails(t *testing.T) {
+	var accountDown atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v3/chains":
+			_, _ = w.Write([]byte(`[{"id": 1, "name": "Ethereum", "ecosystem": "evm", "networks": [
+  {"id": 1, "name": "Mainnet", "nodes": [{"id": 1, "https": "https://eth.example/<key>", "node_type": {"name": "full"}}]}
+]}]`))
+		case "/v4/organization/information/outseta":
+			if accountDown.Load() {
+				http.Error(w, "unavailable", http.StatusServiceUnavailable)
+				return
+			}
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	configDir := t.TempDir()
+	t.Setenv("DWELLIR_CONFIG_DIR", configDir)
+	t.Setenv("DWELLIR_API_URL", server.URL)
+	t.Setenv("DWELLIR_TOKEN", "token")
+	resetOutputFlagsForTest(t)
+	clearAgentMarkers(t)
+
+	oldArgs := os.Args
+	oldTelemetry := telemetryClient
+	telemetryClient = &fakeTelemetry{}
+	rootCmd.SetOut(io.Discard)
+	rootCmd.SetErr(io.Discard)
+	t.Cleanup(func() {
+		os.Args = oldArgs
+		telemetryClient = oldTelemetry
+		catalogOffline = false
+		endpointsCmd.PersistentFlags().Lookup("offline").Changed = false
+		epDiffExitCode = false
+		rootCmd.SetArgs(nil)
+	})
+	run := func(args ...string) error {
+		args = append([]string{"endpoints", "diff", "--json"}, args...)
+		rootCmd.SetArgs(args)
+		os.Args = append([]string{"dwellir"}, args...)
+		return Execute()
+	}
+
+	if err := run(); err != nil {
+		t.Fatalf("baseline run failed: %v", err)
+	}
+	snapshotPath := filepath.Join(configDir, "snapshots", "endpoints.json")
+	saved, err := os.ReadFile(snapshotPath)
+	if err != nil {
+		t.Fatalf("expected a baseline snapshot: %v", err)
+	}
+
+	accountDown.Store(true)
+	if err := run(); err == nil {
+		t.Fatal("expected the diff to fail when premium access cannot be looked up")
+	}
+	if after, _ := os.ReadFile(snapshotPath); string(after) != string(saved) {
+		t.Fatal("expected the saved snapshot to be left alone")
+	}
+
+	if err := run("--offline", "--exit-code"); err != nil {
+		t.Fatalf("expected an offline diff without premium status to pass: %v", err)
+	}
+	if after, _ := os.ReadFile(snapshotPath); string(after) != string(saved) {
+		t.Fatal("expected an offline diff not to replace the saved snapshot")
+	}
+}
+
+func TestWithoutPremiumDropsLabelsFromACopy(t *testing.T) {
+	chains := []api.Chain{{Name: "Hyperliquid", Networks: []api.Network{
+		{Name: "Mainnet", Nodes: []api.Node{{HTTPS: "https://hl.example", Premium: true, PremiumStatus: "trial_active", TrialEndsAt: "2026-11-01"}}},
+	}}}
+	stripped := withoutPremium(chains)
+	if node := stripped[0].Networks[0].Nodes[0]; node.Premium || node.PremiumStatus != "" || node.TrialEndsAt != "" || node.HTTPS != "https://hl.example" {
+		t.Fatalf("expected only the premium labels to be dropped, got %+v", node)
+	}
+	if !chains[0].Networks[0].Nodes[0].Premium {
+		t.Fatal("expected the original chains to keep their labels")
+	}
+}
@@ -0,0 +1,304 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dwellir-public/cli/internal/api"
+	"github.com/dwellir-public/cli/internal/rpc"
+)
+
+var (
+	epExportFormat string
+	epExportOutput string
+)
+
+var endpointExportFormats = []string{"dotenv", "foundry", "hardhat", "viem", "ethers"}
+
+var endpointsExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export endpoints as framework configuration",
+	Long: `Export endpoints as a ready-to-paste configuration snippet.
+
+Formats:
+  dotenv   CHAIN_NETWORK_RPC_URL / CHAIN_NETWORK_WS_URL variables (all ecosystems)
+  foundry  [rpc_endpoints] table for foundry.toml
+  hardhat  networks object for hardhat.config.ts
+  viem     transports keyed by network
+  ethers   JsonRpcProvider instances keyed by network
+
+Names are derived from the chain and network names, so they stay stable
+between exports. The foundry, hardhat, viem and ethers formats only include
+EVM chains, and foundry and hardhat, which expect HTTP RPC URLs, skip nodes
+that only serve WebSockets. Filters and --key work exactly like 'dwellir
+endpoints list'; without --key the URLs keep their <key> placeholder.
+
+Examples:
+  dwellir endpoints export --format dotenv --ecosystem evm --network mainnet --key
+  dwellir endpoints export --format foundry --network mainnet --key=ci --output foundry-endpoints.toml
+  dwellir endpoints export --format hardhat --network sepolia`,
+	Args: endpointsListArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format := strings.ToLower(strings.TrimSpace(epExportFormat))
+		if !slices.Contains(endpointExportFormats, format) {
+			return getFormatter().Error(
+				"validation_error",
+				fmt.Sprintf("Invalid --format %q.", epExportFormat),
+				"Supported formats: "+strings.Join(endpointExportFormats, ", "),
+			)
+		}
+		selectorOverride, err := endpointOptionalKeySelectorFromArgs(cmd, args)
+		if err != nil {
+			return err
+		}
+
+		client, err := newAPIClient()
+		if err != nil {
+			return getFormatter().Error("not_authenticated", err.Error(), "")
+		}
+		chains, err := newEndpointsAPI(client).Search(cmd.Context(), "", epEcosystem, epNodeType, epProtocol, epNetwork)
+		if err != nil {
+			return formatCommandError(err)
+		}
+		chains = applyPremiumEndpointAccess(cmd.Context(), client, chains)
+		chains, err = applyEndpointKey(cmd, client, chains, selectorOverride)
+		if err != nil {
+			return formatEndpointKeyError(err)
+		}
+
+		entries := exportableEntries(format, buildExportEntries(chains, format != "dotenv"))
+		if len(entries) == 0 {
+			help := "Run 'dwellir endpoints list' to see all available chains."
+			switch {
+			case exportNeedsHTTPS(format):
+				help = "The " + format + " format only includes EVM chains with an HTTPS endpoint. Use --format viem or dotenv for WebSocket-only nodes."
+			case format != "dotenv":
+				help = "The " + format + " format only includes EVM chains. Use --format dotenv for other ecosystems."
+			}
+			return getFormatter().Error("not_found", "No endpoints matched the given filters.", help)
+		}
+
+		result := endpointExport{
+			Format:  format,
+			Count:   len(entries),
+			Entries: entries,
+			Content: renderEndpointExport(format, entries),
+		}
+		if epExportOutput != "" {
+			if err := os.WriteFile(epExportOutput, []byte(result.Content), 0o600); err != nil {
+				return getFormatter().Error("write_failed", fmt.Sprintf("Could not write %s: %v", epExportOutput, err), "")
+			}
+			result.Path = epExportOutput
+		}
+		return getFormatter().Success("endpoints.export", result)
+	},
+}
+
+// endpointExport is the structured result of an export; Content holds the
+// rendered snippet.
+type endpointExport struct {
+	Format  string                `json:"format"`
+	Count   int                   `json:"count"`
+	Path    string                `json:"path,omitempty"`
+	Entries []endpointExportEntry `json:"entries"`
+	Content string                `json:"content"`
+}
+
+type endpointExportEntry struct {
+	Name      string `json:"name"`
+	Chain     string `json:"chain"`
+	Network   string `json:"network"`
+	NodeType  string `json:"node_type,omitempty"`
+	Ecosystem string `json:"ecosystem,omitempty"`
+	ChainID   uint64 `json:"chain_id,omitempty"`
+	HTTPS     string `json:"https,omitempty"`
+	WSS       string `json:"wss,omitempty"`
+}
+
+// Text renders the export for human output: the snippet itself, or a short
+// note when it was written to a file.
+func (e endpointExport) Text() string {
+	if e.Path != "" {
+		return fmt.Sprintf("Wrote %d endpoint(s) as %s to %s\n", e.Count, e.Format, e.Path)
+	}
+	return e.Content
+}
+
+// buildExportEntries flattens chains into one entry per node, named
+// <chain>-<network>, with a node type suffix when a network has several nodes.
+func buildExportEntries(chains []api.Chain, evmOnly bool) []endpointExportEntry {
+	var entries []endpointExportEntry
+	for _, chain := range chains {
+		if evmOnly && !strings.EqualFold(chain.Ecosystem, "evm") {
+			continue
+		}
+		chainSlug := api.ChainSlug(chain.Name)
+		for _, network := range chain.Networks {
+			networkSlug := api.ChainSlug(network.Name)
+			// "Base Mainnet" under "Base" becomes base-mainnet, not base-base-mainnet.
+			networkSlug = strings.TrimPrefix(networkSlug, chainSlug+"-")
+			name := chainSlug
+			if networkSlug != "" && networkSlug != chainSlug {
+				name += "-" + networkSlug
+			}
+
+			var chainID uint64
+			if identity, ok := rpc.LookupIdentity(chainSlug, api.ChainSlug(network.Name)); ok && identity.Kind == rpc.IdentityEVMChainID {
+				chainID, _ = strconv.ParseUint(identity.Value, 10, 64)
+			}
+
+			for _, node := range network.Nodes {
+				entryName := name
+				if len(network.Nodes) > 1 && node.NodeType.Name != "" {
+					entryName += "-" + api.ChainSlug(node.NodeType.Name)
+				}
+				entries = append(entries, endpointExportEntry{
+					Name:      entryName,
+					Chain:     chain.Name,
+					Network:   network.Name,
+					NodeType:  node.NodeType.Name,
+					Ecosystem: chain.Ecosystem,
+					ChainID:   chainID,
+					HTTPS:     node.HTTPS,
+					WSS:       node.WSS,
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+	// Disambiguate any remaining collisions deterministically.
+	seen := map[string]int{}
+	for i := range entries {
+		seen[entries[i].Name]++
+		if n := seen[entries[i].Name]; n > 1 {
+			entries[i].Name = fmt.Sprintf("%s-%d", entries[i].Name, n)
+		}
+	}
+	return entries
+}
+
+func renderEndpointExport(format string, entries []endpointExportEntry) string {
+	var b strings.Builder
+	switch format {
+	case "dotenv":
+		b.WriteString("# Dwellir endpoints (generated by dwellir endpoints export)\n")
+		for _, e := range entries {
+			name := exportEnvName(e.Name)
+			if e.HTTPS != "" {
+				fmt.Fprintf(&b, "%s_RPC_URL=%s\n", name, e.HTTPS)
+			}
+			if e.WSS != "" {
+				fmt.Fprintf(&b, "%s_WS_URL=%s\n", name, e.WSS)
+			}
+		}
+	case "foundry":
+		b.WriteString("# Dwellir endpoints (generated by dwellir endpoints export)\n[rpc_endpoints]\n")
+		for _, e := range entries {
+			fmt.Fprintf(&b, "%s = %s\n", strings.ReplaceAll(e.Name, "-", "_"), strconv.Quote(exportURL(e)))
+		}
+	case "hardhat":
+		b.WriteString("// Dwellir endpoints (generated by dwellir endpoints export)\n// Spread into `networks` in hardhat.config.ts.\n")
+		b.WriteString("export const dwellirNetworks = {\n")
+		for _, e := range entries {
+			fmt.Fprintf(&b, "  %s: {\n    url: %s,\n", exportJSKey(e.Name), strconv.Quote(exportURL(e)))
+			if e.ChainID > 0 {
+				fmt.Fprintf(&b, "    chainId: %d,\n", e.ChainID)
+			}
+			b.WriteString("  },\n")
+		}
+		b.WriteString("};\n")
+	case "viem":
+		b.WriteString("// Dwellir endpoints (generated by dwellir endpoints export)\n")
+		b.WriteString("import { http, webSocket } from \"viem\";\n\nexport const dwellirTransports = {\n")
+		for _, e := range entries {
+			transport := "http"
+			if e.HTTPS == "" {
+				transport = "webSocket"
+			}
+			fmt.Fprintf(&b, "  %s: %s(%s),\n", exportJSKey(e.Name), transport, strconv.Quote(exportURL(e)))
+		}
+		b.WriteString("};\n")
+	case "ethers":
+		b.WriteString("// Dwellir endpoints (generated by dwellir endpoints export)\n")
+		b.WriteString("import { JsonRpcProvider, WebSocketProvider } from \"ethers\";\n\nexport const dwellirProviders = {\n")
+		for _, e := range entries {
+			provider := "JsonRpcProvider"
+			if e.HTTPS == "" {
+				provider = "WebSocketProvider"
+			}
+			if e.ChainID > 0 {
+				fmt.Fprintf(&b, "  %s: new %s(%s, %d, { staticNetwork: true }),\n", exportJSKey(e.Name), provider, strconv.Quote(exportURL(e)), e.ChainID)
+			} else {
+				fmt.Fprintf(&b, "  %s: new %s(%s),\n", exportJSKey(e.Name), provider, strconv.Quote(exportURL(e)))
+			}
+		}
+		b.WriteString("};\n")
+	}
+	return b.String()
+}
+
+// exportNeedsHTTPS reports whether a format only accepts HTTP RPC URLs.
+func exportNeedsHTTPS(format string) bool {
+	return format == "foundry" || format == "hardhat"
+}
+
+// exportableEntries drops the entries a format cannot express.
+func exportableEntries(format string, entries []endpointExportEntry) []endpointExportEntry {
+	if !exportNeedsHTTPS(format) {
+		return entries
+	}
+	return slices.DeleteFunc(entries, func(e endpointExportEntry) bool { return e.HTTPS == "" })
+}
+
+// exportURL prefers HTTPS for single-URL formats.
+func exportURL(e endpointExportEntry) string {
+	if e.HTTPS != "" {
+		return e.HTTPS
+	}
+	return e.WSS
+}
+
+func exportEnvName(slug string) string {
+	name := strings.ToUpper(strings.ReplaceAll(slug, "-", "_"))
+	if name != "" && name[0] >= '0' && name[0] <= '9' {
+		name = "_" + name
+	}
+	return name
+}
+
+// exportJSKey converts a slug to a camelCase object key, quoting it when the
+// result is not a valid identifier.
+func exportJSKey(slug string) string {
+	parts := strings.Split(slug, "-")
+	var b strings.Builder
+	for i, part := range parts {
+		if part == "" {
+			continue
+		}
+		if i > 0 {
+			part = strings.ToUpper(part[:1]) + part[1:]
+		}
+		b.WriteString(part)
+	}
+	key := b.String()
+	for i, r := range key {
+		isLetter := (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || r == '_' || r == '$'
+		if !isLetter && (i == 0 || r < '0' || r > '9') {
+			return strconv.Quote(slug)
+		}
+	}
+	return key
+}
+
+func init() {
+	endpointsExportCmd.Flags().StringVar(&epExportFormat, "format", "dotenv", "Output format ("+strings.Join(endpointExportFormats, ", ")+")")
+	endpointsExportCmd.Flags().StringVarP(&epExportOutput, "output", "o", "", "Write the snippet to a file instead of stdout")
+	endpointsCmd.AddCommand(endpointsExportCmd)
+}
@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/dwellir-public/cli/internal/api"
+)
+
+func exportTestChains() []api.Chain {
+	return []api.Chain{
+		{Name: "Ethereum", Ecosystem: "evm", Networks: []api.Network{
+			{Name: "Mainnet", Nodes: []api.Node{
+				{HTTPS: "https://eth.example/k", WSS: "wss://eth.example/k", NodeType: api.NodeType{Name: "full"}},
+				{HTTPS: "https://eth-archive.example/k", NodeType: api.NodeType{Name: "archive"}},
+			}},
+			{Name: "Sepolia Testnet", Nodes: []api.Node{{HTTPS: "https://sepolia.example/k", NodeType: api.NodeType{Name: "full"}}}},
+		}},
+		{Name: "Base", Ecosystem: "evm", Networks: []api.Network{
+			{Name: "Base Mainnet", Nodes: []api.Node{{WSS: "wss://base.example/k", NodeType: api.NodeType{Name: "full"}}}},
+		}},
+		{Name: "Polkadot", Ecosystem: "substrate", Networks: []api.Network{
+			{Name: "Mainnet", Nodes: []api.Node{{HTTPS: "https://dot.example/k", NodeType: api.NodeType{Name: "full"}}}},
+		}},
+	}
+}
+
+func TestBuildExportEntriesSlugsNames(t *testing.T) {
+	entries := buildExportEntries(exportTestChains(), false)
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name)
+	}
+	want := "base-mainnet,ethereum-mainnet-archive,ethereum-mainnet-full,ethereum-sepolia-testnet,polkadot-mainnet"
+	if strings.Join(names, ",") != want {
+		t.Fatalf("unexpected names: %v", names)
+	}
+	if entries[1].ChainID != 1 || entries[3].ChainID != 11155111 || entries[4].ChainID != 0 {
+		t.Fatalf("unexpected chain ids: %+v", entries)
+	}
+
+	evmOnly := buildExportEntries(exportTestChains(), true)
+	if len(evmOnly) != 4 {
+		t.Fatalf("expected substrate chain to be dropped, got %+v", evmOnly)
+	}
+}
+
+func TestRenderEndpointExportFormats(t *testing.T) {
+	entries := buildExportEntries(exportTestChains(), true)
+	cases := map[string][]string{
+		"dotenv":  {"ETHEREUM_MAINNET_FULL_RPC_URL=https://eth.example/k\n", "ETHEREUM_MAINNET_FULL_WS_URL=wss://eth.example/k\n", "BASE_MAINNET_WS_URL=wss://base.example/k\n"},
+		"foundry": {"[rpc_endpoints]\n", `ethereum_sepolia_testnet = "https://sepolia.example/k"`},
+		"hardhat": {"  ethereumMainnetArchive: {\n    url: \"https://eth-archive.example/k\",\n    chainId: 1,\n  },\n"},
+		"viem":    {`ethereumMainnetFull: http("https://eth.example/k"),`, `baseMainnet: webSocket("wss://base.example/k"),`},
+		"ethers":  {`ethereumSepoliaTestnet: new JsonRpcProvider("https://sepolia.example/k", 11155111, { staticNetwork: true }),`, `baseMainnet: new WebSocketProvider("wss://base.example/k", 8453, { staticNetwork: true }),`},
+	}
+	for format, wants := range cases {
+		got := renderEndpointExport(format, exportableEntries(format, slices.Clone(entries)))
+		for _, want := range wants {
+			if !strings.Contains(got, want) {
+				t.Errorf("%s export missing %q:\n%s", format, want, got)
+			}
+		}
+		// foundry and hardhat take HTTP RPC URLs only.
+		if exportNeedsHTTPS(format) && strings.Contains(got, "wss://") {
+			t.Errorf("%s export includes a WebSocket URL:\n%s", format, got)
+		}
+	}
+}
+
+func TestExportJSKeyQuotesInvalidIdentifiers(t *testing.T) {
+	if got := exportJSKey("zksync-era-mainnet"); got != "zksyncEraMainnet" {
+		t.Fatalf("unexpected key: %q", got)
+	}
+	if got := exportJSKey("0g-mainnet"); got != `"0g-mainnet"` {
+		t.Fatalf("expected quoted key, got %q", got)
+	}
+}
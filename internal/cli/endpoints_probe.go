@@ -0,0 +1,133 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dwellir-public/cli/internal/api"
+	"github.com/dwellir-public/cli/internal/rpc"
+)
+
+var (
+	epProbeSamples     int
+	epProbeConcurrency int
+	epProbeTimeout     time.Duration
+)
+
+var endpointsProbeCmd = &cobra.Command{
+	Use:   "probe [chain]",
+	Short: "Measure latency and health of endpoint nodes",
+	Long: `Send a lightweight request to every matching node and report latency,
+success rate, head block, and the dominant error class.
+
+Nodes are selected like 'dwellir endpoints search' (the optional chain argument
+is the search query) and probed concurrently. The probe request depends on the
+ecosystem: eth_blockNumber (EVM), chain_getHeader (Substrate), /status (Cosmos),
+and the checkpoint or ledger height for Move chains.
+
+An API key is injected automatically; pass --key=<name> when you have several.
+
+Examples:
+  dwellir endpoints probe ethereum --network mainnet
+  dwellir endpoints probe base --protocol https --samples 10
+  dwellir endpoints probe --ecosystem substrate --network mainnet --concurrency 16`,
+	Args: endpointsProbeArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		query := ""
+		if len(args) > 0 {
+			query = args[0]
+		}
+		if epProbeSamples < 1 || epProbeConcurrency < 1 {
+			return getFormatter().Error("validation_error", "--samples and --concurrency must be at least 1.", "")
+		}
+
+		client, err := newAPIClient()
+		if err != nil {
+			return getFormatter().Error("not_authenticated", err.Error(), "")
+		}
+		chains, err := newEndpointsAPI(client).Search(cmd.Context(), query, epEcosystem, epNodeType, epProtocol, epNetwork)
+		if err != nil {
+			return formatCommandError(err)
+		}
+		if len(chains) == 0 {
+			return getFormatter().Error("not_found", "No endpoints matched the given filters.", "Run 'dwellir endpoints list' to see all available chains.")
+		}
+
+		targets, err := buildProbeTargets(cmd.Context(), client, chains, epKeyName)
+		if err != nil {
+			return formatEndpointKeyError(err)
+		}
+		if isHumanOutput() && !quiet {
+			_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Probing %d endpoint(s) with %d sample(s) each...\n", len(targets), epProbeSamples)
+		}
+
+		results := rpc.Probe(cmd.Context(), targets, rpc.ProbeOptions{
+			Samples:     epProbeSamples,
+			Concurrency: epProbeConcurrency,
+			Timeout:     epProbeTimeout,
+		})
+		if ctxErr := cmd.Context().Err(); ctxErr != nil {
+			return formatCommandError(ctxErr)
+		}
+		return getFormatter().Success("endpoints.probe", results)
+	},
+}
+
+// buildProbeTargets expands chains into one target per node and protocol,
+// injecting an API key when any URL needs one.
+func buildProbeTargets(ctx context.Context, client *api.Client, chains []api.Chain, keySelector string) ([]rpc.ProbeTarget, error) {
+	keyed, err := keyedEndpointChains(ctx, client, chains, keySelector)
+	if err != nil {
+		return nil, err
+	}
+
+	var targets []rpc.ProbeTarget
+	for i, chain := range chains {
+		kind := rpc.ProbeKindFor(chain.Ecosystem, chain.Name)
+		for j, network := range chain.Networks {
+			for k, node := range network.Nodes {
+				keyedNode := keyed[i].Networks[j].Nodes[k]
+				for _, endpoint := range []struct{ protocol, template, url string }{
+					{"https", node.HTTPS, keyedNode.HTTPS},
+					{"wss", node.WSS, keyedNode.WSS},
+				} {
+					if endpoint.template == "" {
+						continue
+					}
+					targets = append(targets, rpc.ProbeTarget{
+						Chain:    chain.Name,
+						Network:  network.Name,
+						NodeType: node.NodeType.Name,
+						Protocol: endpoint.protocol,
+						Endpoint: endpoint.template,
+						URL:      endpoint.url,
+						Kind:     kind,
+					})
+				}
+			}
+		}
+	}
+	return targets, nil
+}
+
+func endpointsProbeArgs(cmd *cobra.Command, args []string) error {
+	if len(args) > 1 {
+		return getFormatter().Error(
+			"validation_error",
+			fmt.Sprintf("Too many arguments for endpoints probe (got %d).", len(args)),
+			"Usage: dwellir endpoints probe [chain] [--key=<name>]",
+		)
+	}
+	return nil
+}
+
+func init() {
+	endpointsProbeCmd.Flags().IntVar(&epProbeSamples, "samples", 3, "Requests sent to each node")
+	endpointsProbeCmd.Flags().IntVar(&epProbeConcurrency, "concurrency", 8, "Nodes probed in parallel")
+	endpointsProbeCmd.Flags().DurationVar(&epProbeTimeout, "request-timeout", 10*time.Second, "Timeout for each probe request")
+	endpointsProbeCmd.ValidArgsFunction = completeChainNames
+	endpointsCmd.AddCommand(endpointsProbeCmd)
+}
@@ -0,0 +1,34 @@
+package cli
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dwellir-public/cli/internal/api"
+	"github.com/dwellir-public/cli/internal/rpc"
+)
+
+func TestBuildProbeTargetsExpandsProtocolsAndInjectsKey(t *testing.T) {
+	server := newEndpointCatalogServer(t)
+	client := api.NewClient(server.URL, "token")
+
+	chains, err := api.NewEndpointsAPI(client).Search(context.Background(), "ethereum", "", "", "", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	targets, err := buildProbeTargets(context.Background(), client, chains, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Mainnet has HTTPS and WSS, Sepolia only HTTPS.
+	if len(targets) != 3 {
+		t.Fatalf("expected 3 targets, got %d: %+v", len(targets), targets)
+	}
+	wss := targets[1]
+	if wss.Protocol != "wss" || wss.URL != "wss://eth-mainnet.example/key-123" || wss.Endpoint != "wss://eth-mainnet.example/<key>" {
+		t.Fatalf("unexpected wss target: %+v", wss)
+	}
+	if wss.Kind != rpc.ProbeEVM || wss.NodeType != "archive" {
+		t.Fatalf("unexpected target metadata: %+v", wss)
+	}
+}
@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dwellir-public/cli/internal/api"
+)
+
+func newEndpointCatalogServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	// Catalog lookups write the on-disk cache; keep it out of the real config dir.
+	t.Setenv("DWELLIR_CONFIG_DIR", t.TempDir())
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v3/chains":
+			_, _ = w.Write([]byte(`[
+  {"id": 1, "name": "Ethereum", "ecosystem": "evm", "networks": [
+    {"id": 1, "name": "Mainnet", "nodes": [
+      {"id": 1, "https": "https://eth-mainnet.example/<key>", "wss": "wss://eth-mainnet.example/<key>", "node_type": {"name": "archive"}}
+    ]},
+    {"id": 2, "name": "Sepolia Testnet", "nodes": [
+      {"id": 2, "https": "https://eth-sepolia.example/<key>", "wss": "", "node_type": {"name": "full"}}
+    ]}
+  ]},
+  {"id": 2, "name": "Polygon", "ecosystem": "evm", "networks": [
+    {"id": 3, "name": "Amoy", "nodes": [{"id": 3, "https": "https://amoy.example/<key>", "node_type": {"name": "full"}}]},
+    {"id": 4, "name": "Cardona", "nodes": [{"id": 4, "https": "https://cardona.example/<key>", "node_type": {"name": "full"}}]}
+  ]},
+  {"id": 3, "name": "Arbitrum", "ecosystem": "evm", "networks": [
+    {"id": 5, "name": "Arbitrum One Mainnet", "nodes": [
+      {"id": 5, "https": "https://arb-archive.example/<key>", "node_type": {"name": "archive"}},
+      {"id": 6, "https": "https://arb-full.example/<key>", "node_type": {"name": "full"}}
+    ]}
+  ]},
+  {"id": 4, "name": "Gnosis", "ecosystem": "evm", "networks": [
+    {"id": 6, "name": "Mainnet", "nodes": [
+      {"id": 7, "https": "https://gnosis-archive.example/<key>", "node_type": {"name": "archive"}},
+      {"id": 8, "https": "https://gnosis-trace.example/<key>", "node_type": {"name": "trace"}}
+    ]}
+  ]}
+]`))
+		case "/v4/organization/apikeys":
+			_, _ = w.Write([]byte(`[{"api_key": "key-123", "name": "ci", "enabled": true}]`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestResolveEndpointTargetDefaultsToMainnetAndInjectsKey(t *testing.T) {
+	server := newEndpointCatalogServer(t)
+	client := api.NewClient(server.URL, "token")
+
+	target, err := resolveEndpointTarget(context.Background(), client, "ethereum", "", "", "https", "", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if target.Network != "Mainnet" || target.URL != "https://eth-mainnet.example/key-123" {
+		t.Fatalf("unexpected target: %+v", target)
+	}
+	if target.Template != "https://eth-mainnet.example/<key>" {
+		t.Fatalf("expected template to keep placeholder, got %q", target.Template)
+	}
+}
+
+func TestResolveEndpointTargetRequiresNetworkWhenAmbiguous(t *testing.T) {
+	server := newEndpointCatalogServer(t)
+	client := api.NewClient(server.URL, "token")
+
+	_, err := resolveEndpointTarget(context.Background(), client, "polygon", "", "", "https", "", "")
+	var resolveErr endpointResolveError
+	if !errors.As(err, &resolveErr) || resolveErr.code != "validation_error" {
+		t.Fatalf("expected validation_error for ambiguous network, got %v", err)
+	}
+
+	target, err := resolveEndpointTarget(context.Background(), client, "polygon", "", "", "https", "amoy", "ci")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if target.URL != "https://amoy.example/key-123" {
+		t.Fatalf("unexpected target URL: %q", target.URL)
+	}
+}
+
+func TestResolveEndpointTargetChoosesANodeType(t *testing.T) {
+	server := newEndpointCatalogServer(t)
+	client := api.NewClient(server.URL, "token")
+
+	target, err := resolveEndpointTarget(context.Background(), client, "arbitrum", "", "", "https", "", "ci")
+	if err != nil || target.NodeType != "full" || target.URL != "https://arb-full.example/key-123" {
+		t.Fatalf("expected the full node by default, got %+v (%v)", target, err)
+	}
+	target, err = resolveEndpointTarget(context.Background(), client, "arbitrum", "", "archive", "https", "", "ci")
+	if err != nil || target.URL != "https://arb-archive.example/key-123" {
+		t.Fatalf("expected the archive node with --node-type archive, got %+v (%v)", target, err)
+	}
+
+	_, err = resolveEndpointTarget(context.Background(), client, "gnosis", "", "", "https", "", "ci")
+	var resolveErr endpointResolveError
+	if !errors.As(err, &resolveErr) || resolveErr.code != "validation_error" || !strings.Contains(resolveErr.message, "archive, trace") {
+		t.Fatalf("expected a validation_error listing the node types, got %v", err)
+	}
+}
+
+func TestResolveEndpointTargetDoesNotListKeysOffline(t *testing.T) {
+	server := newEndpointCatalogServer(t)
+	client := api.NewClient(server.URL, "token")
+
+	// The first lookup caches the catalog for the offline one.
+	if _, err := resolveEndpointTarget(context.Background(), client, "ethereum", "", "", "https", "", "ci"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	catalogOffline = true
+	t.Cleanup(func() { catalogOffline = false })
+
+	_, err := resolveEndpointTarget(context.Background(), client, "ethereum", "", "", "https", "", "ci")
+	var keyErr endpointKeyError
+	if !errors.As(err, &keyErr) || !strings.Contains(keyErr.message, "--offline") {
+		t.Fatalf("expected a key error about --offline instead of a keys lookup, got %v", err)
+	}
+}
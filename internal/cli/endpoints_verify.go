@@ -0,0 +1,143 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dwellir-public/cli/internal/api"
+	"github.com/dwellir-public/cli/internal/rpc"
+)
+
+var (
+	epVerifyConcurrency int
+	epVerifyTimeout     time.Duration
+	epVerifyStrict      bool
+)
+
+var endpointsVerifyCmd = &cobra.Command{
+	Use:   "verify <chain>",
+	Short: "Check that endpoint nodes serve the expected chain",
+	Long: `Ask every node of a chain for its identity and compare it with the
+known identity of the network it is listed under:
+
+  EVM        eth_chainId
+  Substrate  genesis block hash (chain_getBlockHash 0)
+  Cosmos     chain_id from /status
+  Move       Sui chain identifier or Aptos chain_id
+
+The command fails with an identity_mismatch error when any node reports a
+different identity, and with identity_unverified when a node cannot be
+checked. Networks without a known identity are reported as "unknown"; pass
+--strict to fail on those too.
+
+Examples:
+  dwellir endpoints verify ethereum --network mainnet
+  dwellir endpoints verify polkadot --strict --json`,
+	Args: endpointsVerifyArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		chainLookup := args[0]
+		if epVerifyConcurrency < 1 {
+			return getFormatter().Error("validation_error", "--concurrency must be at least 1.", "")
+		}
+
+		client, err := newAPIClient()
+		if err != nil {
+			return getFormatter().Error("not_authenticated", err.Error(), "")
+		}
+		ep := newEndpointsAPI(client)
+		chains, err := ep.Get(cmd.Context(), chainLookup, epEcosystem, epNodeType, epProtocol, epNetwork)
+		if err != nil {
+			return formatCommandError(err)
+		}
+		if len(chains) == 0 {
+			return chainNotFoundError(cmd.Context(), ep, chainLookup)
+		}
+
+		probeTargets, err := buildProbeTargets(cmd.Context(), client, chains, epKeyName)
+		if err != nil {
+			return formatEndpointKeyError(err)
+		}
+		targets := make([]rpc.VerifyTarget, 0, len(probeTargets))
+		for _, target := range probeTargets {
+			verifyTarget := rpc.VerifyTarget{ProbeTarget: target}
+			if expected, ok := rpc.LookupIdentity(api.ChainSlug(target.Chain), api.ChainSlug(target.Network)); ok {
+				verifyTarget.Expected = &expected
+			}
+			targets = append(targets, verifyTarget)
+		}
+
+		results := rpc.Verify(cmd.Context(), targets, epVerifyConcurrency, epVerifyTimeout)
+		if ctxErr := cmd.Context().Err(); ctxErr != nil {
+			return formatCommandError(ctxErr)
+		}
+		rpc.SortVerifyResults(results)
+		return reportVerifyResults(chainLookup, results)
+	},
+}
+
+func reportVerifyResults(chainLookup string, results []rpc.VerifyResult) error {
+	counts := map[string]int{}
+	for _, result := range results {
+		counts[result.Status]++
+	}
+
+	code, message, help := "", "", ""
+	switch {
+	case counts[rpc.VerifyMismatch] > 0:
+		code = "identity_mismatch"
+		var parts []string
+		for _, result := range results {
+			if result.Status != rpc.VerifyMismatch {
+				continue
+			}
+			observed := result.Observed
+			if result.ObservedAs != "" {
+				observed += " (" + result.ObservedAs + ")"
+			}
+			parts = append(parts, fmt.Sprintf("%s %s %s reports %s, expected %s", result.Network, result.NodeType, result.Protocol, observed, result.Expected))
+		}
+		message = fmt.Sprintf("%d of %d node(s) for '%s' serve a different chain: %s.", counts[rpc.VerifyMismatch], len(results), chainLookup, strings.Join(parts, "; "))
+		help = "Do not deploy against these endpoints. Check the --network filter and the endpoint URLs."
+	case counts[rpc.VerifyError] > 0:
+		code = "identity_unverified"
+		message = fmt.Sprintf("%d of %d node(s) for '%s' could not be verified.", counts[rpc.VerifyError], len(results), chainLookup)
+		help = "Run 'dwellir endpoints probe " + chainLookup + "' to diagnose connectivity."
+	case epVerifyStrict && counts[rpc.VerifyUnknown] > 0:
+		code = "identity_unverified"
+		message = fmt.Sprintf("%d of %d node(s) for '%s' have no known identity to compare against.", counts[rpc.VerifyUnknown], len(results), chainLookup)
+		help = "Drop --strict to accept nodes whose network has no shipped identity."
+	}
+
+	if code == "" {
+		return getFormatter().Success("endpoints.verify", results)
+	}
+	if isHumanOutput() {
+		if err := getFormatter().Success("endpoints.verify", results); err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintln(rootCmd.OutOrStdout())
+	}
+	return getFormatter().ErrorWithDetails(code, message, help, results)
+}
+
+func endpointsVerifyArgs(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return getFormatter().Error(
+			"validation_error",
+			"Expected exactly one argument <chain>.",
+			"Example: dwellir endpoints verify ethereum --network mainnet",
+		)
+	}
+	return nil
+}
+
+func init() {
+	endpointsVerifyCmd.Flags().IntVar(&epVerifyConcurrency, "concurrency", 8, "Nodes checked in parallel")
+	endpointsVerifyCmd.Flags().DurationVar(&epVerifyTimeout, "request-timeout", 10*time.Second, "Timeout for each identity request")
+	endpointsVerifyCmd.Flags().BoolVar(&epVerifyStrict, "strict", false, "Fail when a network has no known identity")
+	endpointsVerifyCmd.ValidArgsFunction = completeChainNames
+	endpointsCmd.AddCommand(endpointsVerifyCmd)
+}
@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strings"
@@ -13,6 +14,14 @@ func formatCommandError(err error) error {
 		return nil
 	}
 
+	if code, message, help, ok := classifyContextError(err); ok {
+		return getFormatter().Error(code, message, help)
+	}
+
+	if errors.Is(err, api.ErrCatalogNotCached) {
+		return getFormatter().Error("not_cached", "The chain catalog has not been cached yet.", "Run the command once without --offline to populate the cache.")
+	}
+
 	var apiErr *api.APIError
 	if errors.As(err, &apiErr) {
 		code := "api_error"
@@ -33,3 +42,14 @@ func formatCommandError(err error) error {
 
 	return getFormatter().Error("error", err.Error(), "")
 }
+
+// classifyContextError maps cancellation and deadline errors to stable error codes.
+func classifyContextError(err error) (code, message, help string, ok bool) {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return "cancelled", "Command cancelled.", "", true
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout", "Command timed out.", "Increase the deadline with --timeout (e.g. --timeout 2m).", true
+	}
+	return "", "", "", false
+}
@@ -3,6 +3,10 @@ package cli
 import "strings"
 
 func classifyExecutionError(err error) (code, message, help string) {
+	if code, message, help, ok := classifyContextError(err); ok {
+		return code, message, help
+	}
+
 	raw := strings.TrimSpace(err.Error())
 	if raw == "" {
 		return "error", "Command failed.", "Run `dwellir --help` to view available commands."
@@ -22,6 +26,10 @@ func classifyExecutionError(err error) (code, message, help string) {
 		return "validation_error", "Missing required arguments.", raw + "\nRun the command with --help to see examples."
 	}
 
+	if strings.Contains(raw, "none of the others can be") {
+		return "validation_error", raw, "Pass only one of the listed flags."
+	}
+
 	if strings.Contains(raw, "missing required argument") {
 		return "validation_error", raw, ""
 	}
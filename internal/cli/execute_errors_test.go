@@ -1,7 +1,9 @@
 package cli
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"testing"
 )
 
@@ -27,3 +29,16 @@ func TestClassifyExecutionErrorMissingArgs(t *testing.T) {
 		t.Fatalf("expected non-empty help")
 	}
 }
+
+func TestClassifyExecutionErrorContext(t *testing.T) {
+	cases := map[error]string{
+		fmt.Errorf("request failed: %w", context.Canceled):         "cancelled",
+		fmt.Errorf("request failed: %w", context.DeadlineExceeded): "timeout",
+	}
+	for err, want := range cases {
+		code, _, _ := classifyExecutionError(err)
+		if code != want {
+			t.Fatalf("classifyExecutionError(%v) code = %q, want %q", err, code, want)
+		}
+	}
+}
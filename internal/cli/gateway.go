@@ -0,0 +1,193 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dwellir-public/cli/internal/api"
+	"github.com/dwellir-public/cli/internal/proxy"
+	"github.com/dwellir-public/cli/internal/rpc"
+)
+
+var (
+	gatewayChain          string
+	gatewayNetwork        string
+	gatewayEcosystem      string
+	gatewayKeyName        string
+	gatewayListen         string
+	gatewayAllowedOrigin  []string
+	gatewayHealthInterval time.Duration
+	gatewayAttemptTimeout time.Duration
+)
+
+var gatewayCmd = &cobra.Command{
+	Use:   "gateway",
+	Short: "Serve a local JSON-RPC endpoint that fails over across a chain's nodes",
+	Long: `Serve a local JSON-RPC endpoint that spreads traffic over every node of a
+chain network (full and archive), injecting your API key on the way out.
+
+Nodes are health-checked every --health-interval; a node whose check fails or
+whose head lags the others is taken out of rotation until it recovers.
+Archive-only calls (debug_* and trace_* methods, and state reads such as
+eth_getBalance or eth_call at blocks older than a full node keeps) are routed
+to archive nodes; everything else prefers full nodes. A request answered with
+a 5xx, a timeout, or a connection error is retried on the next node. Requests
+with write calls (eth_sendRawTransaction and other send, submit, sign, or
+personal_* methods) are only retried when the node could not be reached, so
+a transaction is never broadcast twice.
+
+WebSocket connections go to the healthiest node with a WSS endpoint.
+
+Routing decisions, failovers, and node health are exported on /metrics next
+to per-method request counts and latency histograms, and summarized when the
+gateway stops (Ctrl-C, or --timeout).
+
+Examples:
+  dwellir gateway --chain ethereum --network mainnet
+  dwellir gateway --chain base --listen 127.0.0.1:9545 --health-interval 5s
+  curl -s localhost:8545/metrics | grep dwellir_gateway_routed_total`,
+	Args: gatewayArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := newAPIClient()
+		if err != nil {
+			return getFormatter().Error("not_authenticated", err.Error(), "")
+		}
+		chain, network, nodes, err := resolveGatewayNodes(cmd.Context(), client)
+		if err != nil {
+			return formatEndpointResolveError(err)
+		}
+
+		metrics := proxy.NewMetrics("dwellir_gateway")
+		gateway := proxy.NewGateway(nodes, metrics)
+		gateway.AttemptTimeout = gatewayAttemptTimeout
+		handler := &proxy.Handler{HTTP: gateway, Metrics: metrics, AllowedOrigins: gatewayAllowedOrigin}
+		if gateway.HasWebSocket() {
+			handler.DialWebSocket = gateway.DialWebSocket
+		}
+
+		ctx, cancel := context.WithCancel(cmd.Context())
+		defer cancel()
+		go gateway.RunHealthChecks(ctx, gatewayHealthInterval, gatewayAttemptTimeout)
+
+		listen, err := serveLocalEndpoint(cmd, gatewayListen, handler, fmt.Sprintf("%d %s %s node(s)", len(nodes), chain.Name, network.Name))
+		if err != nil {
+			return err
+		}
+		cancel()
+		return getFormatter().Success("gateway", proxy.GatewaySummary{
+			Chain:         chain.Name,
+			Network:       network.Name,
+			Listen:        listen,
+			UptimeSeconds: metrics.Uptime().Round(time.Millisecond).Seconds(),
+			Nodes:         gateway.Status(),
+			Methods:       metrics.Snapshot(),
+		})
+	},
+}
+
+// resolveGatewayNodes looks up the chain network and returns one gateway node
+// per catalog node, with the API key injected into its URLs.
+func resolveGatewayNodes(ctx context.Context, client *api.Client) (api.Chain, api.Network, []*proxy.Node, error) {
+	ep := newEndpointsAPI(client)
+	chains, err := ep.Get(ctx, gatewayChain, gatewayEcosystem, "", "", gatewayNetwork)
+	if err != nil {
+		return api.Chain{}, api.Network{}, nil, err
+	}
+	if len(chains) == 0 {
+		suggestions, _ := ep.Suggest(ctx, gatewayChain, chainSuggestionLimit)
+		return api.Chain{}, api.Network{}, nil, endpointResolveError{
+			code:        "not_found",
+			message:     fmt.Sprintf("No endpoints found for '%s'.", gatewayChain),
+			help:        chainSuggestionHelp(suggestions, "Run 'dwellir endpoints search <query>' to find the chain name and available networks."),
+			suggestions: suggestions,
+		}
+	}
+	chain := chains[0]
+	network, err := selectTargetNetwork(chain, gatewayNetwork)
+	if err != nil {
+		return api.Chain{}, api.Network{}, nil, err
+	}
+	chain.Networks = []api.Network{network}
+
+	keyed, err := keyedEndpointChains(ctx, client, []api.Chain{chain}, gatewayKeyName)
+	if err != nil {
+		return api.Chain{}, api.Network{}, nil, err
+	}
+	kind := rpc.ProbeKindFor(chain.Ecosystem, chain.Name)
+	target := func(protocol, template, url, nodeType string) rpc.ProbeTarget {
+		if template == "" {
+			return rpc.ProbeTarget{}
+		}
+		return rpc.ProbeTarget{
+			Chain:    chain.Name,
+			Network:  network.Name,
+			NodeType: nodeType,
+			Protocol: protocol,
+			Endpoint: template,
+			URL:      url,
+			Kind:     kind,
+		}
+	}
+
+	seen := map[string]int{}
+	nodes := make([]*proxy.Node, 0, len(network.Nodes))
+	for i, node := range network.Nodes {
+		keyedNode := keyed[0].Networks[0].Nodes[i]
+		nodeType := strings.ToLower(strings.TrimSpace(node.NodeType.Name))
+		name := nodeType
+		if name == "" {
+			name = "node"
+		}
+		seen[name]++
+		if seen[name] > 1 {
+			name += "-" + strconv.Itoa(seen[name])
+		}
+		nodes = append(nodes, &proxy.Node{
+			Name:     name,
+			NodeType: node.NodeType.Name,
+			HTTP:     target("https", node.HTTPS, keyedNode.HTTPS, node.NodeType.Name),
+			WS:       target("wss", node.WSS, keyedNode.WSS, node.NodeType.Name),
+		})
+	}
+	return chain, network, nodes, nil
+}
+
+func gatewayArgs(cmd *cobra.Command, args []string) error {
+	if len(args) > 0 {
+		return getFormatter().Error(
+			"validation_error",
+			fmt.Sprintf("Unexpected arguments for gateway (got %d).", len(args)),
+			"Pass the chain with --chain, e.g. dwellir gateway --chain ethereum",
+		)
+	}
+	if strings.TrimSpace(gatewayChain) == "" {
+		return getFormatter().Error(
+			"validation_error",
+			"Missing required flag --chain.",
+			"Example: dwellir gateway --chain ethereum --network mainnet",
+		)
+	}
+	if gatewayHealthInterval <= 0 || gatewayAttemptTimeout <= 0 {
+		return getFormatter().Error("validation_error", "--health-interval and --attempt-timeout must be positive.", "")
+	}
+	return nil
+}
+
+func init() {
+	gatewayCmd.Flags().StringVar(&gatewayChain, "chain", "", "Chain to serve (required)")
+	gatewayCmd.Flags().StringVar(&gatewayNetwork, "network", "", "Network (mainnet, testnet, or network name; defaults to mainnet)")
+	gatewayCmd.Flags().StringVar(&gatewayEcosystem, "ecosystem", "", "Ecosystem (evm, substrate, cosmos, move, hyperliquid, other)")
+	gatewayCmd.Flags().StringVar(&gatewayKeyName, "key", "", "API key name or value to use")
+	gatewayCmd.Flags().StringVar(&gatewayListen, "listen", "127.0.0.1:8545", "Local address to listen on")
+	gatewayCmd.Flags().StringArrayVar(&gatewayAllowedOrigin, "allow-origin", nil, "Browser origin allowed to use the gateway (repeatable; * allows any)")
+	gatewayCmd.Flags().DurationVar(&gatewayHealthInterval, "health-interval", 15*time.Second, "Time between node health checks")
+	gatewayCmd.Flags().DurationVar(&gatewayAttemptTimeout, "attempt-timeout", 10*time.Second, "Per-node timeout before failing over to the next node")
+	_ = gatewayCmd.RegisterFlagCompletionFunc("chain", completeChainNames)
+	addCatalogFlags(gatewayCmd)
+	rootCmd.AddCommand(gatewayCmd)
+}
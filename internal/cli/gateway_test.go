@@ -0,0 +1,27 @@
+package cli
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dwellir-public/cli/internal/api"
+)
+
+func TestResolveGatewayNodesNamesEveryNode(t *testing.T) {
+	server := newEndpointCatalogServer(t)
+	client := api.NewClient(server.URL, "token")
+	gatewayChain, gatewayNetwork = "ethereum", "mainnet"
+	t.Cleanup(func() { gatewayChain, gatewayNetwork = "", "" })
+
+	chain, network, nodes, err := resolveGatewayNodes(context.Background(), client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if chain.Name != "Ethereum" || network.Name != "Mainnet" || len(nodes) != 1 {
+		t.Fatalf("unexpected resolution: %s %s %+v", chain.Name, network.Name, nodes)
+	}
+	node := nodes[0]
+	if node.Name != "archive" || node.HTTP.URL != "https://eth-mainnet.example/key-123" || node.WS.Endpoint != "wss://eth-mainnet.example/<key>" {
+		t.Fatalf("unexpected node: %+v", node)
+	}
+}
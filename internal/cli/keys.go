@@ -28,7 +28,7 @@ var keysListCmd = &cobra.Command{
 		if err != nil {
 			return getFormatter().Error("not_authenticated", err.Error(), "")
 		}
-		keys, err := api.NewKeysAPI(client).List()
+		keys, err := api.NewKeysAPI(client).List(cmd.Context())
 		if err != nil {
 			return formatCommandError(err)
 		}
@@ -68,7 +68,7 @@ var keysCreateCmd = &cobra.Command{
 		if keyMonthlyQuota > 0 {
 			input.MonthlyQuota = &keyMonthlyQuota
 		}
-		key, err := api.NewKeysAPI(client).Create(input)
+		key, err := api.NewKeysAPI(client).Create(cmd.Context(), input)
 		if err != nil {
 			return formatCommandError(err)
 		}
@@ -108,7 +108,7 @@ var keysUpdateCmd = &cobra.Command{
 		if cmd.Flags().Changed("monthly-quota") {
 			input.MonthlyQuota = &keyMonthlyQuota
 		}
-		key, err := api.NewKeysAPI(client).Update(args[0], input)
+		key, err := api.NewKeysAPI(client).Update(cmd.Context(), args[0], input)
 		if err != nil {
 			return formatCommandError(err)
 		}
@@ -125,7 +125,7 @@ var keysDeleteCmd = &cobra.Command{
 		if err != nil {
 			return getFormatter().Error("not_authenticated", err.Error(), "")
 		}
-		err = api.NewKeysAPI(client).Delete(args[0])
+		err = api.NewKeysAPI(client).Delete(cmd.Context(), args[0])
 		if err != nil {
 			return formatCommandError(err)
 		}
@@ -142,7 +142,7 @@ var keysEnableCmd = &cobra.Command{
 		if err != nil {
 			return getFormatter().Error("not_authenticated", err.Error(), "")
 		}
-		key, err := api.NewKeysAPI(client).Enable(args[0])
+		key, err := api.NewKeysAPI(client).Enable(cmd.Context(), args[0])
 		if err != nil {
 			return formatCommandError(err)
 		}
@@ -159,7 +159,7 @@ var keysDisableCmd = &cobra.Command{
 		if err != nil {
 			return getFormatter().Error("not_authenticated", err.Error(), "")
 		}
-		key, err := api.NewKeysAPI(client).Disable(args[0])
+		key, err := api.NewKeysAPI(client).Disable(cmd.Context(), args[0])
 		if err != nil {
 			return formatCommandError(err)
 		}
@@ -1,20 +1,63 @@
 package cli
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
 	"github.com/spf13/cobra"
 
 	"github.com/dwellir-public/cli/internal/api"
+	"github.com/dwellir-public/cli/internal/output"
+	"github.com/dwellir-public/cli/internal/timeexpr"
 )
 
 var (
-	logKey        string
-	logEndpoint   string
-	logStatusCode int
-	logRPCMethod  string
-	logFrom       string
-	logTo         string
-	logLimit      int
-	logCursor     string
+	logKeys           []string
+	logEndpoints      []string
+	logStatuses       []string
+	logRPCMethods     []string
+	logExcludeStatus  []string
+	logExcludeMethods []string
+	logFrom           string
+	logTo             string
+	logSince          string
+	logLimit          int
+	logCursor         string
+	logAll            bool
+	logMaxRows        int
+
+	logTraceAt     string
+	logTraceWindow time.Duration
+
+	logClusterTop     int
+	logClusterMaxRows int
+
+	logLatencyGroupBy  string
+	logLatencyInterval string
+	logLatencyMaxRows  int
+
+	logTailInterval time.Duration
+	logTailSince    string
+	logTailCount    int
+	logTailDuration time.Duration
+)
+
+const (
+	// logTraceMaxWindow bounds --window so a trace never scans days of logs.
+	logTraceMaxWindow = 24 * time.Hour
+	// logTraceMaxPages bounds how many pages of error logs a trace reads.
+	logTraceMaxPages = 25
+	// logAnalysisDefaultRows is how many error logs clusters and latency read
+	// by default.
+	logAnalysisDefaultRows = 5000
+	// logTailMaxFailures is how many polls in a row may fail before tail gives up.
+	logTailMaxFailures = 3
 )
 
 var logsCmd = &cobra.Command{
@@ -25,30 +68,101 @@ var logsCmd = &cobra.Command{
 var logsErrorsCmd = &cobra.Command{
 	Use:   "errors",
 	Short: "List error logs",
+	Long: `List error logs, newest first.
+
+One page of --limit entries is returned by default. Structured output
+carries the cursor of the next page in meta.page.next_cursor; pass it back
+with --cursor to continue. --all reads every page, and --max-rows reads pages
+until that many entries were returned (leaving a cursor to resume from).
+
+--key, --endpoint, --status-code and --rpc-method can be repeated or given
+comma-separated values, which match any of them. --status-code also accepts
+classes (5xx) and ranges (400-499). --exclude-status and --exclude-method
+drop matching entries after they are fetched, so a page can hold fewer than
+--limit entries.
+
+Examples:
+  dwellir logs errors --status-code 429 --limit 100
+  dwellir logs errors --status-code 5xx --exclude-status 503 --rpc-method eth_call,eth_getLogs
+  dwellir logs errors --from 2026-02-26T00:00:00Z --all --json
+  dwellir logs errors --max-rows 5000 --endpoint api-base-mainnet-archive.n.dwellir.com`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if logMaxRows < 0 {
+			return getFormatter().Error("validation_error", "--max-rows must not be negative.", "")
+		}
+		filter, err := buildLogFilter(cmd.Context())
+		if err != nil {
+			return err
+		}
 		client, err := newAPIClient()
 		if err != nil {
 			return getFormatter().Error("not_authenticated", err.Error(), "")
 		}
-		filters := buildLogFilters()
-		logs, err := api.NewLogsAPI(client).Errors(filters)
+		logsAPI := api.NewLogsAPI(client)
+
+		var (
+			logs []api.ErrorLog
+			last *api.ErrorLogPage
+		)
+		if logAll || logMaxRows > 0 {
+			if !cmd.Flags().Changed("limit") {
+				filter.Limit = 0
+			}
+			last, err = logsAPI.WalkErrors(cmd.Context(), filter, logMaxRows, func(page *api.ErrorLogPage) error {
+				logs = append(logs, page.Items...)
+				return nil
+			})
+		} else {
+			last, err = logsAPI.ErrorsPage(cmd.Context(), filter)
+			logs = last.Items
+		}
 		if err != nil {
 			return formatCommandError(err)
 		}
+		if logs == nil {
+			logs = []api.ErrorLog{}
+		}
+		recordLogsPage(last)
+		if last.HasMore && last.NextCursor != "" && !quiet && isHumanOutput() {
+			_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "More error logs are available: --cursor %s (or --all)\n", last.NextCursor)
+		}
 		return getFormatter().Success("logs.errors", logs)
 	},
 }
 
+// logsPage records where the error log listing of the current run stopped,
+// for the envelope metadata.
+var logsPage atomic.Pointer[output.PageMeta]
+
+func recordLogsPage(page *api.ErrorLogPage) {
+	meta := &output.PageMeta{HasMore: page.HasMore && page.NextCursor != ""}
+	if meta.HasMore {
+		meta.NextCursor = page.NextCursor
+	}
+	logsPage.Store(meta)
+}
+
 var logsStatsCmd = &cobra.Command{
 	Use:   "stats",
 	Short: "Error metrics and classifications",
+	Long: `Show error counts per HTTP status code.
+
+The API returns the counts already aggregated, so --exclude-status hides the
+rows of the excluded statuses, and RPC methods cannot be excluded.
+
+Examples:
+  dwellir logs stats --since 24h
+  dwellir logs stats --endpoint api-base-mainnet-archive.n.dwellir.com --exclude-status 429`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		filter, err := buildLogFilter(cmd.Context())
+		if err != nil {
+			return err
+		}
 		client, err := newAPIClient()
 		if err != nil {
 			return getFormatter().Error("not_authenticated", err.Error(), "")
 		}
-		filters := buildLogFilters()
-		stats, err := api.NewLogsAPI(client).Stats(filters)
+		stats, err := api.NewLogsAPI(client).Stats(cmd.Context(), filter)
 		if err != nil {
 			return formatCommandError(err)
 		}
@@ -59,13 +173,26 @@ var logsStatsCmd = &cobra.Command{
 var logsFacetsCmd = &cobra.Command{
 	Use:   "facets",
 	Short: "Error facet aggregations",
+	Long: `Show error counts per endpoint, RPC method, origin, and API key.
+
+The API returns the counts already aggregated, so --exclude-method only hides
+the excluded methods from the RPC method counts. The endpoint, origin, and
+API key counts still include their errors; use --rpc-method to narrow every
+facet. Statuses cannot be excluded.
+
+Examples:
+  dwellir logs facets --since 24h
+  dwellir logs facets --status-code 5xx --exclude-method eth_call`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		filter, err := buildLogFilter(cmd.Context())
+		if err != nil {
+			return err
+		}
 		client, err := newAPIClient()
 		if err != nil {
 			return getFormatter().Error("not_authenticated", err.Error(), "")
 		}
-		filters := buildLogFilters()
-		facets, err := api.NewLogsAPI(client).Facets(filters)
+		facets, err := api.NewLogsAPI(client).Facets(cmd.Context(), filter)
 		if err != nil {
 			return formatCommandError(err)
 		}
@@ -73,47 +200,457 @@ var logsFacetsCmd = &cobra.Command{
 	},
 }
 
-func buildLogFilters() map[string]interface{} {
-	filters := map[string]interface{}{}
-	if logKey != "" {
-		filters["api_key"] = logKey
+var logsTraceCmd = &cobra.Command{
+	Use:   "trace <request-id>",
+	Short: "Find the error log of a request ID",
+	Long: `Find the server-side error log of a request by the request ID your
+application logged, and show the status, error message, and how the total
+latency splits between the backend node and the Dwellir gateway.
+
+Only failed requests are logged. The logs API cannot filter by request ID, so
+error logs within --window of --at (default: the last hour) are scanned
+newest first; narrow the scan with --endpoint or --key.
+
+Examples:
+  dwellir logs trace 4f7c2a9e-51d3-4b8e-9a0c-2f6d1e8b7c34
+  dwellir logs trace 4f7c2a9e-51d3-4b8e-9a0c-2f6d1e8b7c34 --at 2026-02-26T14:05:00Z --window 10m
+  dwellir logs trace req-123 --at -2h --tz Europe/Stockholm
+  dwellir logs trace req-123 --endpoint api-ethereum-mainnet.n.dwellir.com --json`,
+	Args: logsTraceArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		requestID := strings.TrimSpace(args[0])
+		start, end, err := logTraceWindowBounds(logTraceAt, logTraceWindow, timeReference(cmd.Context(), time.Now()))
+		if err != nil {
+			return err
+		}
+
+		client, err := newAPIClient()
+		if err != nil {
+			return getFormatter().Error("not_authenticated", err.Error(), "")
+		}
+		filter := api.ErrorLogFilter{APIKeys: logFlagValues(logKeys), FQDNs: logFlagValues(logEndpoints)}
+		trace, scanned, err := api.NewLogsAPI(client).Trace(cmd.Context(), requestID, start, end, filter, logTraceMaxPages)
+		span := fmt.Sprintf("%s and %s", start.Format(time.RFC3339), end.Format(time.RFC3339))
+		switch {
+		case errors.Is(err, api.ErrTraceScanLimit):
+			return getFormatter().Error(
+				"not_found",
+				fmt.Sprintf("Request %s was not among the %d most recent error logs between %s.", requestID, scanned, span),
+				"Narrow the search with --endpoint or --key, or pass --at with the request time and a smaller --window.",
+			)
+		case err != nil:
+			return formatCommandError(err)
+		case trace == nil:
+			return getFormatter().Error(
+				"not_found",
+				fmt.Sprintf("No error log for request %s between %s (%d checked).", requestID, span, scanned),
+				"Only failed requests are logged. Pass the request time with --at, or widen --window (up to 24h).",
+			)
+		}
+		return getFormatter().Success("logs.trace", trace)
+	},
+}
+
+var logsClustersCmd = &cobra.Command{
+	Use:   "clusters",
+	Short: "Group error logs by message template",
+	Long: `Group error logs whose messages differ only in block numbers, hashes,
+addresses, UUIDs, or other numbers, and report each template's count, first
+and last occurrence, and the endpoints, RPC methods, and API keys affected,
+largest cluster first.
+
+Up to --max-rows of the most recent matching logs are read.
+
+Examples:
+  dwellir logs clusters --from 2026-02-26T00:00:00Z
+  dwellir logs clusters --status-code 500 --top 5
+  dwellir logs clusters --endpoint api-base-mainnet-archive.n.dwellir.com --json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if logClusterMaxRows < 1 || logClusterTop < 0 {
+			return getFormatter().Error("validation_error", "--max-rows must be at least 1 and --top must not be negative.", "")
+		}
+		filter, err := buildLogFilter(cmd.Context())
+		if err != nil {
+			return err
+		}
+		client, err := newAPIClient()
+		if err != nil {
+			return getFormatter().Error("not_authenticated", err.Error(), "")
+		}
+		filter.Limit = 0
+
+		var logs []api.ErrorLog
+		last, err := api.NewLogsAPI(client).WalkErrors(cmd.Context(), filter, logClusterMaxRows, func(page *api.ErrorLogPage) error {
+			logs = append(logs, page.Items...)
+			return nil
+		})
+		if err != nil {
+			return formatCommandError(err)
+		}
+
+		clusters := api.ClusterErrorLogs(logs)
+		report := api.ErrorClusterReport{
+			Scanned:       len(logs),
+			Truncated:     last.HasMore && last.NextCursor != "",
+			TotalClusters: len(clusters),
+			Clusters:      clusters,
+		}
+		if logClusterTop > 0 && len(report.Clusters) > logClusterTop {
+			report.Clusters = report.Clusters[:logClusterTop]
+		}
+		return getFormatter().Success("logs.clusters", report)
+	},
+}
+
+var logsLatencyCmd = &cobra.Command{
+	Use:   "latency",
+	Short: "Latency percentiles of error logs by endpoint, method, or status",
+	Long: `Compute p50/p90/p99 and max total latency of error logs grouped by endpoint
+(fqdn), RPC method, or status code, next to the backend node's median and the
+gateway overhead (total minus backend latency). Groups where the gateway
+accounts for more than half of the time are flagged.
+
+--interval splits the window into buckets (minute, hour, day, or a duration
+such as 15m) to show trends. Up to --max-rows of the most recent matching
+logs are read. Only failed requests are logged, so successful requests are
+not part of these figures.
+
+Examples:
+  dwellir logs latency --from 2026-02-26T00:00:00Z
+  dwellir logs latency --group-by method --endpoint api-base-mainnet-archive.n.dwellir.com
+  dwellir logs latency --group-by status --interval hour --json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		groupBy := strings.ToLower(strings.TrimSpace(logLatencyGroupBy))
+		switch groupBy {
+		case api.LatencyByFQDN, api.LatencyByMethod, api.LatencyByStatus:
+		default:
+			return getFormatter().Error("validation_error", fmt.Sprintf("Invalid --group-by %q.", logLatencyGroupBy), "Supported groupings: fqdn, method, status")
+		}
+		interval, err := parseLogInterval(logLatencyInterval)
+		if err != nil {
+			return getFormatter().Error("validation_error", err.Error(), "Use minute, hour, day, or a duration of at least 1m such as 15m.")
+		}
+		if logLatencyMaxRows < 1 {
+			return getFormatter().Error("validation_error", "--max-rows must be at least 1.", "")
+		}
+
+		filter, err := buildLogFilter(cmd.Context())
+		if err != nil {
+			return err
+		}
+		client, err := newAPIClient()
+		if err != nil {
+			return getFormatter().Error("not_authenticated", err.Error(), "")
+		}
+		filter.Limit = 0
+
+		var logs []api.ErrorLog
+		last, err := api.NewLogsAPI(client).WalkErrors(cmd.Context(), filter, logLatencyMaxRows, func(page *api.ErrorLogPage) error {
+			logs = append(logs, page.Items...)
+			return nil
+		})
+		if err != nil {
+			return formatCommandError(err)
+		}
+
+		report := api.ErrorLatencyReport{
+			GroupBy:   groupBy,
+			Scanned:   len(logs),
+			Truncated: last.HasMore && last.NextCursor != "",
+			Groups:    api.LatencyByGroup(logs, groupBy, interval),
+		}
+		if interval > 0 {
+			report.Interval = interval.String()
+		}
+		return getFormatter().Success("logs.latency", report)
+	},
+}
+
+// parseLogInterval accepts minute, hour, day, or a Go duration of at least a
+// minute. An empty value disables bucketing.
+func parseLogInterval(value string) (time.Duration, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "":
+		return 0, nil
+	case "minute":
+		return time.Minute, nil
+	case "hour":
+		return time.Hour, nil
+	case "day":
+		return 24 * time.Hour, nil
+	}
+	interval, err := time.ParseDuration(strings.TrimSpace(value))
+	if err != nil || interval < time.Minute {
+		return 0, fmt.Errorf("invalid --interval %q", value)
+	}
+	return interval, nil
+}
+
+var logsTailCmd = &cobra.Command{
+	Use:   "tail",
+	Short: "Follow new error logs as they arrive",
+	Long: `Poll the error logs every --interval and print entries that have not been
+shown yet, oldest first. Entries are de-duplicated by request ID.
+
+Entries stream as NDJSON with --json or --toon, or as a scrolling table.
+The command starts --since ago (a duration such as 5m, or a time such as
+today or -1h) and stops after --count entries, after --duration, or on
+Ctrl-C. Times are shown in local time, or in --tz.
+
+Examples:
+  dwellir logs tail --status-code 429
+  dwellir logs tail --endpoint api-base-mainnet-archive.n.dwellir.com --interval 2s
+  dwellir logs tail --rpc-method eth_call --json | jq .error_message`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if logTailInterval < time.Second {
+			return getFormatter().Error("validation_error", "--interval must be at least 1s.", "")
+		}
+		if logTailCount < 0 || logTailDuration < 0 {
+			return getFormatter().Error("validation_error", "--count and --duration must not be negative.", "")
+		}
+
+		since, err := timeexpr.ParseSince(logTailSince, timeReference(cmd.Context(), time.Now()))
+		if err != nil {
+			return getFormatter().Error("validation_error", fmt.Sprintf("Invalid --since: %v.", err), "Use a duration such as 5m or 2h. "+timeExprHelp)
+		}
+		filter, err := buildLogFilter(cmd.Context())
+		if err != nil {
+			return err
+		}
+		client, err := newAPIClient()
+		if err != nil {
+			return getFormatter().Error("not_authenticated", err.Error(), "")
+		}
+		tail := api.NewLogsAPI(client).Tail(filter, since)
+
+		ctx := cmd.Context()
+		if logTailDuration > 0 {
+			var cancel func()
+			ctx, cancel = context.WithTimeout(ctx, logTailDuration)
+			defer cancel()
+		}
+
+		human := isHumanOutput()
+		stderr := cmd.ErrOrStderr()
+		out := cmd.OutOrStdout()
+		encoder := json.NewEncoder(out)
+		if human {
+			_, _ = fmt.Fprintln(out, formatLogTailRow("TIME", "STATUS", "RPC METHODS", "ENDPOINT", "MESSAGE"))
+		}
+
+		received, failures := 0, 0
+		ticker := time.NewTicker(logTailInterval)
+		defer ticker.Stop()
+		for {
+			entries, err := tail.Poll(ctx)
+			switch {
+			case ctx.Err() != nil:
+			case err != nil:
+				failures++
+				if failures >= logTailMaxFailures {
+					return formatCommandError(err)
+				}
+				if human && !quiet {
+					_, _ = fmt.Fprintf(stderr, "Polling failed (%d/%d): %v\n", failures, logTailMaxFailures, err)
+				}
+			default:
+				failures = 0
+			}
+			for _, entry := range entries {
+				if human {
+					_, _ = fmt.Fprintln(out, formatLogTailRow(
+						formatLogTailTime(entry.Timestamp),
+						strconv.Itoa(entry.StatusCode),
+						entry.RPCMethods,
+						entry.FQDN,
+						entry.ErrorMessage,
+					))
+				} else if err := encoder.Encode(entry); err != nil {
+					return err
+				}
+				received++
+				if logTailCount > 0 && received >= logTailCount {
+					return nil
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				// Reaching --duration or pressing Ctrl-C is the normal way to stop.
+				if human && !quiet {
+					_, _ = fmt.Fprintf(stderr, "Received %d error log(s).\n", received)
+				}
+				return nil
+			case <-ticker.C:
+			}
+		}
+	},
+}
+
+func formatLogTailRow(at, status, methods, fqdn, message string) string {
+	return fmt.Sprintf("%-12s  %-6s  %-24s  %-36s  %s",
+		at, status, truncateLogField(methods, 24), truncateLogField(fqdn, 36), truncateLogField(message, 100))
+}
+
+// formatLogTailTime shows an entry's time of day in local time.
+func formatLogTailTime(timestamp string) string {
+	parsed, err := time.Parse(time.RFC3339Nano, timestamp)
+	if err != nil {
+		return timestamp
 	}
-	if logEndpoint != "" {
-		filters["fqdn"] = logEndpoint
+	loc := displayLocation
+	if loc == nil {
+		loc = time.Local
 	}
-	if logStatusCode != 0 {
-		filters["status_code"] = logStatusCode
+	return parsed.In(loc).Format("15:04:05.000")
+}
+
+func truncateLogField(value string, limit int) string {
+	value = strings.Join(strings.Fields(value), " ")
+	if runes := []rune(value); len(runes) > limit {
+		return string(runes[:limit-1]) + "…"
 	}
-	if logRPCMethod != "" {
-		filters["rpc_method"] = logRPCMethod
+	return value
+}
+
+// logTraceWindowBounds returns the span --window either side of --at, or the
+// last --window when --at is not set. The end never lies in the future.
+func logTraceWindowBounds(at string, window time.Duration, ref timeexpr.Reference) (time.Time, time.Time, error) {
+	now := ref.Now.UTC()
+	center := now
+	if strings.TrimSpace(at) != "" {
+		parsed, err := timeexpr.Parse(at, ref)
+		if err != nil {
+			return time.Time{}, time.Time{}, getFormatter().Error("validation_error", fmt.Sprintf("Invalid --at: %v.", err), timeExprHelp)
+		}
+		center = parsed.UTC()
 	}
-	if logFrom != "" {
-		filters["from"] = logFrom
+	start, end := center.Add(-window), center.Add(window)
+	if end.After(now) {
+		end = now
 	}
-	if logTo != "" {
-		filters["to"] = logTo
+	if !start.Before(end) {
+		return time.Time{}, time.Time{}, getFormatter().Error("validation_error", "--at must not be in the future.", "")
+	}
+	return start, end, nil
+}
+
+func logsTraceArgs(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 || strings.TrimSpace(args[0]) == "" {
+		return getFormatter().Error(
+			"validation_error",
+			"logs trace expects exactly one request ID.",
+			"Example: dwellir logs trace 4f7c2a9e-51d3-4b8e-9a0c-2f6d1e8b7c34",
+		)
+	}
+	if logTraceWindow <= 0 || logTraceWindow > logTraceMaxWindow {
+		return getFormatter().Error("validation_error", "--window must be positive and at most 24h.", "")
+	}
+	return nil
+}
+
+// buildLogFilter builds the error log filter from the flags, returning a
+// formatted validation error for malformed times or status filters.
+func buildLogFilter(ctx context.Context) (api.ErrorLogFilter, error) {
+	filter := api.ErrorLogFilter{
+		APIKeys:        logFlagValues(logKeys),
+		FQDNs:          logFlagValues(logEndpoints),
+		RPCMethods:     logFlagValues(logRPCMethods),
+		ExcludeMethods: logFlagValues(logExcludeMethods),
+		Cursor:         logCursor,
 	}
 	if logLimit > 0 {
-		filters["limit"] = logLimit
+		filter.Limit = logLimit
+	}
+	start, end, err := resolveTimeFlags(timeReference(ctx, time.Now()), logFrom, logTo, logSince)
+	if err != nil {
+		return filter, err
+	}
+	if !start.IsZero() {
+		filter.From = start.Format(time.RFC3339)
 	}
-	if logCursor != "" {
-		filters["cursor"] = logCursor
+	if !end.IsZero() {
+		filter.To = end.Format(time.RFC3339)
 	}
-	return filters
+	if filter.Statuses, err = parseStatusFlag("--status-code", logStatuses); err != nil {
+		return filter, err
+	}
+	if filter.ExcludeStatuses, err = parseStatusFlag("--exclude-status", logExcludeStatus); err != nil {
+		return filter, err
+	}
+	return filter, nil
+}
+
+func parseStatusFlag(flag string, values []string) ([]api.StatusRange, error) {
+	var ranges []api.StatusRange
+	for _, value := range logFlagValues(values) {
+		r, err := api.ParseStatusRange(value)
+		if err != nil {
+			return nil, getFormatter().Error("validation_error", fmt.Sprintf("Invalid %s: %v.", flag, err), "Use a status code (429), a class (5xx), or a range (400-499).")
+		}
+		ranges = append(ranges, r)
+	}
+	return ranges, nil
+}
+
+// logFlagValues trims repeated or comma-separated flag values and drops
+// empty ones.
+func logFlagValues(values []string) []string {
+	var trimmed []string
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			trimmed = append(trimmed, value)
+		}
+	}
+	return trimmed
 }
 
 func init() {
-	for _, cmd := range []*cobra.Command{logsErrorsCmd, logsStatsCmd, logsFacetsCmd} {
-		cmd.Flags().StringVar(&logKey, "key", "", "Filter by API key")
-		cmd.Flags().StringVar(&logEndpoint, "endpoint", "", "Filter by FQDN")
-		cmd.Flags().IntVar(&logStatusCode, "status-code", 0, "Filter by HTTP status code")
-		cmd.Flags().StringVar(&logRPCMethod, "rpc-method", "", "Filter by RPC method")
-		cmd.Flags().StringVar(&logFrom, "from", "", "Start time (RFC3339)")
-		cmd.Flags().StringVar(&logTo, "to", "", "End time (RFC3339)")
-	}
-	logsErrorsCmd.Flags().IntVar(&logLimit, "limit", 50, "Max results")
-	logsErrorsCmd.Flags().StringVar(&logCursor, "cursor", "", "Pagination cursor")
-
-	logsCmd.AddCommand(logsErrorsCmd, logsStatsCmd, logsFacetsCmd)
+	for _, cmd := range []*cobra.Command{logsErrorsCmd, logsStatsCmd, logsFacetsCmd, logsTailCmd, logsExportCmd, logsClustersCmd, logsLatencyCmd} {
+		cmd.Flags().StringSliceVar(&logKeys, "key", nil, "Filter by API key (repeatable)")
+		cmd.Flags().StringSliceVar(&logEndpoints, "endpoint", nil, "Filter by FQDN (repeatable)")
+		cmd.Flags().StringSliceVar(&logStatuses, "status-code", nil, "Filter by HTTP status: 429, 5xx, or 400-499 (repeatable)")
+		cmd.Flags().StringSliceVar(&logRPCMethods, "rpc-method", nil, "Filter by RPC method (repeatable)")
+	}
+	for _, cmd := range []*cobra.Command{logsErrorsCmd, logsTailCmd, logsExportCmd, logsClustersCmd, logsLatencyCmd} {
+		cmd.Flags().StringSliceVar(&logExcludeStatus, "exclude-status", nil, "Drop entries with this HTTP status: 429, 5xx, or 400-499 (repeatable)")
+		cmd.Flags().StringSliceVar(&logExcludeMethods, "exclude-method", nil, "Drop entries that include this RPC method (repeatable)")
+	}
+	// The API aggregates stats per status and facets per value, so stats can
+	// only hide status rows and facets only method rows.
+	logsStatsCmd.Flags().StringSliceVar(&logExcludeStatus, "exclude-status", nil, "Hide the counts of this HTTP status: 429, 5xx, or 400-499 (repeatable)")
+	logsFacetsCmd.Flags().StringSliceVar(&logExcludeMethods, "exclude-method", nil, "Hide this RPC method from the method counts only; other facets still count its errors (repeatable)")
+	for _, cmd := range []*cobra.Command{logsErrorsCmd, logsStatsCmd, logsFacetsCmd, logsExportCmd, logsClustersCmd, logsLatencyCmd} {
+		cmd.Flags().StringVar(&logFrom, "from", "", "Start time: RFC3339, a date, -15m, 24h ago, yesterday, this-cycle, ...")
+		cmd.Flags().StringVar(&logTo, "to", "", "End time: RFC3339, a date, now, today, ...")
+		cmd.Flags().StringVar(&logSince, "since", "", "Start this long ago, e.g. 15m or 2d (short for --from -15m)")
+	}
+	logsErrorsCmd.Flags().IntVar(&logLimit, "limit", 50, "Max results per page")
+	logsErrorsCmd.Flags().StringVar(&logCursor, "cursor", "", "Pagination cursor (from meta.page.next_cursor)")
+	logsErrorsCmd.Flags().BoolVar(&logAll, "all", false, "Read every page")
+	logsErrorsCmd.Flags().IntVar(&logMaxRows, "max-rows", 0, "Read pages until this many entries (0 = no limit)")
+
+	logsTraceCmd.Flags().StringVar(&logTraceAt, "at", "", "Approximate request time: RFC3339, 2026-02-26 14:05, -15m, ... (default: now)")
+	logsTraceCmd.Flags().DurationVar(&logTraceWindow, "window", time.Hour, "How far either side of --at to search (max 24h)")
+	logsTraceCmd.Flags().StringSliceVar(&logKeys, "key", nil, "Filter by API key (repeatable)")
+	logsTraceCmd.Flags().StringSliceVar(&logEndpoints, "endpoint", nil, "Filter by FQDN (repeatable)")
+
+	logsClustersCmd.Flags().IntVar(&logClusterMaxRows, "max-rows", logAnalysisDefaultRows, "How many recent error logs to read")
+	logsClustersCmd.Flags().IntVar(&logClusterTop, "top", 20, "Show only the largest clusters (0 = all)")
+
+	logsLatencyCmd.Flags().StringVar(&logLatencyGroupBy, "group-by", "fqdn", "Group by fqdn, method, or status")
+	logsLatencyCmd.Flags().StringVar(&logLatencyInterval, "interval", "", "Bucket size: minute, hour, day, or a duration such as 15m")
+	logsLatencyCmd.Flags().IntVar(&logLatencyMaxRows, "max-rows", logAnalysisDefaultRows, "How many recent error logs to read")
+
+	logsTailCmd.Flags().DurationVar(&logTailInterval, "interval", 5*time.Second, "How often to poll for new entries")
+	logsTailCmd.Flags().StringVar(&logTailSince, "since", "5m", "Where to start: a duration ago (5m, 2h) or a time such as today")
+	logsTailCmd.Flags().IntVar(&logTailCount, "count", 0, "Stop after this many entries (0 = no limit)")
+	logsTailCmd.Flags().DurationVar(&logTailDuration, "duration", 0, "Stop after this long (0 = until Ctrl-C)")
+
+	addTimezoneFlag(logsCmd)
+	logsCmd.AddCommand(logsErrorsCmd, logsStatsCmd, logsFacetsCmd, logsTraceCmd, logsTailCmd, logsClustersCmd, logsLatencyCmd)
 	rootCmd.AddCommand(logsCmd)
 }
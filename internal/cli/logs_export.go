@@ -0,0 +1,192 @@
+package cli
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dwellir-public/cli/internal/api"
+)
+
+var (
+	logExportFormat string
+	logExportOutput string
+)
+
+var logExportFormats = []string{"ndjson", "csv"}
+
+// logExportColumns are the CSV columns, named like the NDJSON fields.
+var logExportColumns = []string{
+	"timestamp", "request_id", "api_key", "fqdn", "response_status_code", "response_status_label",
+	"request_rpc_methods", "request_http_method", "error_message", "backend_latency_ms", "total_latency_ms",
+}
+
+var logsExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export every matching error log as NDJSON or CSV",
+	Long: `Read every page of error logs matching the filters and write them as NDJSON
+(one JSON object per line) or CSV, newest first.
+
+Rows are streamed to --output as pages arrive, or to stdout without it.
+--max-rows stops early; the summary then carries the cursor to resume from
+with --cursor.
+
+Examples:
+  dwellir logs export --from 2026-02-26T00:00:00Z --to 2026-02-27T00:00:00Z --output incident.ndjson
+  dwellir logs export --status-code 429 --format csv -o rate-limits.csv
+  dwellir logs export --endpoint api-base-mainnet-archive.n.dwellir.com --max-rows 10000 | jq .error_message`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format := strings.ToLower(strings.TrimSpace(logExportFormat))
+		if format != "ndjson" && format != "csv" {
+			return getFormatter().Error(
+				"validation_error",
+				fmt.Sprintf("Invalid --format %q.", logExportFormat),
+				"Supported formats: "+strings.Join(logExportFormats, ", "),
+			)
+		}
+		if logMaxRows < 0 {
+			return getFormatter().Error("validation_error", "--max-rows must not be negative.", "")
+		}
+
+		filter, err := buildLogFilter(cmd.Context())
+		if err != nil {
+			return err
+		}
+		client, err := newAPIClient()
+		if err != nil {
+			return getFormatter().Error("not_authenticated", err.Error(), "")
+		}
+
+		out := cmd.OutOrStdout()
+		var file *os.File
+		if logExportOutput != "" {
+			file, err = os.OpenFile(logExportOutput, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+			if err != nil {
+				return getFormatter().Error("write_failed", fmt.Sprintf("Could not write %s: %v", logExportOutput, err), "")
+			}
+			out = file
+		}
+		buffered := bufio.NewWriter(out)
+		writer := newLogExportWriter(format, buffered)
+
+		// Exports page at the API's preferred size rather than logs errors' --limit.
+		filter.Limit = 0
+
+		result := logExport{Format: format, Path: logExportOutput}
+		var writeErr error
+		last, err := api.NewLogsAPI(client).WalkErrors(cmd.Context(), filter, logMaxRows, func(page *api.ErrorLogPage) error {
+			result.Pages++
+			for _, entry := range page.Items {
+				if writeErr = writer.write(entry); writeErr != nil {
+					return writeErr
+				}
+				result.Rows++
+			}
+			writeErr = writer.flush()
+			return writeErr
+		})
+		if writeErr == nil {
+			writeErr = buffered.Flush()
+		}
+		if file != nil {
+			if closeErr := file.Close(); writeErr == nil {
+				writeErr = closeErr
+			}
+		}
+		if writeErr != nil {
+			target := logExportOutput
+			if target == "" {
+				target = "stdout"
+			}
+			return getFormatter().Error("write_failed", fmt.Sprintf("Could not write %s: %v", target, writeErr), "")
+		}
+		if err != nil {
+			return formatCommandError(err)
+		}
+
+		recordLogsPage(last)
+		if last.HasMore && last.NextCursor != "" {
+			result.NextCursor = last.NextCursor
+		}
+		if file == nil {
+			// The rows are the output.
+			return nil
+		}
+		return getFormatter().Success("logs.export", result)
+	},
+}
+
+// logExport is the structured result of an export written to a file.
+type logExport struct {
+	Format     string `json:"format"`
+	Path       string `json:"path"`
+	Rows       int    `json:"rows"`
+	Pages      int    `json:"pages"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// Text renders the export summary for human output.
+func (e logExport) Text() string {
+	text := fmt.Sprintf("Wrote %d error log(s) as %s to %s\n", e.Rows, e.Format, e.Path)
+	if e.NextCursor != "" {
+		text += fmt.Sprintf("Stopped at --max-rows; resume with --cursor %s\n", e.NextCursor)
+	}
+	return text
+}
+
+type logExportWriter struct {
+	json *json.Encoder
+	csv  *csv.Writer
+}
+
+func newLogExportWriter(format string, w io.Writer) *logExportWriter {
+	if format == "csv" {
+		writer := csv.NewWriter(w)
+		_ = writer.Write(logExportColumns)
+		return &logExportWriter{csv: writer}
+	}
+	return &logExportWriter{json: json.NewEncoder(w)}
+}
+
+func (w *logExportWriter) write(entry api.ErrorLog) error {
+	if w.json != nil {
+		return w.json.Encode(entry)
+	}
+	return w.csv.Write([]string{
+		entry.Timestamp,
+		entry.RequestID,
+		entry.APIKey,
+		entry.FQDN,
+		strconv.Itoa(entry.StatusCode),
+		entry.StatusLabel,
+		entry.RPCMethods,
+		entry.HTTPMethod,
+		entry.ErrorMessage,
+		strconv.Itoa(entry.BackendLatencyMs),
+		strconv.Itoa(entry.TotalLatencyMs),
+	})
+}
+
+func (w *logExportWriter) flush() error {
+	if w.csv == nil {
+		return nil
+	}
+	w.csv.Flush()
+	return w.csv.Error()
+}
+
+func init() {
+	logsExportCmd.Flags().StringVar(&logExportFormat, "format", "ndjson", "Output format ("+strings.Join(logExportFormats, ", ")+")")
+	logsExportCmd.Flags().StringVarP(&logExportOutput, "output", "o", "", "Write to a file instead of stdout")
+	logsExportCmd.Flags().IntVar(&logMaxRows, "max-rows", 0, "Stop after this many entries (0 = no limit)")
+	logsExportCmd.Flags().StringVar(&logCursor, "cursor", "", "Resume from a cursor")
+	logsCmd.AddCommand(logsExportCmd)
+}
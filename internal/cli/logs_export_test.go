@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/dwellir-public/cli/internal/api"
+)
+
+func TestLogExportWriterCSV(t *testing.T) {
+	var buf bytes.Buffer
+	writer := newLogExportWriter("csv", &buf)
+	entry := api.ErrorLog{
+		Timestamp:        "2026-02-26T00:00:00Z",
+		RequestID:        "req-1",
+		FQDN:             "example.com",
+		StatusCode:       500,
+		RPCMethods:       "eth_call,eth_getLogs",
+		ErrorMessage:     "execution reverted: \"boom\"",
+		BackendLatencyMs: 10,
+		TotalLatencyMs:   15,
+	}
+	if err := writer.write(entry); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if err := writer.flush(); err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 || lines[0] != strings.Join(logExportColumns, ",") {
+		t.Fatalf("unexpected header: %q", buf.String())
+	}
+	want := `2026-02-26T00:00:00Z,req-1,,example.com,500,,"eth_call,eth_getLogs",,"execution reverted: ""boom""",10,15`
+	if lines[1] != want {
+		t.Fatalf("unexpected row:\n got %s\nwant %s", lines[1], want)
+	}
+}
+
+func TestLogExportWriterNDJSON(t *testing.T) {
+	var buf bytes.Buffer
+	writer := newLogExportWriter("ndjson", &buf)
+	for _, id := range []string{"a", "b"} {
+		if err := writer.write(api.ErrorLog{RequestID: id}); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[1], `"request_id":"b"`) {
+		t.Fatalf("unexpected NDJSON: %q", buf.String())
+	}
+}
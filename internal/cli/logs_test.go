@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dwellir-public/cli/internal/timeexpr"
+)
+
+func TestLogTraceWindowBounds(t *testing.T) {
+	now := time.Date(2026, 2, 26, 15, 0, 0, 0, time.UTC)
+	ref := timeexpr.Reference{Now: now}
+
+	start, end, err := logTraceWindowBounds("", time.Hour, ref)
+	if err != nil || !start.Equal(now.Add(-time.Hour)) || !end.Equal(now) {
+		t.Fatalf("expected the last hour, got %s..%s (%v)", start, end, err)
+	}
+
+	start, end, err = logTraceWindowBounds("2026-02-26T12:00:00+02:00", 10*time.Minute, ref)
+	if err != nil || start.Format(time.RFC3339) != "2026-02-26T09:50:00Z" || end.Format(time.RFC3339) != "2026-02-26T10:10:00Z" {
+		t.Fatalf("expected 10 minutes around --at, got %s..%s (%v)", start, end, err)
+	}
+
+	if _, end, _ := logTraceWindowBounds("2026-02-26T14:50:00Z", time.Hour, ref); !end.Equal(now) {
+		t.Fatalf("expected the end to be clamped to now, got %s", end)
+	}
+	if _, _, err := logTraceWindowBounds("2026-02-27T00:00:00Z", time.Hour, ref); err == nil {
+		t.Fatal("expected an error for a window entirely in the future")
+	}
+	if _, _, err := logTraceWindowBounds("around noon", time.Hour, ref); err == nil {
+		t.Fatal("expected an error for a malformed --at")
+	}
+
+	// Relative times and times without a zone work as for --from.
+	start, end, err = logTraceWindowBounds("-15m", 10*time.Minute, ref)
+	if err != nil || start.Format(time.RFC3339) != "2026-02-26T14:35:00Z" || end.Format(time.RFC3339) != "2026-02-26T14:55:00Z" {
+		t.Fatalf("expected 10 minutes around 15 minutes ago, got %s..%s (%v)", start, end, err)
+	}
+	stockholm, err := time.LoadLocation("Europe/Stockholm")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	start, _, err = logTraceWindowBounds("2026-02-26 12:00", 10*time.Minute, timeexpr.Reference{Now: now, Location: stockholm})
+	if err != nil || start.Format(time.RFC3339) != "2026-02-26T10:50:00Z" {
+		t.Fatalf("expected --at in the --tz zone, got %s (%v)", start, err)
+	}
+}
+
+func TestParseLogInterval(t *testing.T) {
+	tests := map[string]time.Duration{"": 0, "minute": time.Minute, "Hour": time.Hour, "day": 24 * time.Hour, "15m": 15 * time.Minute}
+	for value, want := range tests {
+		if got, err := parseLogInterval(value); err != nil || got != want {
+			t.Errorf("parseLogInterval(%q) = %s, %v; want %s", value, got, err, want)
+		}
+	}
+	for _, value := range []string{"30s", "weekly", "-1h"} {
+		if _, err := parseLogInterval(value); err == nil {
+			t.Errorf("parseLogInterval(%q): expected an error", value)
+		}
+	}
+}
+
+func TestLogsAggregateExclusionsSayWhatTheyHide(t *testing.T) {
+	if flag := logsFacetsCmd.Flags().Lookup("exclude-method"); flag == nil || !strings.Contains(flag.Usage, "method counts only") {
+		t.Fatalf("expected facets --exclude-method to say it only hides method counts, got %+v", flag)
+	}
+	if logsFacetsCmd.Flags().Lookup("exclude-status") != nil || logsStatsCmd.Flags().Lookup("exclude-method") != nil {
+		t.Fatal("expected aggregate commands to register only the exclusion they can apply")
+	}
+}
@@ -0,0 +1,196 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/net/websocket"
+
+	"github.com/dwellir-public/cli/internal/api"
+	"github.com/dwellir-public/cli/internal/proxy"
+	"github.com/dwellir-public/cli/internal/rpc"
+)
+
+// proxyShutdownTimeout bounds how long in-flight requests may finish after
+// the proxy is asked to stop.
+const proxyShutdownTimeout = 5 * time.Second
+
+var (
+	proxyChain         string
+	proxyNetwork       string
+	proxyNodeType      string
+	proxyEcosystem     string
+	proxyKeyName       string
+	proxyListen        string
+	proxyAllowedOrigin []string
+)
+
+var proxyCmd = &cobra.Command{
+	Use:   "proxy",
+	Short: "Serve a local JSON-RPC endpoint that forwards to a chain",
+	Long: `Serve a local JSON-RPC endpoint that forwards HTTP and WebSocket traffic to
+a Dwellir node, injecting your API key on the way out.
+
+Point wallets, scripts, and frameworks at the local address instead of
+embedding keys in their configuration; the key never leaves the CLI. The
+endpoint is resolved like 'dwellir endpoints get' (pass --key <name> when you
+have more than one key).
+
+Per-method request counts, error counts, and latency histograms are served
+in the Prometheus text format on /metrics, and summarized when the proxy
+stops (Ctrl-C, or --timeout).
+
+Browsers may only use the proxy from localhost pages unless an origin is
+allowed with --allow-origin.
+
+Examples:
+  dwellir proxy --chain ethereum --network mainnet
+  dwellir proxy --chain base --listen 127.0.0.1:9545 --node-type archive
+  curl -s localhost:8545 -d '{"jsonrpc":"2.0","id":1,"method":"eth_blockNumber"}'
+  curl -s localhost:8545/metrics`,
+	Args: proxyArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := newAPIClient()
+		if err != nil {
+			return getFormatter().Error("not_authenticated", err.Error(), "")
+		}
+		httpTarget, wsTarget, err := resolveProxyTargets(cmd.Context(), client, proxyChain, proxyEcosystem, proxyNodeType, proxyNetwork, proxyKeyName)
+		if err != nil {
+			return formatEndpointResolveError(err)
+		}
+
+		metrics := proxy.NewMetrics("dwellir_proxy")
+		handler := &proxy.Handler{Metrics: metrics, AllowedOrigins: proxyAllowedOrigin}
+		if httpTarget.URL != "" {
+			handler.HTTP = proxy.NewHTTPBackend(httpTarget.URL)
+		}
+		if wsTarget.URL != "" {
+			wsURL := wsTarget.URL
+			handler.DialWebSocket = func(ctx context.Context) (*websocket.Conn, error) {
+				return rpc.DialWebSocket(ctx, wsURL)
+			}
+		}
+		target := httpTarget
+		if target.URL == "" {
+			target = wsTarget
+		}
+
+		upstream := httpTarget.Template
+		if upstream == "" {
+			upstream = wsTarget.Template
+		}
+		listen, err := serveLocalEndpoint(cmd, proxyListen, handler, upstream)
+		if err != nil {
+			return err
+		}
+		return getFormatter().Success("proxy", proxy.Summary{
+			Chain:         target.Chain,
+			Network:       target.Network,
+			NodeType:      target.NodeType,
+			Listen:        listen,
+			HTTPEndpoint:  httpTarget.Template,
+			WSEndpoint:    wsTarget.Template,
+			UptimeSeconds: metrics.Uptime().Round(time.Millisecond).Seconds(),
+			Methods:       metrics.Snapshot(),
+		})
+	},
+}
+
+// resolveProxyTargets resolves the HTTPS and WSS endpoints of the proxied
+// chain. A chain that only publishes one of the two is proxied over that one.
+func resolveProxyTargets(ctx context.Context, client *api.Client, chainLookup, ecosystem, nodeType, network, keySelector string) (endpointTarget, endpointTarget, error) {
+	httpTarget, httpErr := resolveEndpointTarget(ctx, client, chainLookup, ecosystem, nodeType, "https", network, keySelector)
+	if httpErr != nil && !isEndpointNotFound(httpErr) {
+		return endpointTarget{}, endpointTarget{}, httpErr
+	}
+	wsTarget, wsErr := resolveEndpointTarget(ctx, client, chainLookup, ecosystem, nodeType, "wss", network, keySelector)
+	if wsErr != nil && !isEndpointNotFound(wsErr) {
+		return endpointTarget{}, endpointTarget{}, wsErr
+	}
+	if httpErr != nil && wsErr != nil {
+		return endpointTarget{}, endpointTarget{}, httpErr
+	}
+	return httpTarget, wsTarget, nil
+}
+
+func isEndpointNotFound(err error) bool {
+	var resolveErr endpointResolveError
+	return errors.As(err, &resolveErr) && resolveErr.code == "not_found"
+}
+
+// serveLocalEndpoint listens on addr and serves handler until the command
+// context is cancelled. It returns the address it was bound to.
+func serveLocalEndpoint(cmd *cobra.Command, addr string, handler http.Handler, upstream string) (string, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return "", getFormatter().Error("listen_failed", fmt.Sprintf("Could not listen on %s: %v", addr, err), "Choose another address with --listen, e.g. 127.0.0.1:9545.")
+	}
+	listen := listener.Addr().String()
+
+	if !quiet {
+		stderr := cmd.ErrOrStderr()
+		if !isLoopbackListener(listener) {
+			_, _ = fmt.Fprintf(stderr, "Warning: %s is reachable from other machines, and requests sent to it use your API key.\n", listen)
+		}
+		if isHumanOutput() {
+			_, _ = fmt.Fprintf(stderr, "Forwarding http://%s to %s\n", listen, upstream)
+			_, _ = fmt.Fprintf(stderr, "Metrics at http://%s/metrics. Press Ctrl-C to stop.\n", listen)
+		}
+	}
+
+	server := &http.Server{Handler: handler, ReadHeaderTimeout: 10 * time.Second}
+	served := make(chan error, 1)
+	go func() { served <- server.Serve(listener) }()
+
+	select {
+	case err := <-served:
+		return "", getFormatter().Error("listen_failed", fmt.Sprintf("Stopped serving %s: %v", listen, err), "")
+	case <-cmd.Context().Done():
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), proxyShutdownTimeout)
+	defer cancel()
+	_ = server.Shutdown(shutdownCtx)
+	return listen, nil
+}
+
+func proxyArgs(cmd *cobra.Command, args []string) error {
+	if len(args) > 0 {
+		return getFormatter().Error(
+			"validation_error",
+			fmt.Sprintf("Unexpected arguments for proxy (got %d).", len(args)),
+			"Pass the chain with --chain, e.g. dwellir proxy --chain ethereum",
+		)
+	}
+	if strings.TrimSpace(proxyChain) == "" {
+		return getFormatter().Error(
+			"validation_error",
+			"Missing required flag --chain.",
+			"Example: dwellir proxy --chain ethereum --network mainnet",
+		)
+	}
+	return nil
+}
+
+func isLoopbackListener(listener net.Listener) bool {
+	addr, ok := listener.Addr().(*net.TCPAddr)
+	return ok && addr.IP.IsLoopback()
+}
+
+func init() {
+	proxyCmd.Flags().StringVar(&proxyChain, "chain", "", "Chain to proxy (required)")
+	proxyCmd.Flags().StringVar(&proxyNetwork, "network", "", "Network (mainnet, testnet, or network name; defaults to mainnet)")
+	proxyCmd.Flags().StringVar(&proxyNodeType, "node-type", "", "Node type (full, archive; default: full when a network has both)")
+	proxyCmd.Flags().StringVar(&proxyEcosystem, "ecosystem", "", "Ecosystem (evm, substrate, cosmos, move, hyperliquid, other)")
+	proxyCmd.Flags().StringVar(&proxyKeyName, "key", "", "API key name or value to use")
+	proxyCmd.Flags().StringVar(&proxyListen, "listen", "127.0.0.1:8545", "Local address to listen on")
+	proxyCmd.Flags().StringArrayVar(&proxyAllowedOrigin, "allow-origin", nil, "Browser origin allowed to use the proxy (repeatable; * allows any)")
+	_ = proxyCmd.RegisterFlagCompletionFunc("chain", completeChainNames)
+	addCatalogFlags(proxyCmd)
+	rootCmd.AddCommand(proxyCmd)
+}
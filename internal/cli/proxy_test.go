@@ -0,0 +1,32 @@
+package cli
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dwellir-public/cli/internal/api"
+)
+
+func TestResolveProxyTargetsToleratesMissingProtocol(t *testing.T) {
+	server := newEndpointCatalogServer(t)
+	client := api.NewClient(server.URL, "token")
+	httpTarget, wsTarget, err := resolveProxyTargets(context.Background(), client, "ethereum", "", "", "", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if httpTarget.URL != "https://eth-mainnet.example/key-123" || wsTarget.URL != "wss://eth-mainnet.example/key-123" {
+		t.Fatalf("unexpected targets: %+v %+v", httpTarget, wsTarget)
+	}
+
+	httpTarget, wsTarget, err = resolveProxyTargets(context.Background(), client, "ethereum", "", "", "sepolia", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if httpTarget.Template != "https://eth-sepolia.example/<key>" || wsTarget.URL != "" {
+		t.Fatalf("expected an HTTPS-only target, got %+v %+v", httpTarget, wsTarget)
+	}
+
+	if _, _, err := resolveProxyTargets(context.Background(), client, "solana", "", "", "", ""); !isEndpointNotFound(err) {
+		t.Fatalf("expected not_found for an unknown chain, got %v", err)
+	}
+}
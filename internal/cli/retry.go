@@ -0,0 +1,47 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dwellir-public/cli/internal/api"
+	"github.com/dwellir-public/cli/internal/config"
+)
+
+// configureRetries applies the retry budget from config and global flags to client.
+// Flags take precedence over config.
+func configureRetries(client *api.Client) {
+	cfg, _ := config.Load(config.DefaultConfigDir())
+
+	policy := api.DefaultRetryPolicy()
+	policy.MaxAttempts = cfg.RetryAttempts()
+	policy.MaxElapsed = cfg.RetryElapsed()
+	if retryMaxAttempts > 0 {
+		policy.MaxAttempts = retryMaxAttempts
+	}
+	if retryMaxElapsed > 0 {
+		policy.MaxElapsed = retryMaxElapsed
+	}
+	client.Retry = policy
+
+	client.OnRetry = func(event api.RetryEvent) {
+		apiRetryCount.Add(1)
+		if quiet || !isHumanOutput() {
+			return
+		}
+		reason := "network error"
+		if event.StatusCode > 0 {
+			reason = fmt.Sprintf("HTTP %d", event.StatusCode)
+		}
+		_, _ = fmt.Fprintf(
+			config.Stderr(),
+			"Retrying %s %s after %s (attempt %d/%d) in %s\n",
+			event.Method,
+			event.Path,
+			reason,
+			event.Attempt+1,
+			policy.MaxAttempts,
+			event.Delay.Round(100*time.Millisecond),
+		)
+	}
+}
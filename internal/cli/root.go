@@ -1,10 +1,14 @@
 package cli
 
 import (
+	"context"
 	"errors"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"strings"
+	"sync/atomic"
+	"syscall"
 	"time"
 
 	"github.com/google/uuid"
@@ -23,10 +27,24 @@ var (
 	profile       string
 	quiet         bool
 	anonTelemetry bool
+	cmdTimeout    time.Duration
+
+	retryMaxAttempts int
+	retryMaxElapsed  time.Duration
 )
 
+// apiRetryCount tracks retried API requests for the current run so structured
+// output can report them in the envelope meta.
+var apiRetryCount atomic.Int64
+
+// cancelCmdTimeout releases the per-command deadline installed by --timeout.
+var cancelCmdTimeout context.CancelFunc = func() {}
+
 var globalFlagsWithValue = map[string]bool{
-	"--profile": true,
+	"--profile":            true,
+	"--timeout":            true,
+	"--retry-max-attempts": true,
+	"--retry-max-elapsed":  true,
 }
 
 var stdoutIsTerminal = func() bool {
@@ -98,9 +116,10 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&profile, "profile", "", "Use a specific auth profile")
 	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "Suppress non-essential output")
 	rootCmd.PersistentFlags().BoolVar(&anonTelemetry, "anon-telemetry", false, "Anonymize telemetry data")
-	rootCmd.PersistentPreRun = func(cmd *cobra.Command, args []string) {
-		startTelemetryRun(cmd)
-	}
+	rootCmd.PersistentFlags().DurationVar(&cmdTimeout, "timeout", 0, "Overall deadline for the command (e.g. 30s, 2m); 0 disables")
+	rootCmd.PersistentFlags().IntVar(&retryMaxAttempts, "retry-max-attempts", 0, "Max attempts per API request, including the first (default from config, 3)")
+	rootCmd.PersistentFlags().DurationVar(&retryMaxElapsed, "retry-max-elapsed", 0, "Max time spent retrying one API request (default from config, 30s)")
+	rootCmd.PersistentPreRun = rootPersistentPreRun
 	rootCmd.PersistentPostRun = func(cmd *cobra.Command, args []string) {
 		trackTelemetryRunResult(true, "")
 	}
@@ -115,7 +134,13 @@ func Execute() error {
 	}
 	defer telemetryClient.Close()
 
-	if err := rootCmd.Execute(); err != nil {
+	// Ctrl-C / SIGTERM cancel the command context so in-flight requests and
+	// pagination loops stop instead of running to completion.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	defer func() { cancelCmdTimeout() }()
+
+	if err := rootCmd.ExecuteContext(ctx); err != nil {
 		var errorCode string
 		var renderedErr *output.RenderedError
 		if errors.As(err, &renderedErr) && renderedErr != nil && renderedErr.Code != "" {
@@ -138,6 +163,27 @@ func Execute() error {
 	return nil
 }
 
+// rootPersistentPreRun applies --timeout and starts the telemetry run. Cobra
+// only runs the nearest persistent hook, so command groups that set their
+// own must call it first.
+func rootPersistentPreRun(cmd *cobra.Command, args []string) {
+	applyCommandTimeout(cmd)
+	startTelemetryRun(cmd)
+}
+
+func applyCommandTimeout(cmd *cobra.Command) {
+	if cmdTimeout <= 0 {
+		return
+	}
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(ctx, cmdTimeout)
+	cancelCmdTimeout = cancel
+	cmd.SetContext(ctx)
+}
+
 func explicitOutputFromArgs(args []string) string {
 	if len(args) == 0 {
 		return ""
@@ -157,7 +203,17 @@ func explicitOutputFromArgs(args []string) string {
 }
 
 func buildFormatter(format string) output.Formatter {
-	return output.New(format, rootCmd.OutOrStdout())
+	formatter := output.NewWithMeta(format, rootCmd.OutOrStdout(), runMeta)
+	if human, ok := formatter.(*output.HumanFormatter); ok && displayLocation != nil {
+		human.SetLocation(displayLocation)
+	}
+	return formatter
+}
+
+func runMeta(meta *output.Meta) {
+	meta.Retries = int(apiRetryCount.Load())
+	meta.Cache = catalogMeta()
+	meta.Page = logsPage.Load()
 }
 
 func resolvedOutputFormat() string {
@@ -2,9 +2,12 @@ package cli
 
 import (
 	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 )
 
 func TestResolvedOutputFormat_DefaultHuman(t *testing.T) {
@@ -361,3 +364,47 @@ func TestExecute_TracksUnknownCommandError_WithProfileFlagValue(t *testing.T) {
 		t.Fatalf("unknown_command = %q, want %q", unknown, "get")
 	}
 }
+
+func TestExecute_TimeoutAppliesUnderLogsAndUsage(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	t.Cleanup(server.Close)
+	t.Cleanup(func() { close(release) })
+	t.Setenv("DWELLIR_CONFIG_DIR", t.TempDir())
+	t.Setenv("DWELLIR_API_URL", server.URL)
+	t.Setenv("DWELLIR_TOKEN", "token")
+	resetOutputFlagsForTest(t)
+	clearAgentMarkers(t)
+
+	oldArgs := os.Args
+	oldTelemetry := telemetryClient
+	telemetryClient = &fakeTelemetry{}
+	rootCmd.SetOut(io.Discard)
+	rootCmd.SetErr(io.Discard)
+	t.Cleanup(func() {
+		os.Args = oldArgs
+		telemetryClient = oldTelemetry
+		cmdTimeout = 0
+		rootCmd.SetArgs(nil)
+	})
+
+	for _, args := range [][]string{
+		{"logs", "errors", "--timeout", "200ms", "--json"},
+		{"usage", "summary", "--timeout", "200ms", "--json"},
+	} {
+		rootCmd.SetArgs(args)
+		os.Args = append([]string{"dwellir"}, args...)
+		started := time.Now()
+		if err := Execute(); err == nil {
+			t.Fatalf("%v: expected a timeout error", args)
+		}
+		if elapsed := time.Since(started); elapsed > 5*time.Second {
+			t.Fatalf("%v: --timeout 200ms took %s", args, elapsed)
+		}
+	}
+}
@@ -0,0 +1,450 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dwellir-public/cli/internal/rpc"
+)
+
+var (
+	rpcEcosystem string
+	rpcNodeType  string
+	rpcNetwork   string
+	rpcKeyName   string
+
+	rpcBatchFile        string
+	rpcBatchMode        string
+	rpcBatchSize        int
+	rpcBatchConcurrency int
+
+	rpcSubscribeCount         int
+	rpcSubscribeDuration      time.Duration
+	rpcSubscribeMaxReconnects int
+)
+
+var rpcCmd = &cobra.Command{
+	Use:   "rpc",
+	Short: "Send JSON-RPC requests to a chain endpoint",
+	Long: `Send JSON-RPC requests to a Dwellir endpoint resolved by chain name.
+
+The endpoint is looked up like 'dwellir endpoints get', and an API key is
+injected automatically (pass --key <name> when you have more than one).
+
+Use filter flags with any subcommand:
+  --network    Network (mainnet, testnet, or network name; defaults to mainnet)
+  --node-type  Node type (full, archive)
+  --ecosystem  Ecosystem (evm, substrate, cosmos, move, hyperliquid, other)
+  --key        API key name or value to use
+
+Examples:
+  dwellir rpc call ethereum eth_blockNumber
+  dwellir rpc call base eth_getBalance 0xd8dA6BF26964aF9D7eEd9e03E53415D37aA96045 latest
+  dwellir rpc call polkadot chain_getBlockHash 1000000 --network mainnet
+  dwellir rpc subscribe ethereum newHeads --count 5`,
+}
+
+var rpcCallCmd = &cobra.Command{
+	Use:   "call <chain> <method> [params...]",
+	Short: "Call a JSON-RPC method on a chain endpoint",
+	Long: `Call a JSON-RPC method on a chain endpoint.
+
+Params are positional. Each one is sent as JSON when it parses as JSON
+(numbers, booleans, quoted strings, arrays, objects) and as a string otherwise.
+A single JSON array or object argument is sent as the params value verbatim.`,
+	Args: rpcCallArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		chainLookup, method := args[0], args[1]
+		params, err := rpc.ParseParams(args[2:])
+		if err != nil {
+			return getFormatter().Error("validation_error", err.Error(), "Quote JSON params, e.g. '[\"0x1\", false]'.")
+		}
+
+		client, err := newAPIClient()
+		if err != nil {
+			return getFormatter().Error("not_authenticated", err.Error(), "")
+		}
+		target, err := resolveEndpointTarget(cmd.Context(), client, chainLookup, rpcEcosystem, rpcNodeType, "https", rpcNetwork, rpcKeyName)
+		if err != nil {
+			return formatEndpointResolveError(err)
+		}
+
+		result, err := rpc.NewClient(target.URL).Call(cmd.Context(), method, params)
+		if err != nil {
+			return formatRPCError(err)
+		}
+		if result.Response.Error != nil {
+			return formatRPCError(result.Response.Error)
+		}
+
+		return getFormatter().Success("rpc.call", rpc.CallSummary{
+			Chain:      target.Chain,
+			Network:    target.Network,
+			NodeType:   target.NodeType,
+			Endpoint:   target.Template,
+			Method:     method,
+			HTTPStatus: result.StatusCode,
+			LatencyMs:  durationMillis(result.Latency),
+			Result:     result.Response.Result,
+		})
+	},
+}
+
+var rpcBatchCmd = &cobra.Command{
+	Use:   "batch <chain>",
+	Short: "Replay a file of JSON-RPC requests against a chain endpoint",
+	Long: `Replay a set of JSON-RPC requests against a chain endpoint.
+
+Input is a JSON array of request objects or NDJSON (one request per line),
+read from --file or stdin. Missing "jsonrpc" and "id" members are filled in;
+responses are matched to requests by id.
+
+Modes:
+  auto      Send JSON-RPC batches; if the endpoint rejects them, send the rest in parallel (default)
+  batch     Send JSON-RPC batches of up to --batch-size requests
+  parallel  Send requests individually with up to --concurrency in flight
+
+Examples:
+  dwellir rpc batch ethereum --file requests.json
+  cat requests.ndjson | dwellir rpc batch base --mode parallel --concurrency 8`,
+	Args: rpcBatchArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		mode := strings.ToLower(strings.TrimSpace(rpcBatchMode))
+		switch mode {
+		case "auto", "batch", "parallel":
+		default:
+			return getFormatter().Error("validation_error", fmt.Sprintf("Invalid --mode %q.", rpcBatchMode), "Supported modes: auto, batch, parallel")
+		}
+
+		data, err := readRPCBatchInput(cmd, rpcBatchFile)
+		if err != nil {
+			return getFormatter().Error("validation_error", err.Error(), "Pass --file <path> or pipe requests on stdin.")
+		}
+		reqs, err := rpc.ParseRequests(data)
+		if err != nil {
+			return getFormatter().Error("validation_error", err.Error(), "Provide a JSON array of requests or one request object per line.")
+		}
+
+		client, err := newAPIClient()
+		if err != nil {
+			return getFormatter().Error("not_authenticated", err.Error(), "")
+		}
+		target, err := resolveEndpointTarget(cmd.Context(), client, args[0], rpcEcosystem, rpcNodeType, "https", rpcNetwork, rpcKeyName)
+		if err != nil {
+			return formatEndpointResolveError(err)
+		}
+
+		rpcClient := rpc.NewClient(target.URL)
+		started := time.Now()
+		var items []rpc.BatchItem
+		pending := reqs
+		if mode != "parallel" {
+			items, err = sendRPCBatches(cmd, rpcClient, reqs, rpcBatchSize)
+			if errors.Is(err, rpc.ErrBatchUnsupported) && mode == "auto" {
+				// Chunks the endpoint already answered are kept, not replayed.
+				pending = reqs[len(items):]
+				if !quiet && isHumanOutput() {
+					_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Endpoint rejected JSON-RPC batches; sending %d remaining request(s) in parallel.\n", len(pending))
+				}
+				mode = "parallel"
+				if len(items) > 0 {
+					mode = "batch+parallel"
+				}
+				err = nil
+			}
+			var rpcErr *rpc.Error
+			if errors.As(err, &rpcErr) {
+				return getFormatter().Error(
+					"rpc_error",
+					fmt.Sprintf("Batch rejected: JSON-RPC error %d: %s", rpcErr.Code, rpcErr.Message),
+					"Lower --batch-size to send smaller batches.",
+				)
+			}
+			if err != nil {
+				return formatRPCError(err)
+			}
+		}
+		if mode != "batch" {
+			items = append(items, rpcClient.Parallel(cmd.Context(), pending, rpcBatchConcurrency)...)
+			if ctxErr := cmd.Context().Err(); ctxErr != nil {
+				return formatCommandError(ctxErr)
+			}
+		}
+
+		summary := rpc.BatchSummary{
+			Chain:    target.Chain,
+			Network:  target.Network,
+			NodeType: target.NodeType,
+			Endpoint: target.Template,
+			Mode:     mode,
+			Requests: len(items),
+			TotalMs:  durationMillis(time.Since(started)),
+			Items:    items,
+		}
+		for _, item := range items {
+			if item.OK() {
+				summary.Succeeded++
+			} else {
+				summary.Failed++
+			}
+		}
+		return getFormatter().Success("rpc.batch", summary)
+	},
+}
+
+var rpcSubscribeCmd = &cobra.Command{
+	Use:   "subscribe <chain> <subscription> [params...]",
+	Short: "Stream subscription notifications from a chain's WebSocket endpoint",
+	Long: `Open the chain's WSS endpoint and stream subscription notifications.
+
+EVM topics (newHeads, logs, newPendingTransactions) are sent via eth_subscribe
+with any params appended. Full method names such as chain_subscribeNewHeads
+are sent as-is. Params follow the same rules as 'rpc call'.
+
+Notifications stream as NDJSON with --json or --toon, or as a live table.
+Dropped connections are re-established and resubscribed automatically.
+The command stops after --count notifications, after --duration, or on Ctrl-C.
+
+Examples:
+  dwellir rpc subscribe ethereum newHeads --count 5
+  dwellir rpc subscribe base logs '{"address":"0x833589fCD6eDb6E08f4c7C32D4f71b54bdA02913"}' --json
+  dwellir rpc subscribe polkadot chain_subscribeNewHeads --duration 1m`,
+	Args: rpcSubscribeArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if rpcSubscribeCount < 0 || rpcSubscribeDuration < 0 {
+			return getFormatter().Error("validation_error", "--count and --duration must not be negative.", "")
+		}
+		params, err := rpc.ParseParams(args[2:])
+		if err != nil {
+			return getFormatter().Error("validation_error", err.Error(), "Quote JSON params, e.g. '{\"address\":\"0x...\"}'.")
+		}
+
+		client, err := newAPIClient()
+		if err != nil {
+			return getFormatter().Error("not_authenticated", err.Error(), "")
+		}
+		target, err := resolveEndpointTarget(cmd.Context(), client, args[0], rpcEcosystem, rpcNodeType, "wss", rpcNetwork, rpcKeyName)
+		if err != nil {
+			return formatEndpointResolveError(err)
+		}
+		sub, err := rpc.NewSubscription(target.URL, args[1], params)
+		if err != nil {
+			return getFormatter().Error("validation_error", err.Error(), "")
+		}
+		sub.MaxReconnects = rpcSubscribeMaxReconnects
+
+		human := isHumanOutput()
+		stderr := cmd.ErrOrStderr()
+		out := cmd.OutOrStdout()
+		sub.OnSubscribed = func(id string) {
+			if human && !quiet {
+				_, _ = fmt.Fprintf(stderr, "Subscribed to %s on %s %s (id %s)\n", args[1], target.Chain, target.Network, id)
+			}
+		}
+		sub.OnReconnect = func(attempt int, delay time.Duration, err error) {
+			if human && !quiet {
+				_, _ = fmt.Fprintf(stderr, "Reconnecting in %s (attempt %d/%d): %v\n", delay, attempt, sub.MaxReconnects, err)
+			}
+		}
+
+		ctx := cmd.Context()
+		if rpcSubscribeDuration > 0 {
+			var cancel func()
+			ctx, cancel = context.WithTimeout(ctx, rpcSubscribeDuration)
+			defer cancel()
+		}
+
+		encoder := json.NewEncoder(out)
+		received := 0
+		if human {
+			_, _ = fmt.Fprintf(out, "%-6s  %-12s  %s\n", "#", "RECEIVED", "EVENT")
+		}
+		err = sub.Run(ctx, func(n rpc.Notification) error {
+			received++
+			if human {
+				_, _ = fmt.Fprintf(out, "%-6d  %-12s  %s\n", n.Seq, n.ReceivedAt.Local().Format("15:04:05.000"), summarizeNotification(n.Result))
+			} else if err := encoder.Encode(n); err != nil {
+				return err
+			}
+			if rpcSubscribeCount > 0 && received >= rpcSubscribeCount {
+				return rpc.ErrStopSubscription
+			}
+			return nil
+		})
+		// Reaching --duration or pressing Ctrl-C is the normal way to stop a stream.
+		if err != nil && (errors.Is(err, context.Canceled) || (rpcSubscribeDuration > 0 && ctx.Err() != nil && cmd.Context().Err() == nil)) {
+			err = nil
+		}
+		if err != nil {
+			return formatRPCError(err)
+		}
+		if human && !quiet {
+			_, _ = fmt.Fprintf(stderr, "Received %d notification(s).\n", received)
+		}
+		return nil
+	},
+}
+
+// summarizeNotification renders a one-line description of a notification
+// payload, preferring block and log identifiers over the raw JSON.
+func summarizeNotification(result json.RawMessage) string {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(result, &fields); err == nil {
+		var parts []string
+		for _, key := range []string{"number", "blockNumber", "hash", "transactionHash", "address"} {
+			raw, ok := fields[key]
+			if !ok {
+				continue
+			}
+			var value string
+			if json.Unmarshal(raw, &value) != nil {
+				value = string(raw)
+			}
+			if (key == "number" || key == "blockNumber") && strings.HasPrefix(value, "0x") {
+				if n, parseErr := strconv.ParseUint(strings.TrimPrefix(value, "0x"), 16, 64); parseErr == nil {
+					value = strconv.FormatUint(n, 10)
+				}
+			}
+			parts = append(parts, key+"="+value)
+		}
+		if len(parts) > 0 {
+			return strings.Join(parts, " ")
+		}
+	}
+
+	var compact bytes.Buffer
+	text := string(result)
+	if json.Compact(&compact, result) == nil {
+		text = compact.String()
+	}
+	const limit = 120
+	if runes := []rune(text); len(runes) > limit {
+		return string(runes[:limit-1]) + "…"
+	}
+	return text
+}
+
+func rpcSubscribeArgs(cmd *cobra.Command, args []string) error {
+	if len(args) < 2 {
+		return getFormatter().Error(
+			"validation_error",
+			"Missing required arguments <chain> <subscription>.",
+			"Example: dwellir rpc subscribe ethereum newHeads --count 5",
+		)
+	}
+	return nil
+}
+
+// sendRPCBatches sends reqs in batches of size. On an error it returns the
+// items of the batches answered so far, in request order, with the error.
+func sendRPCBatches(cmd *cobra.Command, client *rpc.Client, reqs []rpc.Request, size int) ([]rpc.BatchItem, error) {
+	if size < 1 {
+		size = len(reqs)
+	}
+	items := make([]rpc.BatchItem, 0, len(reqs))
+	for start := 0; start < len(reqs); start += size {
+		end := min(start+size, len(reqs))
+		chunk, err := client.Batch(cmd.Context(), reqs[start:end])
+		if err != nil {
+			return items, err
+		}
+		items = append(items, chunk...)
+	}
+	return items, nil
+}
+
+func readRPCBatchInput(cmd *cobra.Command, path string) ([]byte, error) {
+	path = strings.TrimSpace(path)
+	if path == "" || path == "-" {
+		return io.ReadAll(cmd.InOrStdin())
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	return data, nil
+}
+
+func rpcBatchArgs(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return getFormatter().Error(
+			"validation_error",
+			"Expected exactly one argument <chain>.",
+			"Example: dwellir rpc batch ethereum --file requests.json",
+		)
+	}
+	return nil
+}
+
+func rpcCallArgs(cmd *cobra.Command, args []string) error {
+	if len(args) < 2 {
+		return getFormatter().Error(
+			"validation_error",
+			"Missing required arguments <chain> <method>.",
+			"Example: dwellir rpc call ethereum eth_blockNumber",
+		)
+	}
+	return nil
+}
+
+func formatRPCError(err error) error {
+	var rpcErr *rpc.Error
+	if errors.As(err, &rpcErr) {
+		help := ""
+		if len(rpcErr.Data) > 0 {
+			help = "Data: " + string(rpcErr.Data)
+		}
+		return getFormatter().Error("rpc_error", fmt.Sprintf("JSON-RPC error %d: %s", rpcErr.Code, rpcErr.Message), help)
+	}
+
+	var httpErr *rpc.HTTPError
+	if errors.As(err, &httpErr) {
+		code := "rpc_http_error"
+		switch httpErr.StatusCode {
+		case 401, 403:
+			code = "forbidden"
+		case 429:
+			code = "rate_limited"
+		}
+		return getFormatter().Error(code, fmt.Sprintf("RPC endpoint returned HTTP %d.", httpErr.StatusCode), strings.TrimSpace(httpErr.Body))
+	}
+
+	return formatCommandError(err)
+}
+
+func durationMillis(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
+
+func init() {
+	rpcCmd.PersistentFlags().StringVar(&rpcEcosystem, "ecosystem", "", "Filter by ecosystem (evm, substrate, cosmos, move, hyperliquid, other)")
+	rpcCmd.PersistentFlags().StringVar(&rpcNodeType, "node-type", "", "Node type (full, archive; default: full when a network has both)")
+	rpcCmd.PersistentFlags().StringVar(&rpcNetwork, "network", "", "Network (mainnet, testnet, or network name)")
+	rpcCmd.PersistentFlags().StringVar(&rpcKeyName, "key", "", "API key name or value to inject (required when you have several keys)")
+
+	rpcBatchCmd.Flags().StringVar(&rpcBatchFile, "file", "", "Read requests from a file (default: stdin)")
+	rpcBatchCmd.Flags().StringVar(&rpcBatchMode, "mode", "auto", "Send mode (auto, batch, parallel)")
+	rpcBatchCmd.Flags().IntVar(&rpcBatchSize, "batch-size", 100, "Max requests per JSON-RPC batch")
+	rpcBatchCmd.Flags().IntVar(&rpcBatchConcurrency, "concurrency", 4, "Max requests in flight in parallel mode")
+
+	rpcSubscribeCmd.Flags().IntVar(&rpcSubscribeCount, "count", 0, "Stop after this many notifications (0 = unlimited)")
+	rpcSubscribeCmd.Flags().DurationVar(&rpcSubscribeDuration, "duration", 0, "Stop after this long, e.g. 30s or 5m (0 = until interrupted)")
+	rpcSubscribeCmd.Flags().IntVar(&rpcSubscribeMaxReconnects, "max-reconnects", 5, "Consecutive reconnect attempts before giving up")
+
+	addCatalogFlags(rpcCmd)
+	for _, cmd := range []*cobra.Command{rpcCallCmd, rpcBatchCmd, rpcSubscribeCmd} {
+		cmd.ValidArgsFunction = completeChainNames
+	}
+	rpcCmd.AddCommand(rpcCallCmd, rpcBatchCmd, rpcSubscribeCmd)
+	rootCmd.AddCommand(rpcCmd)
+}
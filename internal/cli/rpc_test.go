@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dwellir-public/cli/internal/rpc"
+)
+
+func TestSummarizeNotification(t *testing.T) {
+	cases := map[string]string{
+		`{"number":"0x10","hash":"0xabc","parentHash":"0x1"}`:           "number=16 hash=0xabc",
+		`{"blockNumber":"0x2","transactionHash":"0xt","address":"0xa"}`: "blockNumber=2 transactionHash=0xt address=0xa",
+		`"0xpending"`:         `"0xpending"`,
+		"{ \"other\": true }": `{"other":true}`,
+	}
+	for input, want := range cases {
+		if got := summarizeNotification(json.RawMessage(input)); got != want {
+			t.Errorf("summarizeNotification(%s) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestSendRPCBatchesKeepsAnsweredChunks(t *testing.T) {
+	var batches atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if batches.Add(1) > 1 {
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":null,"error":{"code":-32600,"message":"invalid request"}}`))
+			return
+		}
+		var reqs []rpc.Request
+		_ = json.NewDecoder(r.Body).Decode(&reqs)
+		responses := make([]rpc.Response, len(reqs))
+		for i, req := range reqs {
+			responses[i] = rpc.Response{JSONRPC: "2.0", ID: req.ID, Result: json.RawMessage(fmt.Sprintf("%q", req.Method))}
+		}
+		_ = json.NewEncoder(w).Encode(responses)
+	}))
+	defer server.Close()
+
+	reqs, _ := rpc.ParseRequests([]byte(`[{"method":"a"},{"method":"b"},{"method":"c"}]`))
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	items, err := sendRPCBatches(cmd, rpc.NewClient(server.URL), reqs, 2)
+	if !errors.Is(err, rpc.ErrBatchUnsupported) {
+		t.Fatalf("expected ErrBatchUnsupported from the second chunk, got %v", err)
+	}
+	if len(items) != 2 || string(items[1].Result) != `"b"` {
+		t.Fatalf("expected the first chunk's items to be kept, got %+v", items)
+	}
+}
@@ -0,0 +1,114 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dwellir-public/cli/internal/api"
+	"github.com/dwellir-public/cli/internal/timeexpr"
+)
+
+// timeExprHelp lists the forms accepted by --from, --to and --since.
+const timeExprHelp = "Use RFC3339 (2026-02-27T00:00:00Z), a date (2026-02-27), now, -15m, 24h ago, today, yesterday, this-cycle or last-cycle."
+
+var (
+	timezoneName string
+	// displayLocation is the --tz location; nil when the flag is not set.
+	displayLocation *time.Location
+)
+
+// addTimezoneFlag adds --tz to cmd and its subcommands. Its hook replaces
+// the root one for them, so it runs that first.
+func addTimezoneFlag(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVar(&timezoneName, "tz", "", "Time zone for dates without a zone and for human output (UTC, local, or e.g. Europe/Stockholm)")
+	cmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		rootPersistentPreRun(cmd, args)
+		loc, err := loadTimezone(timezoneName)
+		if err != nil {
+			return getFormatter().Error("validation_error", err.Error(), "Use UTC, local, or an IANA zone name such as Europe/Stockholm.")
+		}
+		displayLocation = loc
+		return nil
+	}
+}
+
+// loadTimezone resolves a --tz value; an empty name yields nil.
+func loadTimezone(name string) (*time.Location, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "":
+		return nil, nil
+	case "utc":
+		return time.UTC, nil
+	case "local":
+		return time.Local, nil
+	}
+	loc, err := time.LoadLocation(strings.TrimSpace(name))
+	if err != nil {
+		return nil, fmt.Errorf("unknown time zone %q", name)
+	}
+	return loc, nil
+}
+
+// timeReference resolves time expressions at now in the --tz location. The
+// billing cycle for this-cycle and last-cycle is looked up on first use.
+func timeReference(ctx context.Context, now time.Time) timeexpr.Reference {
+	var sub *api.CurrentSubscriptionWindow
+	looked := false
+	return timeexpr.Reference{
+		Now:      now,
+		Location: displayLocation,
+		CycleStart: func(at time.Time) (time.Time, error) {
+			if !looked {
+				client, err := newAPIClient()
+				if err != nil {
+					return time.Time{}, err
+				}
+				info, err := api.NewAccountAPI(client).Info(ctx)
+				if err != nil {
+					return time.Time{}, err
+				}
+				sub, looked = info.CurrentSubscription, true
+			}
+			start, _ := api.CurrentBillingCycleRange(at, sub)
+			return start, nil
+		},
+	}
+}
+
+// resolveTimeFlags turns --from, --to and --since into instants. Unset flags
+// yield zero times; --since is a shorthand for --from and excludes it.
+func resolveTimeFlags(ref timeexpr.Reference, from, to, since string) (time.Time, time.Time, error) {
+	var start, end time.Time
+	if strings.TrimSpace(since) != "" {
+		if strings.TrimSpace(from) != "" {
+			return start, end, getFormatter().Error("validation_error", "--since and --from cannot be combined.", "--since 2h is short for --from -2h.")
+		}
+		parsed, err := timeexpr.ParseSince(since, ref)
+		if err != nil {
+			return start, end, getFormatter().Error("validation_error", fmt.Sprintf("Invalid --since: %v.", err), "Use a duration such as 15m or 2d. "+timeExprHelp)
+		}
+		start = parsed
+	}
+	if strings.TrimSpace(from) != "" {
+		parsed, err := timeexpr.Parse(from, ref)
+		if err != nil {
+			return start, end, getFormatter().Error("validation_error", fmt.Sprintf("Invalid --from: %v.", err), timeExprHelp)
+		}
+		start = parsed
+	}
+	if strings.TrimSpace(to) != "" {
+		parsed, err := timeexpr.Parse(to, ref)
+		if err != nil {
+			return start, end, getFormatter().Error("validation_error", fmt.Sprintf("Invalid --to: %v.", err), timeExprHelp)
+		}
+		end = parsed
+	}
+	if !start.IsZero() && !end.IsZero() && !start.Before(end) {
+		return start, end, getFormatter().Error("validation_error", "`--from` must be earlier than `--to`.", "")
+	}
+	return start, end, nil
+}
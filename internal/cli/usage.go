@@ -14,6 +14,7 @@ var (
 	usageInterval string
 	usageFrom     string
 	usageTo       string
+	usageSince    string
 	usageAPIKey   string
 	usageFQDN     string
 	usageMethod   string
@@ -33,18 +34,18 @@ var usageSummaryCmd = &cobra.Command{
 			return getFormatter().Error("not_authenticated", err.Error(), "")
 		}
 		usageAPI := api.NewUsageAPI(client)
-		summary, err := usageAPI.Summary()
+		summary, err := usageAPI.Summary(cmd.Context())
 		if err != nil {
 			return formatCommandError(err)
 		}
 
 		// Frontend uses organization RPS analytics for limitedRequests.
 		// Keep summary aligned by pulling the same source over the current billing cycle.
-		if accountInfo, infoErr := api.NewAccountAPI(client).Info(); infoErr == nil && summary != nil {
+		if accountInfo, infoErr := api.NewAccountAPI(client).Info(cmd.Context()); infoErr == nil && summary != nil {
 			start, end := api.CurrentBillingCycleRange(time.Now().UTC(), accountInfo.CurrentSubscription)
 			startStr := start.Format(time.RFC3339)
 			endStr := end.Format(time.RFC3339)
-			if stats, statsErr := usageAPI.OrganizationRPS("day", startStr, endStr, "", ""); statsErr == nil && stats != nil {
+			if stats, statsErr := usageAPI.OrganizationRPS(cmd.Context(), "day", startStr, endStr, "", ""); statsErr == nil && stats != nil {
 				summary.RateLimited = int(math.Round(stats.LimitedRequests))
 			}
 			summary.BillingStart = startStr
@@ -62,11 +63,11 @@ var usageHistoryCmd = &cobra.Command{
 		if err != nil {
 			return getFormatter().Error("not_authenticated", err.Error(), "")
 		}
-		window, err := resolveUsageWindow(usageInterval, usageFrom, usageTo)
+		window, err := resolveUsageWindow(usageInterval, usageFrom, usageTo, usageSince, timeReference(cmd.Context(), time.Now()))
 		if err != nil {
 			return err
 		}
-		if err := validateUsageLookback(client, window); err != nil {
+		if err := validateUsageLookback(cmd.Context(), client, window); err != nil {
 			return err
 		}
 		if window.UsedDefaults && window.DefaultLabel != "" && !quiet {
@@ -81,6 +82,7 @@ var usageHistoryCmd = &cobra.Command{
 
 		usageAPI := api.NewUsageAPI(client)
 		breakdown, err := usageAPI.EndpointBreakdown(
+			cmd.Context(),
 			window.Interval,
 			window.FormattedStart,
 			window.FormattedEnd,
@@ -93,6 +95,7 @@ var usageHistoryCmd = &cobra.Command{
 		}
 		if !isHumanOutput() {
 			raw, rawErr := usageAPI.RawHistory(
+				cmd.Context(),
 				window.Interval,
 				window.FormattedStart,
 				window.FormattedEnd,
@@ -117,11 +120,11 @@ var usageRPSCmd = &cobra.Command{
 		if err != nil {
 			return getFormatter().Error("not_authenticated", err.Error(), "")
 		}
-		window, err := resolveUsageWindow(usageInterval, usageFrom, usageTo)
+		window, err := resolveUsageWindow(usageInterval, usageFrom, usageTo, usageSince, timeReference(cmd.Context(), time.Now()))
 		if err != nil {
 			return err
 		}
-		if err := validateUsageLookback(client, window); err != nil {
+		if err := validateUsageLookback(cmd.Context(), client, window); err != nil {
 			return err
 		}
 		if window.UsedDefaults && window.DefaultLabel != "" && !quiet {
@@ -135,6 +138,7 @@ var usageRPSCmd = &cobra.Command{
 		}
 
 		rps, err := api.NewUsageAPI(client).RPS(
+			cmd.Context(),
 			window.Interval,
 			window.FormattedStart,
 			window.FormattedEnd,
@@ -156,11 +160,11 @@ var usageMethodsCmd = &cobra.Command{
 		if err != nil {
 			return getFormatter().Error("not_authenticated", err.Error(), "")
 		}
-		window, err := resolveUsageWindow(usageInterval, usageFrom, usageTo)
+		window, err := resolveUsageWindow(usageInterval, usageFrom, usageTo, usageSince, timeReference(cmd.Context(), time.Now()))
 		if err != nil {
 			return err
 		}
-		if err := validateUsageLookback(client, window); err != nil {
+		if err := validateUsageLookback(cmd.Context(), client, window); err != nil {
 			return err
 		}
 		if window.UsedDefaults && window.DefaultLabel != "" && !quiet {
@@ -174,6 +178,7 @@ var usageMethodsCmd = &cobra.Command{
 		}
 
 		methods, err := api.NewUsageAPI(client).MethodBreakdown(
+			cmd.Context(),
 			window.Interval,
 			window.FormattedStart,
 			window.FormattedEnd,
@@ -195,11 +200,11 @@ var usageCostsCmd = &cobra.Command{
 		if err != nil {
 			return getFormatter().Error("not_authenticated", err.Error(), "")
 		}
-		window, err := resolveUsageWindow(usageInterval, usageFrom, usageTo)
+		window, err := resolveUsageWindow(usageInterval, usageFrom, usageTo, usageSince, timeReference(cmd.Context(), time.Now()))
 		if err != nil {
 			return err
 		}
-		if err := validateUsageLookback(client, window); err != nil {
+		if err := validateUsageLookback(cmd.Context(), client, window); err != nil {
 			return err
 		}
 		if window.UsedDefaults && window.DefaultLabel != "" && !quiet {
@@ -213,21 +218,22 @@ var usageCostsCmd = &cobra.Command{
 		}
 
 		accountAPI := api.NewAccountAPI(client)
-		sub, err := accountAPI.Subscription()
+		sub, err := accountAPI.Subscription(cmd.Context())
 		if err != nil {
 			return formatCommandError(err)
 		}
-		info, err := accountAPI.Info()
+		info, err := accountAPI.Info(cmd.Context())
 		if err != nil {
 			return formatCommandError(err)
 		}
-		discount, err := accountAPI.Discount()
+		discount, err := accountAPI.Discount(cmd.Context())
 		if err != nil {
 			return formatCommandError(err)
 		}
 
 		usageAPI := api.NewUsageAPI(client)
 		filteredRows, err := usageAPI.RawHistory(
+			cmd.Context(),
 			window.Interval,
 			window.FormattedStart,
 			window.FormattedEnd,
@@ -244,6 +250,7 @@ var usageCostsCmd = &cobra.Command{
 		if hasFilters {
 			earliest := api.EarliestBillingPeriodStart(window.Start, window.End, info.CurrentSubscription)
 			basisRows, err = usageAPI.RawHistory(
+				cmd.Context(),
 				window.Interval,
 				earliest.Format(time.RFC3339),
 				window.FormattedEnd,
@@ -283,7 +290,7 @@ var usageLimitsCmd = &cobra.Command{
 		if err != nil {
 			return getFormatter().Error("not_authenticated", err.Error(), "")
 		}
-		sub, err := api.NewAccountAPI(client).Subscription()
+		sub, err := api.NewAccountAPI(client).Subscription(cmd.Context())
 		if err != nil {
 			return formatCommandError(err)
 		}
@@ -300,14 +307,16 @@ var usageLimitsCmd = &cobra.Command{
 func init() {
 	for _, sub := range []*cobra.Command{usageHistoryCmd, usageRPSCmd, usageMethodsCmd, usageCostsCmd} {
 		sub.Flags().StringVar(&usageInterval, "interval", "hour", "Aggregation interval (minute, hour, day). Default: hour.")
-		sub.Flags().StringVar(&usageFrom, "from", "", "Start time (RFC3339). Example: 2026-02-27T00:00:00Z")
-		sub.Flags().StringVar(&usageTo, "to", "", "End time (RFC3339). Example: 2026-02-27T23:59:59Z")
+		sub.Flags().StringVar(&usageFrom, "from", "", "Start time: RFC3339, a date, -24h, yesterday, this-cycle, ... Example: 2026-02-27T00:00:00Z")
+		sub.Flags().StringVar(&usageTo, "to", "", "End time: RFC3339, a date, now, today, ... Example: 2026-02-27T23:59:59Z")
+		sub.Flags().StringVar(&usageSince, "since", "", "Start this long ago, e.g. 6h or 7d (short for --from -6h)")
 		sub.Flags().StringVar(&usageAPIKey, "api-key", "", "Filter by API key value")
 		sub.Flags().StringVar(&usageFQDN, "fqdn", "", "Filter by endpoint hostname (FQDN)")
 	}
 	usageHistoryCmd.Flags().StringVar(&usageMethod, "method", "", "Filter by RPC method")
 	usageCostsCmd.Flags().StringVar(&usageMethod, "method", "", "Filter by RPC method")
 
+	addTimezoneFlag(usageCmd)
 	usageCmd.AddCommand(usageSummaryCmd, usageHistoryCmd, usageRPSCmd, usageMethodsCmd, usageCostsCmd, usageLimitsCmd)
 	rootCmd.AddCommand(usageCmd)
 }
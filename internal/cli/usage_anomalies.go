@@ -0,0 +1,208 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dwellir-public/cli/internal/api"
+)
+
+var (
+	usageAnomalyInterval     string
+	usageAnomalyScore        string
+	usageAnomalyBaseline     int
+	usageAnomalyThreshold    float64
+	usageAnomalyBy           []string
+	usageAnomalyKind         string
+	usageAnomalyMinResponses int
+	usageAnomalyExitCode     bool
+)
+
+// usageAnomalyLag keeps the newest bucket out of the scan until analytics
+// for it have settled, so late data does not read as a drop.
+const usageAnomalyLag = 5 * time.Minute
+
+var usageAnomaliesCmd = &cobra.Command{
+	Use:   "anomalies",
+	Short: "Flag usage spikes and drops per API key, endpoint, and method",
+	Long: `Scan hourly or daily usage per API key, endpoint, and RPC method and flag
+buckets whose responses spike above or drop below a rolling baseline of the
+--baseline buckets before them (default 24 hours or 14 days).
+
+Buckets are scored with the median absolute deviation (--score mad, the
+default, threshold 3.5) or with z-scores (--score zscore, threshold 3).
+Consecutive anomalous buckets are reported as one anomaly with its window,
+responses against the baseline, peak score, and the keys, endpoints, and
+methods involved. Quiet series below --min-responses are ignored.
+
+With --exit-code the command fails with anomalies_found when anything is
+flagged, for use in cron jobs and CI.
+
+Examples:
+  dwellir usage anomalies
+  dwellir usage anomalies --since 3h --kind spike --exit-code
+  dwellir usage anomalies --interval day --since 7d --by key --json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		interval := strings.ToLower(strings.TrimSpace(usageAnomalyInterval))
+		if interval != "hour" && interval != "day" {
+			return getFormatter().Error("validation_error", fmt.Sprintf("Invalid interval %q.", usageAnomalyInterval), "Supported intervals: hour, day")
+		}
+		score := strings.ToLower(strings.TrimSpace(usageAnomalyScore))
+		if score != api.AnomalyMAD && score != api.AnomalyZScore {
+			return getFormatter().Error("validation_error", fmt.Sprintf("Invalid --score %q.", usageAnomalyScore), "Supported scores: mad, zscore")
+		}
+		kind := strings.ToLower(strings.TrimSpace(usageAnomalyKind))
+		switch kind {
+		case "any", "":
+			kind = ""
+		case api.AnomalySpike, api.AnomalyDrop:
+		default:
+			return getFormatter().Error("validation_error", fmt.Sprintf("Invalid --kind %q.", usageAnomalyKind), "Supported kinds: spike, drop, any")
+		}
+		dimensions, err := parseAnomalyDimensions(usageAnomalyBy)
+		if err != nil {
+			return err
+		}
+		switch {
+		case usageAnomalyBaseline < 0 || (usageAnomalyBaseline > 0 && usageAnomalyBaseline < 3):
+			return getFormatter().Error("validation_error", "--baseline must be at least 3 buckets.", "Leave it at 0 for the interval's default.")
+		case usageAnomalyThreshold < 0 || usageAnomalyMinResponses < 0:
+			return getFormatter().Error("validation_error", "--threshold and --min-responses must not be negative.", "")
+		}
+		baseline := usageAnomalyBaseline
+		if baseline == 0 {
+			baseline = 24
+			if interval == "day" {
+				baseline = 14
+			}
+		}
+
+		client, err := newAPIClient()
+		if err != nil {
+			return getFormatter().Error("not_authenticated", err.Error(), "")
+		}
+		now := time.Now()
+		window, err := resolveUsageWindow(interval, usageFrom, usageTo, usageSince, timeReference(cmd.Context(), now))
+		if err != nil {
+			return err
+		}
+		sub, err := api.NewAccountAPI(client).Subscription(cmd.Context())
+		if err != nil {
+			return formatCommandError(err)
+		}
+		if err := checkUsageLookback(sub.ID, window.Start); err != nil {
+			return err
+		}
+		if window.UsedDefaults && window.DefaultLabel != "" && !quiet {
+			_, _ = fmt.Fprintf(
+				cmd.ErrOrStderr(),
+				"Using default usage window (%s): %s to %s\n",
+				window.DefaultLabel,
+				window.FormattedStart,
+				window.FormattedEnd,
+			)
+		}
+
+		// The baseline reaches back before the window, but no further than
+		// the plan's lookback.
+		step := time.Hour
+		if interval == "day" {
+			step = 24 * time.Hour
+		}
+		historyStart := window.Start.UTC().Truncate(step).Add(-time.Duration(baseline) * step)
+		maxLookback, _, _ := planLookback(sub.ID)
+		if oldest := now.Add(-maxLookback).UTC().Truncate(time.Minute).Add(time.Minute); historyStart.Before(oldest) {
+			historyStart = oldest
+		}
+		end := window.End
+		if settled := now.Add(-usageAnomalyLag).UTC(); end.After(settled) {
+			end = settled
+		}
+
+		history, err := api.NewUsageAPI(client).History(
+			cmd.Context(),
+			interval,
+			historyStart.Format(time.RFC3339),
+			window.FormattedEnd,
+			usageAPIKey,
+			usageFQDN,
+			"",
+		)
+		if err != nil {
+			return formatCommandError(err)
+		}
+
+		report := api.DetectUsageAnomalies(history, api.UsageAnomalyOptions{
+			Interval:     interval,
+			Method:       score,
+			Dimensions:   dimensions,
+			Baseline:     baseline,
+			Threshold:    usageAnomalyThreshold,
+			Kind:         kind,
+			MinResponses: usageAnomalyMinResponses,
+			HistoryStart: historyStart,
+			From:         window.Start,
+			To:           end,
+		})
+		if !usageAnomalyExitCode || len(report.Anomalies) == 0 {
+			return getFormatter().Success("usage.anomalies", report)
+		}
+		if isHumanOutput() {
+			if err := getFormatter().Success("usage.anomalies", report); err != nil {
+				return err
+			}
+			_, _ = fmt.Fprintln(rootCmd.OutOrStdout())
+		}
+		return getFormatter().ErrorWithDetails(
+			"anomalies_found",
+			fmt.Sprintf("Usage anomalies found: %d spike(s), %d drop(s).", report.Spikes, report.Drops),
+			"",
+			report,
+		)
+	},
+}
+
+// parseAnomalyDimensions reads --by into api dimension names.
+func parseAnomalyDimensions(values []string) ([]string, error) {
+	var dimensions []string
+	seen := map[string]bool{}
+	for _, value := range logFlagValues(values) {
+		dimension := ""
+		switch strings.ToLower(value) {
+		case "key", "api-key", "api_key":
+			dimension = api.DimensionAPIKey
+		case "endpoint", "fqdn":
+			dimension = api.DimensionEndpoint
+		case "method":
+			dimension = api.DimensionMethod
+		default:
+			return nil, getFormatter().Error("validation_error", fmt.Sprintf("Invalid --by %q.", value), "Supported dimensions: key, endpoint, method")
+		}
+		if !seen[dimension] {
+			seen[dimension] = true
+			dimensions = append(dimensions, dimension)
+		}
+	}
+	return dimensions, nil
+}
+
+func init() {
+	usageAnomaliesCmd.Flags().StringVar(&usageAnomalyInterval, "interval", "hour", "Bucket size (hour, day)")
+	usageAnomaliesCmd.Flags().StringVar(&usageFrom, "from", "", "Start of the scanned window: RFC3339, a date, -24h, yesterday, ...")
+	usageAnomaliesCmd.Flags().StringVar(&usageTo, "to", "", "End of the scanned window: RFC3339, a date, now, ...")
+	usageAnomaliesCmd.Flags().StringVar(&usageSince, "since", "", "Scan from this long ago, e.g. 6h or 7d (short for --from -6h)")
+	usageAnomaliesCmd.Flags().StringVar(&usageAnomalyScore, "score", api.AnomalyMAD, "Scoring method (mad, zscore)")
+	usageAnomaliesCmd.Flags().IntVar(&usageAnomalyBaseline, "baseline", 0, "Buckets in the rolling baseline (default 24 hourly or 14 daily)")
+	usageAnomaliesCmd.Flags().Float64Var(&usageAnomalyThreshold, "threshold", 0, "Score at which a bucket is anomalous (default 3.5 for mad, 3 for zscore)")
+	usageAnomaliesCmd.Flags().StringSliceVar(&usageAnomalyBy, "by", []string{"key", "endpoint", "method"}, "Dimensions to scan (key, endpoint, method)")
+	usageAnomaliesCmd.Flags().StringVar(&usageAnomalyKind, "kind", "any", "Anomalies to report (spike, drop, any)")
+	usageAnomaliesCmd.Flags().IntVar(&usageAnomalyMinResponses, "min-responses", 100, "Ignore buckets where neither usage nor baseline reach this many responses")
+	usageAnomaliesCmd.Flags().StringVar(&usageAPIKey, "api-key", "", "Filter by API key value")
+	usageAnomaliesCmd.Flags().StringVar(&usageFQDN, "fqdn", "", "Filter by endpoint hostname (FQDN)")
+	usageAnomaliesCmd.Flags().BoolVar(&usageAnomalyExitCode, "exit-code", false, "Fail with anomalies_found when any anomaly is flagged")
+	usageCmd.AddCommand(usageAnomaliesCmd)
+}
@@ -0,0 +1,22 @@
+package cli
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/dwellir-public/cli/internal/api"
+)
+
+func TestParseAnomalyDimensions(t *testing.T) {
+	got, err := parseAnomalyDimensions([]string{"method", " key", "api-key", "fqdn"})
+	if err != nil {
+		t.Fatalf("parseAnomalyDimensions: %v", err)
+	}
+	want := []string{api.DimensionMethod, api.DimensionAPIKey, api.DimensionEndpoint}
+	if !slices.Equal(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if _, err := parseAnomalyDimensions([]string{"status"}); err == nil {
+		t.Fatal("expected an error for an unknown dimension")
+	}
+}
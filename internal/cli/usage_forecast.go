@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"errors"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dwellir-public/cli/internal/api"
+)
+
+var usageForecastCmd = &cobra.Command{
+	Use:   "forecast",
+	Short: "Project end-of-cycle responses, quota exhaustion, and cost",
+	Long: `Project where the current billing cycle will land from its daily usage so
+far: total responses, when the monthly quota will be used up, and the cost of
+the cycle including overage.
+
+A daily mean, a linear trend, and (from two weeks of history) a linear trend
+with weekday factors are fitted to the complete days of the cycle, and the
+best fit is projected. Low and high values bound an 80% confidence band.
+
+Examples:
+  dwellir usage forecast
+  dwellir usage forecast --json | jq .projected_cost`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := newAPIClient()
+		if err != nil {
+			return getFormatter().Error("not_authenticated", err.Error(), "")
+		}
+
+		accountAPI := api.NewAccountAPI(client)
+		sub, err := accountAPI.Subscription(cmd.Context())
+		if err != nil {
+			return formatCommandError(err)
+		}
+		info, err := accountAPI.Info(cmd.Context())
+		if err != nil {
+			return formatCommandError(err)
+		}
+		discount, err := accountAPI.Discount(cmd.Context())
+		if err != nil {
+			return formatCommandError(err)
+		}
+
+		now := time.Now().UTC()
+		start, end := api.CurrentBillingCycleRange(now, info.CurrentSubscription)
+		history, err := api.NewUsageAPI(client).History(
+			cmd.Context(),
+			"day",
+			start.Format(time.RFC3339),
+			end.Format(time.RFC3339),
+			"",
+			"",
+			"",
+		)
+		if err != nil {
+			return formatCommandError(err)
+		}
+
+		monthlyQuota := 0
+		if sub.MonthlyQuota != nil {
+			monthlyQuota = *sub.MonthlyQuota
+		}
+		forecast, err := api.ForecastUsage(*sub, monthlyQuota, discount, info.CurrentSubscription, now, history)
+		if errors.Is(err, api.ErrForecastNoHistory) {
+			return getFormatter().Error(
+				"insufficient_data",
+				"Not enough usage to forecast: "+err.Error()+".",
+				"Try again once the first day of the cycle has passed.",
+			)
+		}
+		if err != nil {
+			return formatCommandError(err)
+		}
+		return getFormatter().Success("usage.forecast", forecast)
+	},
+}
+
+func init() {
+	usageCmd.AddCommand(usageForecastCmd)
+}
@@ -0,0 +1,199 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dwellir-public/cli/internal/api"
+)
+
+var (
+	usageWatchInterval time.Duration
+	usageWatchWindow   time.Duration
+	usageWatchTop      int
+	usageWatchCount    int
+	usageWatchDuration time.Duration
+)
+
+const (
+	// usageWatchMaxWindow keeps the minute-level history of one refresh small.
+	usageWatchMaxWindow = 3 * time.Hour
+	// usageWatchMaxFailures is how many refreshes in a row may fail before
+	// watch gives up.
+	usageWatchMaxFailures = 3
+)
+
+// sparkTicks are the bar heights of a sparkline, lowest first.
+var sparkTicks = []rune("▁▂▃▄▅▆▇█")
+
+var usageWatchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Live view of request rate, rate limiting, and top endpoints",
+	Long: `Refresh a live view of your organization's usage every --interval: the
+current, average, and peak RPS, rate-limited requests, the busiest endpoints
+and methods, and per-minute sparklines over the trailing --window.
+
+The current RPS is that of the newest complete minute; usage analytics are
+aggregated per minute, so figures trail live traffic by a minute or two.
+
+With --json or --toon one NDJSON snapshot is written per refresh instead.
+The command stops after --count refreshes, after --duration, or on Ctrl-C.
+
+Examples:
+  dwellir usage watch
+  dwellir usage watch --fqdn api-ethereum-mainnet.n.dwellir.com --window 30m
+  dwellir usage watch --interval 30s --json | jq .current_rps`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		switch {
+		case usageWatchInterval < 5*time.Second:
+			return getFormatter().Error("validation_error", "--interval must be at least 5s.", "Usage analytics are aggregated per minute.")
+		case usageWatchWindow < 2*time.Minute || usageWatchWindow > usageWatchMaxWindow:
+			return getFormatter().Error("validation_error", "--window must be between 2m and 3h.", "")
+		case usageWatchTop < 1:
+			return getFormatter().Error("validation_error", "--top must be at least 1.", "")
+		case usageWatchCount < 0 || usageWatchDuration < 0:
+			return getFormatter().Error("validation_error", "--count and --duration must not be negative.", "")
+		}
+
+		client, err := newAPIClient()
+		if err != nil {
+			return getFormatter().Error("not_authenticated", err.Error(), "")
+		}
+		usageAPI := api.NewUsageAPI(client)
+
+		ctx := cmd.Context()
+		if usageWatchDuration > 0 {
+			var cancel func()
+			ctx, cancel = context.WithTimeout(ctx, usageWatchDuration)
+			defer cancel()
+		}
+
+		human := isHumanOutput()
+		redraw := human && stdoutIsTerminal()
+		out := cmd.OutOrStdout()
+		stderr := cmd.ErrOrStderr()
+		encoder := json.NewEncoder(out)
+
+		ticks, failures := 0, 0
+		ticker := time.NewTicker(usageWatchInterval)
+		defer ticker.Stop()
+		for {
+			now := time.Now()
+			snapshot, err := usageAPI.Snapshot(ctx, now.Add(-usageWatchWindow), now, usageAPIKey, usageFQDN, usageWatchTop)
+			switch {
+			case ctx.Err() != nil:
+			case err != nil:
+				failures++
+				if failures >= usageWatchMaxFailures {
+					return formatCommandError(err)
+				}
+				if human && !quiet {
+					_, _ = fmt.Fprintf(stderr, "Refresh failed (%d/%d): %v\n", failures, usageWatchMaxFailures, err)
+				}
+			default:
+				failures = 0
+				ticks++
+				if !human {
+					if err := encoder.Encode(snapshot); err != nil {
+						return err
+					}
+				} else {
+					if redraw {
+						// Move home and clear the screen so each frame replaces the last.
+						_, _ = fmt.Fprint(out, "\033[H\033[2J")
+					} else if ticks > 1 {
+						_, _ = fmt.Fprintln(out)
+					}
+					writeUsageWatchFrame(out, snapshot, usageWatchWindow, usageWatchInterval)
+				}
+				if usageWatchCount > 0 && ticks >= usageWatchCount {
+					return nil
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				// Reaching --duration or pressing Ctrl-C is the normal way to stop.
+				return nil
+			case <-ticker.C:
+			}
+		}
+	},
+}
+
+// writeUsageWatchFrame renders one snapshot of the live view.
+func writeUsageWatchFrame(w io.Writer, s *api.UsageSnapshot, window, interval time.Duration) {
+	loc := displayLocation
+	if loc == nil {
+		loc = time.Local
+	}
+	at := s.Timestamp
+	if parsed, err := time.Parse(time.RFC3339, s.Timestamp); err == nil {
+		at = parsed.In(loc).Format("2006-01-02 15:04:05 MST")
+	}
+	rps := make([]float64, len(s.Series))
+	limited := make([]float64, len(s.Series))
+	peakMinute := 0.0
+	for i, point := range s.Series {
+		rps[i] = point.RPS
+		limited[i] = float64(point.RateLimited)
+		peakMinute = max(peakMinute, point.RPS)
+	}
+
+	_, _ = fmt.Fprintf(w, "Usage at %s (last %s, refreshing every %s)\n\n", at, window, interval)
+	_, _ = fmt.Fprintf(w, "%-14s %10.2f    %-14s %10.2f    %-14s %10.2f\n", "Current RPS", s.CurrentRPS, "Peak RPS", s.PeakRPS, "Average RPS", s.AverageRPS)
+	_, _ = fmt.Fprintf(w, "%-14s %10d    %-14s %10d    %-14s %10d\n\n", "Requests", s.Requests, "Responses", s.Responses, "Rate limited", s.RateLimited)
+	_, _ = fmt.Fprintf(w, "%-14s %s  (busiest minute %.2f RPS)\n", "RPS/minute", sparkline(rps), peakMinute)
+	_, _ = fmt.Fprintf(w, "%-14s %s\n", "Rate limited", sparkline(limited))
+	writeUsageWatchTop(w, "Top endpoints", s.TopEndpoints)
+	writeUsageWatchTop(w, "Top methods", s.TopMethods)
+}
+
+func writeUsageWatchTop(w io.Writer, title string, rows []api.UsageBreakdown) {
+	_, _ = fmt.Fprintf(w, "\n%-48s %12s %12s\n", title, "Requests", "Rate limited")
+	if len(rows) == 0 {
+		_, _ = fmt.Fprintln(w, "(no usage in this window)")
+		return
+	}
+	for _, row := range rows {
+		_, _ = fmt.Fprintf(w, "%-48s %12d %12d\n", truncateLogField(row.Group, 48), row.Requests, row.RateLimited)
+	}
+}
+
+// sparkline draws values as bars scaled to the largest value.
+func sparkline(values []float64) string {
+	if len(values) == 0 {
+		return "-"
+	}
+	highest := 0.0
+	for _, v := range values {
+		highest = max(highest, v)
+	}
+	var b strings.Builder
+	for _, v := range values {
+		tick := 0
+		if highest > 0 && v > 0 {
+			tick = min(int(v/highest*float64(len(sparkTicks)-1)+0.5), len(sparkTicks)-1)
+		}
+		b.WriteRune(sparkTicks[tick])
+	}
+	return b.String()
+}
+
+func init() {
+	usageWatchCmd.Flags().DurationVar(&usageWatchInterval, "interval", 10*time.Second, "How often to refresh")
+	usageWatchCmd.Flags().DurationVar(&usageWatchWindow, "window", 15*time.Minute, "Trailing window of per-minute history to show (max 3h)")
+	usageWatchCmd.Flags().IntVar(&usageWatchTop, "top", 5, "How many endpoints and methods to list")
+	usageWatchCmd.Flags().IntVar(&usageWatchCount, "count", 0, "Stop after this many refreshes (0 = no limit)")
+	usageWatchCmd.Flags().DurationVar(&usageWatchDuration, "duration", 0, "Stop after this long (0 = until Ctrl-C)")
+	usageWatchCmd.Flags().StringVar(&usageAPIKey, "api-key", "", "Filter by API key value")
+	usageWatchCmd.Flags().StringVar(&usageFQDN, "fqdn", "", "Filter by endpoint hostname (FQDN)")
+	usageCmd.AddCommand(usageWatchCmd)
+}
@@ -0,0 +1,20 @@
+package cli
+
+import "testing"
+
+func TestSparkline(t *testing.T) {
+	tests := []struct {
+		values []float64
+		want   string
+	}{
+		{nil, "-"},
+		{[]float64{0, 0, 0}, "▁▁▁"},
+		{[]float64{0, 1, 2, 4, 8}, "▁▂▃▅█"},
+		{[]float64{5, 5}, "██"},
+	}
+	for _, tt := range tests {
+		if got := sparkline(tt.values); got != tt.want {
+			t.Errorf("sparkline(%v) = %q, want %q", tt.values, got, tt.want)
+		}
+	}
+}
@@ -1,11 +1,13 @@
 package cli
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/dwellir-public/cli/internal/api"
+	"github.com/dwellir-public/cli/internal/timeexpr"
 )
 
 type usageWindow struct {
@@ -18,7 +20,10 @@ type usageWindow struct {
 	FormattedEnd   string
 }
 
-func resolveUsageWindow(interval, from, to string) (usageWindow, error) {
+// resolveUsageWindow validates the interval and resolves --from, --to and
+// --since against ref, defaulting to a lookback that suits the interval and
+// ends now.
+func resolveUsageWindow(interval, from, to, since string, ref timeexpr.Reference) (usageWindow, error) {
 	normalized := strings.ToLower(strings.TrimSpace(interval))
 	if normalized == "" {
 		normalized = "hour"
@@ -33,43 +38,23 @@ func resolveUsageWindow(interval, from, to string) (usageWindow, error) {
 		)
 	}
 
-	var start time.Time
-	var end time.Time
+	ref.Now = ref.Now.UTC().Truncate(time.Minute)
+	start, end, err := resolveTimeFlags(ref, from, to, since)
+	if err != nil {
+		return usageWindow{}, err
+	}
+
 	usedDefaults := false
 	defaultLabel := ""
-
-	now := time.Now().UTC().Truncate(time.Minute)
-
-	if strings.TrimSpace(to) == "" {
-		end = now
+	if end.IsZero() {
+		end = ref.Now
 		usedDefaults = true
-	} else {
-		parsed, err := time.Parse(time.RFC3339, to)
-		if err != nil {
-			return usageWindow{}, getFormatter().Error(
-				"validation_error",
-				fmt.Sprintf("Invalid --to timestamp %q.", to),
-				"Use RFC3339 format, e.g. 2026-02-27T23:59:59Z",
-			)
-		}
-		end = parsed.UTC()
 	}
-
-	if strings.TrimSpace(from) == "" {
+	if start.IsZero() {
 		d, label := defaultDurationForInterval(normalized)
 		start = end.Add(-d)
 		defaultLabel = label
 		usedDefaults = true
-	} else {
-		parsed, err := time.Parse(time.RFC3339, from)
-		if err != nil {
-			return usageWindow{}, getFormatter().Error(
-				"validation_error",
-				fmt.Sprintf("Invalid --from timestamp %q.", from),
-				"Use RFC3339 format, e.g. 2026-02-27T00:00:00Z",
-			)
-		}
-		start = parsed.UTC()
 	}
 
 	if !start.Before(end) {
@@ -91,14 +76,19 @@ func resolveUsageWindow(interval, from, to string) (usageWindow, error) {
 	}, nil
 }
 
-func validateUsageLookback(client *api.Client, window usageWindow) error {
-	sub, err := api.NewAccountAPI(client).Subscription()
+func validateUsageLookback(ctx context.Context, client *api.Client, window usageWindow) error {
+	sub, err := api.NewAccountAPI(client).Subscription(ctx)
 	if err != nil {
 		return formatCommandError(err)
 	}
+	return checkUsageLookback(sub.ID, window.Start)
+}
 
-	maxLookback, lookbackLabel, tierName := planLookback(sub.ID)
-	requestedLookback := time.Since(window.Start)
+// checkUsageLookback fails when start lies further back than the plan's
+// usage lookback allows.
+func checkUsageLookback(planID int, start time.Time) error {
+	maxLookback, lookbackLabel, tierName := planLookback(planID)
+	requestedLookback := time.Since(start)
 	if requestedLookback <= maxLookback {
 		return nil
 	}
@@ -115,7 +105,7 @@ func validateUsageLookback(client *api.Client, window usageWindow) error {
 			"Current plan: %s\nAllowed lookback: %s\nRequested from: %s\n%s",
 			tierName,
 			lookbackLabel,
-			window.Start.Format(time.RFC3339),
+			start.Format(time.RFC3339),
 			guidance,
 		),
 	)
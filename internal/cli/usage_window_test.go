@@ -0,0 +1,27 @@
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dwellir-public/cli/internal/timeexpr"
+)
+
+func TestResolveUsageWindowAcceptsTimeExpressions(t *testing.T) {
+	ref := timeexpr.Reference{Now: time.Date(2026, 3, 15, 14, 30, 45, 0, time.UTC)}
+
+	window, err := resolveUsageWindow("hour", "", "", "6h", ref)
+	if err != nil || window.FormattedStart != "2026-03-15T08:30:00Z" || window.FormattedEnd != "2026-03-15T14:30:00Z" || window.DefaultLabel != "" {
+		t.Fatalf("unexpected --since window: %+v (%v)", window, err)
+	}
+	window, err = resolveUsageWindow("day", "yesterday", "today", "", ref)
+	if err != nil || window.FormattedStart != "2026-03-14T00:00:00Z" || window.FormattedEnd != "2026-03-15T00:00:00Z" || window.UsedDefaults {
+		t.Fatalf("unexpected yesterday window: %+v (%v)", window, err)
+	}
+	if _, err := resolveUsageWindow("hour", "-1h", "", "2h", ref); err == nil {
+		t.Fatal("expected --since and --from to conflict")
+	}
+	if _, err := resolveUsageWindow("hour", "today", "yesterday", "", ref); err == nil {
+		t.Fatal("expected an error for --from after --to")
+	}
+}
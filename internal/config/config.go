@@ -6,18 +6,32 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strconv"
+	"time"
+)
+
+const (
+	DefaultRetryMaxAttempts = 3
+	DefaultRetryMaxElapsed  = 30 * time.Second
+	DefaultCatalogCacheTTL  = time.Hour
 )
 
 type Config struct {
-	Output         string `json:"output"`
-	DefaultProfile string `json:"default_profile"`
-	configDir      string
-	outputExplicit bool
+	Output           string `json:"output"`
+	DefaultProfile   string `json:"default_profile"`
+	RetryMaxAttempts string `json:"retry_max_attempts,omitempty"`
+	RetryMaxElapsed  string `json:"retry_max_elapsed,omitempty"`
+	CatalogCacheTTL  string `json:"catalog_cache_ttl,omitempty"`
+	configDir        string
+	outputExplicit   bool
 }
 
 var validKeys = map[string]bool{
-	"output":          true,
-	"default_profile": true,
+	"output":             true,
+	"default_profile":    true,
+	"retry_max_attempts": true,
+	"retry_max_elapsed":  true,
+	"catalog_cache_ttl":  true,
 }
 
 func Load(configDir string) (*Config, error) {
@@ -37,8 +51,11 @@ func Load(configDir string) (*Config, error) {
 	}
 
 	var raw struct {
-		Output         *string `json:"output"`
-		DefaultProfile *string `json:"default_profile"`
+		Output           *string `json:"output"`
+		DefaultProfile   *string `json:"default_profile"`
+		RetryMaxAttempts string  `json:"retry_max_attempts"`
+		RetryMaxElapsed  string  `json:"retry_max_elapsed"`
+		CatalogCacheTTL  string  `json:"catalog_cache_ttl"`
 	}
 	if err := json.Unmarshal(data, &raw); err != nil {
 		return nil, fmt.Errorf("parsing config: %w", err)
@@ -50,13 +67,16 @@ func Load(configDir string) (*Config, error) {
 	if raw.DefaultProfile != nil {
 		cfg.DefaultProfile = *raw.DefaultProfile
 	}
+	cfg.RetryMaxAttempts = raw.RetryMaxAttempts
+	cfg.RetryMaxElapsed = raw.RetryMaxElapsed
+	cfg.CatalogCacheTTL = raw.CatalogCacheTTL
 	cfg.configDir = configDir
 	return cfg, nil
 }
 
 func (c *Config) Set(key, value string) error {
 	if !validKeys[key] {
-		return fmt.Errorf("unknown config key: %s (valid keys: output, default_profile)", key)
+		return fmt.Errorf("unknown config key: %s (valid keys: output, default_profile, retry_max_attempts, retry_max_elapsed, catalog_cache_ttl)", key)
 	}
 	switch key {
 	case "output":
@@ -67,6 +87,24 @@ func (c *Config) Set(key, value string) error {
 		c.outputExplicit = true
 	case "default_profile":
 		c.DefaultProfile = value
+	case "retry_max_attempts":
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			return fmt.Errorf("retry_max_attempts must be a positive integer (1 disables retries)")
+		}
+		c.RetryMaxAttempts = strconv.Itoa(n)
+	case "retry_max_elapsed":
+		d, err := time.ParseDuration(value)
+		if err != nil || d < 0 {
+			return fmt.Errorf("retry_max_elapsed must be a duration such as 30s or 2m")
+		}
+		c.RetryMaxElapsed = d.String()
+	case "catalog_cache_ttl":
+		d, err := time.ParseDuration(value)
+		if err != nil || d < 0 {
+			return fmt.Errorf("catalog_cache_ttl must be a duration such as 1h or 15m (0 always revalidates)")
+		}
+		c.CatalogCacheTTL = d.String()
 	}
 	return c.Save()
 }
@@ -77,6 +115,12 @@ func (c *Config) Get(key string) string {
 		return c.Output
 	case "default_profile":
 		return c.DefaultProfile
+	case "retry_max_attempts":
+		return strconv.Itoa(c.RetryAttempts())
+	case "retry_max_elapsed":
+		return c.RetryElapsed().String()
+	case "catalog_cache_ttl":
+		return c.CatalogTTL().String()
 	default:
 		return ""
 	}
@@ -84,9 +128,45 @@ func (c *Config) Get(key string) string {
 
 func (c *Config) All() map[string]string {
 	return map[string]string{
-		"output":          c.Output,
-		"default_profile": c.DefaultProfile,
+		"output":             c.Output,
+		"default_profile":    c.DefaultProfile,
+		"retry_max_attempts": c.Get("retry_max_attempts"),
+		"retry_max_elapsed":  c.Get("retry_max_elapsed"),
+		"catalog_cache_ttl":  c.Get("catalog_cache_ttl"),
+	}
+}
+
+// RetryAttempts returns the configured API retry attempt budget, falling back
+// to the default when unset or invalid.
+func (c *Config) RetryAttempts() int {
+	if c != nil {
+		if n, err := strconv.Atoi(c.RetryMaxAttempts); err == nil && n >= 1 {
+			return n
+		}
+	}
+	return DefaultRetryMaxAttempts
+}
+
+// RetryElapsed returns the configured API retry time budget, falling back to
+// the default when unset or invalid.
+func (c *Config) RetryElapsed() time.Duration {
+	if c != nil {
+		if d, err := time.ParseDuration(c.RetryMaxElapsed); err == nil && d >= 0 {
+			return d
+		}
+	}
+	return DefaultRetryMaxElapsed
+}
+
+// CatalogTTL returns how long the cached chain catalog is used without
+// revalidation, falling back to the default when unset or invalid.
+func (c *Config) CatalogTTL() time.Duration {
+	if c != nil {
+		if d, err := time.ParseDuration(c.CatalogCacheTTL); err == nil && d >= 0 {
+			return d
+		}
 	}
+	return DefaultCatalogCacheTTL
 }
 
 func (c *Config) Save() error {
@@ -95,10 +175,16 @@ func (c *Config) Save() error {
 	}
 
 	toSave := struct {
-		Output         *string `json:"output,omitempty"`
-		DefaultProfile string  `json:"default_profile"`
+		Output           *string `json:"output,omitempty"`
+		DefaultProfile   string  `json:"default_profile"`
+		RetryMaxAttempts string  `json:"retry_max_attempts,omitempty"`
+		RetryMaxElapsed  string  `json:"retry_max_elapsed,omitempty"`
+		CatalogCacheTTL  string  `json:"catalog_cache_ttl,omitempty"`
 	}{
-		DefaultProfile: c.DefaultProfile,
+		DefaultProfile:   c.DefaultProfile,
+		RetryMaxAttempts: c.RetryMaxAttempts,
+		RetryMaxElapsed:  c.RetryMaxElapsed,
+		CatalogCacheTTL:  c.CatalogCacheTTL,
 	}
 	if c.outputExplicit {
 		output := c.Output
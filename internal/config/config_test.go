@@ -155,3 +155,31 @@ func TestResolveProfileFromDwellirJSON(t *testing.T) {
 		t.Errorf("expected 'work' from .dwellir.json, got '%s'", name)
 	}
 }
+
+func TestRetrySettingsDefaultsAndOverrides(t *testing.T) {
+	dir := t.TempDir()
+	cfg, _ := Load(dir)
+	if cfg.RetryAttempts() != DefaultRetryMaxAttempts || cfg.RetryElapsed() != DefaultRetryMaxElapsed {
+		t.Fatalf("unexpected retry defaults: %d %s", cfg.RetryAttempts(), cfg.RetryElapsed())
+	}
+	if err := cfg.Set("retry_max_attempts", "0"); err == nil {
+		t.Fatal("expected retry_max_attempts=0 to be rejected")
+	}
+	if err := cfg.Set("retry_max_attempts", "5"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := cfg.Set("retry_max_elapsed", "1m"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	reloaded, err := Load(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := reloaded.Get("retry_max_attempts"); got != "5" {
+		t.Fatalf("retry_max_attempts = %q, want 5", got)
+	}
+	if got := reloaded.Get("retry_max_elapsed"); got != "1m0s" {
+		t.Fatalf("retry_max_elapsed = %q, want 1m0s", got)
+	}
+}
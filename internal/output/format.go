@@ -3,6 +3,7 @@ package output
 import (
 	"errors"
 	"io"
+	"time"
 )
 
 // Response is the JSON envelope for all CLI output.
@@ -14,21 +15,46 @@ type Response struct {
 }
 
 type ErrorBody struct {
-	Code    string `json:"code"`
-	Message string `json:"message"`
-	Help    string `json:"help,omitempty"`
+	Code    string      `json:"code"`
+	Message string      `json:"message"`
+	Help    string      `json:"help,omitempty"`
+	Details interface{} `json:"details,omitempty"`
 }
 
 type Meta struct {
-	Command   string `json:"command"`
-	Timestamp string `json:"timestamp"`
-	Profile   string `json:"profile,omitempty"`
+	Command   string     `json:"command"`
+	Timestamp string     `json:"timestamp"`
+	Profile   string     `json:"profile,omitempty"`
+	Retries   int        `json:"retries,omitempty"`
+	Cache     *CacheMeta `json:"cache,omitempty"`
+	Page      *PageMeta  `json:"page,omitempty"`
 }
 
+// PageMeta describes where a paginated listing stopped; pass NextCursor back
+// with --cursor to continue.
+type PageMeta struct {
+	NextCursor string `json:"next_cursor,omitempty"`
+	HasMore    bool   `json:"has_more"`
+}
+
+// CacheMeta describes cached data a command was answered from.
+type CacheMeta struct {
+	Source     string `json:"source"`
+	FetchedAt  string `json:"fetched_at"`
+	AgeSeconds int64  `json:"age_seconds"`
+}
+
+// MetaSource supplies run-level metadata (such as API retry counts) that is
+// merged into the envelope when a structured response is rendered.
+type MetaSource func(meta *Meta)
+
 // Formatter defines how CLI output is rendered.
 type Formatter interface {
 	Success(command string, data interface{}) error
 	Error(code string, message string, help string) error
+	// ErrorWithDetails is like Error but attaches structured details to the
+	// error envelope. Human output renders only the message and help.
+	ErrorWithDetails(code string, message string, help string, details interface{}) error
 	Write(data interface{}) error
 }
 
@@ -53,11 +79,27 @@ func IsRenderedError(err error) bool {
 
 // New returns a Formatter based on the format string ("json" or "human").
 func New(format string, w io.Writer) Formatter {
+	return NewWithMeta(format, w, nil)
+}
+
+// NewWithMeta is like New but decorates structured envelopes with metadata from source.
+func NewWithMeta(format string, w io.Writer, source MetaSource) Formatter {
 	if format == "json" {
-		return NewJSONFormatter(w)
+		return &JSONFormatter{w: w, meta: source}
 	}
 	if format == "toon" {
-		return NewTOONFormatter(w)
+		return &TOONFormatter{w: w, meta: source}
 	}
 	return NewHumanFormatter(w)
 }
+
+func buildMeta(command string, source MetaSource) *Meta {
+	meta := &Meta{
+		Command:   command,
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+	}
+	if source != nil {
+		source(meta)
+	}
+	return meta
+}
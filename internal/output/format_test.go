@@ -37,6 +37,24 @@ func TestJSONError(t *testing.T) {
 	}
 }
 
+func TestJSONErrorWithDetails(t *testing.T) {
+	var buf bytes.Buffer
+	f := NewJSONFormatter(&buf)
+	err := f.ErrorWithDetails("identity_mismatch", "Node serves a different chain.", "", []map[string]string{{"status": "mismatch"}})
+	if !IsRenderedError(err) {
+		t.Fatalf("expected rendered error, got %v", err)
+	}
+	if !bytes.Contains(buf.Bytes(), []byte(`"details":[{"status":"mismatch"}]`)) {
+		t.Errorf("expected details in error envelope, got: %s", buf.String())
+	}
+
+	buf.Reset()
+	_ = f.Error("not_found", "Missing.", "")
+	if bytes.Contains(buf.Bytes(), []byte(`"details"`)) {
+		t.Errorf("expected details to be omitted, got: %s", buf.String())
+	}
+}
+
 func TestHumanSuccess(t *testing.T) {
 	var buf bytes.Buffer
 	f := NewHumanFormatter(&buf)
@@ -323,3 +341,14 @@ func TestTOONSuccess(t *testing.T) {
 		t.Fatalf("expected TOON (non-JSON) output, got:\n%s", got)
 	}
 }
+
+func TestJSONSuccessIncludesMetaSource(t *testing.T) {
+	var buf bytes.Buffer
+	f := NewWithMeta("json", &buf, func(meta *Meta) { meta.Retries = 2 })
+	if err := f.Success("keys.list", []string{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.Contains(buf.Bytes(), []byte(`"retries":2`)) {
+		t.Errorf("expected retries in meta, got: %s", buf.String())
+	}
+}
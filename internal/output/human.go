@@ -1,6 +1,7 @@
 package output
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -16,11 +17,14 @@ import (
 	"golang.org/x/term"
 
 	"github.com/dwellir-public/cli/internal/api"
+	"github.com/dwellir-public/cli/internal/proxy"
+	"github.com/dwellir-public/cli/internal/rpc"
 )
 
 type HumanFormatter struct {
 	w          io.Writer
 	mdRenderer *glamour.TermRenderer
+	loc        *time.Location
 }
 
 type endpointTableRow struct {
@@ -37,6 +41,25 @@ func NewHumanFormatter(w io.Writer) *HumanFormatter {
 	return &HumanFormatter{w: w}
 }
 
+// SetLocation shows RFC3339 timestamps in usage and logs output in loc
+// rather than as returned by the API.
+func (f *HumanFormatter) SetLocation(loc *time.Location) {
+	f.loc = loc
+}
+
+// formatTime renders an RFC3339 timestamp in the formatter's location,
+// leaving it unchanged when no location is set or it does not parse.
+func (f *HumanFormatter) formatTime(value string) string {
+	if f.loc == nil {
+		return value
+	}
+	parsed, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return value
+	}
+	return parsed.In(f.loc).Format(time.RFC3339)
+}
+
 func (f *HumanFormatter) Success(command string, data interface{}) error {
 	switch command {
 	case "keys.list":
@@ -55,14 +78,49 @@ func (f *HumanFormatter) Success(command string, data interface{}) error {
 		return f.writeUsageBreakdown(data)
 	case "usage.costs":
 		return f.writeUsageCosts(data)
+	case "usage.forecast":
+		return f.writeUsageForecast(data)
+	case "usage.anomalies":
+		return f.writeUsageAnomalies(data)
 	case "logs.errors":
 		return f.writeLogsErrors(data)
 	case "logs.stats":
 		return f.writeLogsStats(data)
 	case "logs.facets":
 		return f.writeLogsFacets(data)
+	case "logs.trace":
+		return f.writeLogTrace(data)
+	case "logs.clusters":
+		return f.writeLogClusters(data)
+	case "logs.latency":
+		return f.writeLogLatency(data)
 	case "endpoints.list", "endpoints.search", "endpoints.get":
 		return f.writeEndpoints(data)
+	case "rpc.call":
+		return f.writeRPCCall(data)
+	case "rpc.batch":
+		return f.writeRPCBatch(data)
+	case "endpoints.probe":
+		return f.writeEndpointProbe(data)
+	case "endpoints.verify":
+		return f.writeEndpointVerify(data)
+	case "endpoints.diff":
+		return f.writeEndpointDiff(data)
+	case "endpoints.export", "logs.export":
+		// Exports are rendered as the raw snippet so they can be pasted or piped.
+		if text, ok := data.(interface{ Text() string }); ok {
+			_, err := io.WriteString(f.w, text.Text())
+			return err
+		}
+		return f.Write(data)
+	case "proxy":
+		return f.writeProxySummary(data)
+	case "gateway":
+		return f.writeGatewaySummary(data)
+	case "cache-serve":
+		return f.writeCacheSummary(data)
+	case "bench":
+		return f.writeBenchResult(data)
 	case "account.info":
 		return f.writeAccountInfo(data)
 	case "account.subscription":
@@ -182,6 +240,10 @@ func (f *HumanFormatter) Error(code string, message string, help string) error {
 	return &RenderedError{Code: code, Message: message}
 }
 
+func (f *HumanFormatter) ErrorWithDetails(code string, message string, help string, details interface{}) error {
+	return f.Error(code, message, help)
+}
+
 func (f *HumanFormatter) Write(data interface{}) error {
 	switch v := data.(type) {
 	case map[string]string:
@@ -281,10 +343,10 @@ func (f *HumanFormatter) writeUsageSummary(data interface{}) error {
 		{"Rate limited", fmt.Sprintf("%d", summary.RateLimited)},
 	}
 	if summary.BillingStart != "" {
-		rows = append(rows, [2]string{"Billing start", summary.BillingStart})
+		rows = append(rows, [2]string{"Billing start", f.formatTime(summary.BillingStart)})
 	}
 	if summary.BillingEnd != "" {
-		rows = append(rows, [2]string{"Billing end", summary.BillingEnd})
+		rows = append(rows, [2]string{"Billing end", f.formatTime(summary.BillingEnd)})
 	}
 	return f.renderKeyValueRows(rows)
 }
@@ -305,7 +367,7 @@ func (f *HumanFormatter) writeUsageHistory(data interface{}) error {
 	tw := table.NewWriter()
 	tw.AppendHeader(table.Row{"Timestamp", "Requests", "Responses"})
 	for _, h := range history {
-		tw.AppendRow(f.formatTableRow(table.Row{h.Timestamp, h.Requests, h.Responses}))
+		tw.AppendRow(f.formatTableRow(table.Row{f.formatTime(h.Timestamp), h.Requests, h.Responses}))
 	}
 	return f.renderTable(tw)
 }
@@ -322,7 +384,7 @@ func (f *HumanFormatter) writeUsageRPS(data interface{}) error {
 	tw := table.NewWriter()
 	tw.AppendHeader(table.Row{"Timestamp", "RPS"})
 	for _, point := range points {
-		tw.AppendRow(f.formatTableRow(table.Row{point.Timestamp, fmt.Sprintf("%.2f", point.RPS)}))
+		tw.AppendRow(f.formatTableRow(table.Row{f.formatTime(point.Timestamp), fmt.Sprintf("%.2f", point.RPS)}))
 	}
 	return f.renderTable(tw)
 }
@@ -367,8 +429,8 @@ func (f *HumanFormatter) writeUsageCosts(data interface{}) error {
 
 	if err := f.renderKeyValueRows([][2]string{
 		{"Plan", report.PlanName},
-		{"Interval start", report.IntervalStart},
-		{"Interval end", report.IntervalEnd},
+		{"Interval start", f.formatTime(report.IntervalStart)},
+		{"Interval end", f.formatTime(report.IntervalEnd)},
 		{"Total responses", fmt.Sprintf("%d", report.TotalResponses)},
 		{"Total cost (USD)", fmt.Sprintf("$%.2f", report.TotalCost)},
 	}); err != nil {
@@ -397,8 +459,8 @@ func (f *HumanFormatter) writeUsageCosts(data interface{}) error {
 		tw.AppendHeader(table.Row{"Segment Start", "Segment End", "Responses", "Cost (USD)", "Type"})
 		for _, segment := range report.Segments {
 			tw.AppendRow(f.formatTableRow(table.Row{
-				segment.Start,
-				segment.End,
+				f.formatTime(segment.Start),
+				f.formatTime(segment.End),
 				segment.Responses,
 				fmt.Sprintf("$%.2f", segment.Cost),
 				segment.CostType,
@@ -410,6 +472,134 @@ func (f *HumanFormatter) writeUsageCosts(data interface{}) error {
 	return nil
 }
 
+func (f *HumanFormatter) writeUsageForecast(data interface{}) error {
+	forecast, ok := data.(api.UsageForecast)
+	if !ok {
+		return f.Write(data)
+	}
+
+	band := func(r api.ForecastResponses) string {
+		return fmt.Sprintf("%s (%s – %s)", formatInt64(int64(r.Expected)), formatInt64(int64(r.Low)), formatInt64(int64(r.High)))
+	}
+	rows := [][2]string{
+		{"Plan", forecast.PlanName},
+		{"Cycle", f.formatTime(forecast.CycleStart) + " to " + f.formatTime(forecast.CycleEnd)},
+		{"Days observed", fmt.Sprintf("%d of %d", forecast.DaysObserved, forecast.DaysInCycle)},
+		{"Model", forecast.Model},
+		{"Responses to date", formatInt64(int64(forecast.ResponsesToDate))},
+		{"Projected responses", band(forecast.Projected)},
+	}
+	if forecast.MonthlyQuota > 0 {
+		rows = append(rows,
+			[2]string{"Monthly quota", formatInt64(int64(forecast.MonthlyQuota))},
+			[2]string{"Projected overage", band(forecast.Overage)},
+		)
+		exhausted := "Not projected this cycle"
+		if e := forecast.QuotaExhaustion; e != nil {
+			switch {
+			case e.AlreadyExceeded:
+				exhausted = "Already exceeded"
+			case e.Expected == "":
+				exhausted = fmt.Sprintf("Not expected (possibly from %s)", f.formatTime(e.Earliest))
+			case e.Latest == "":
+				exhausted = fmt.Sprintf("%s (%s – after cycle end)", f.formatTime(e.Expected), f.formatTime(e.Earliest))
+			default:
+				exhausted = fmt.Sprintf("%s (%s – %s)", f.formatTime(e.Expected), f.formatTime(e.Earliest), f.formatTime(e.Latest))
+			}
+		}
+		rows = append(rows, [2]string{"Quota exhausted", exhausted})
+	}
+	if forecast.Cost != nil {
+		rows = append(rows, [2]string{"Projected cost (USD)", fmt.Sprintf("$%.2f ($%.2f – $%.2f)", forecast.Cost.Expected, forecast.Cost.Low, forecast.Cost.High)})
+	} else if !forecast.CostSupported {
+		rows = append(rows, [2]string{"Projected cost (USD)", "Not usage-based on this plan"})
+	}
+	if err := f.renderKeyValueRows(rows); err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprintln(f.w); err != nil {
+		return err
+	}
+	tw := table.NewWriter()
+	tw.AppendHeader(table.Row{"Date", "Responses", "Projected", "Cumulative", "Low", "High"})
+	for _, day := range forecast.Daily {
+		responses, projected := "-", "-"
+		if day.Responses != nil {
+			responses = formatInt64(int64(*day.Responses))
+		}
+		if day.Projected != nil {
+			projected = formatInt64(int64(*day.Projected))
+		}
+		tw.AppendRow(f.formatTableRow(table.Row{
+			day.Date,
+			responses,
+			projected,
+			formatInt64(int64(day.Cumulative)),
+			formatInt64(int64(day.CumulativeLow)),
+			formatInt64(int64(day.CumulativeHigh)),
+		}))
+	}
+	if err := f.renderTable(tw); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(f.w, "\nRanges are %.0f%% confidence bands.\n", forecast.Confidence*100)
+	return err
+}
+
+func (f *HumanFormatter) writeUsageAnomalies(data interface{}) error {
+	report, ok := data.(api.UsageAnomalyReport)
+	if !ok {
+		return f.Write(data)
+	}
+	scanned := fmt.Sprintf("%s to %s, %d series by %s against the previous %d %s(s), threshold %.1f",
+		f.formatTime(report.WindowStart), f.formatTime(report.WindowEnd), report.Series, report.Method, report.Baseline, report.Interval, report.Threshold)
+	if len(report.Anomalies) == 0 {
+		_, err := fmt.Fprintf(f.w, "No usage anomalies found (%s).\n", scanned)
+		return err
+	}
+
+	tw := table.NewWriter()
+	tw.AppendHeader(table.Row{"Start", "End", "Dimension", "Group", "Kind", "Responses", "Baseline", "Change", "Score", "Involved"})
+	for _, a := range report.Anomalies {
+		change := "new"
+		if a.Change != nil {
+			change = fmt.Sprintf("%+.0f%%", *a.Change*100)
+		}
+		var involved []string
+		for _, part := range []struct {
+			dimension string
+			label     string
+			groups    []string
+		}{
+			{api.DimensionAPIKey, "keys", a.APIKeys},
+			{api.DimensionEndpoint, "endpoints", a.Endpoints},
+			{api.DimensionMethod, "methods", a.Methods},
+		} {
+			if part.dimension != a.Dimension && len(part.groups) > 0 {
+				involved = append(involved, part.label+": "+strings.Join(part.groups, ", "))
+			}
+		}
+		tw.AppendRow(f.formatTableRow(table.Row{
+			f.formatTime(a.WindowStart),
+			f.formatTime(a.WindowEnd),
+			a.Dimension,
+			truncateWithEllipsis(a.Group, 40),
+			a.Kind,
+			formatInt64(int64(a.Responses)),
+			formatInt64(int64(a.Expected)),
+			change,
+			fmt.Sprintf("%.1f", a.Score),
+			truncateWithEllipsis(valueOrNone(strings.Join(involved, "; ")), 60),
+		}))
+	}
+	if err := f.renderTable(tw); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(f.w, "\n%d spike(s) and %d drop(s) in %s.\n", report.Spikes, report.Drops, scanned)
+	return err
+}
+
 func (f *HumanFormatter) writeLogsErrors(data interface{}) error {
 	logs, ok := data.([]api.ErrorLog)
 	if !ok {
@@ -423,7 +613,7 @@ func (f *HumanFormatter) writeLogsErrors(data interface{}) error {
 	tw.AppendHeader(table.Row{"Timestamp", "Status", "RPC Methods", "Endpoint", "Message"})
 	for _, row := range logs {
 		tw.AppendRow(f.formatTableRow(table.Row{
-			row.Timestamp,
+			f.formatTime(row.Timestamp),
 			fmt.Sprintf("%d %s", row.StatusCode, row.StatusLabel),
 			row.RPCMethods,
 			row.FQDN,
@@ -433,6 +623,138 @@ func (f *HumanFormatter) writeLogsErrors(data interface{}) error {
 	return f.renderTable(tw)
 }
 
+func (f *HumanFormatter) writeLogTrace(data interface{}) error {
+	trace, ok := data.(*api.RequestTrace)
+	if !ok || trace == nil {
+		return f.Write(data)
+	}
+	gateway := fmt.Sprintf("%d ms", trace.GatewayLatencyMs)
+	if trace.TotalLatencyMs > 0 {
+		gateway += fmt.Sprintf(" (%.0f%% of total)", float64(trace.GatewayLatencyMs)/float64(trace.TotalLatencyMs)*100)
+	}
+	return f.renderKeyValueRows([][2]string{
+		{"Request ID", trace.RequestID},
+		{"Timestamp", f.formatTime(trace.Timestamp)},
+		{"Status", strings.TrimSpace(fmt.Sprintf("%d %s", trace.StatusCode, trace.StatusLabel))},
+		{"Endpoint", valueOrNone(trace.FQDN)},
+		{"API key", valueOrNone(trace.APIKey)},
+		{"RPC methods", valueOrNone(trace.RPCMethods)},
+		{"HTTP method", valueOrNone(trace.HTTPMethod)},
+		{"Total latency", fmt.Sprintf("%d ms", trace.TotalLatencyMs)},
+		{"Backend latency", fmt.Sprintf("%d ms", trace.BackendLatencyMs)},
+		{"Gateway overhead", gateway},
+		{"Error", valueOrNone(trace.ErrorMessage)},
+	})
+}
+
+func (f *HumanFormatter) writeLogClusters(data interface{}) error {
+	report, ok := data.(api.ErrorClusterReport)
+	if !ok {
+		return f.Write(data)
+	}
+	if len(report.Clusters) == 0 {
+		_, err := fmt.Fprintln(f.w, "No error logs found.")
+		return err
+	}
+	tw := table.NewWriter()
+	tw.AppendHeader(table.Row{"Count", "Template", "First Seen", "Last Seen", "Status", "Endpoints", "RPC Methods", "API Keys"})
+	for _, c := range report.Clusters {
+		template := c.Template
+		if template == "" {
+			template = "(no message)"
+		}
+		tw.AppendRow(f.formatTableRow(table.Row{
+			formatInt64(int64(c.Count)),
+			truncateWithEllipsis(template, 70),
+			valueOrNone(f.formatTime(c.FirstSeen)),
+			valueOrNone(f.formatTime(c.LastSeen)),
+			summarizeFacets(c.StatusCodes, 2),
+			summarizeFacets(c.FQDNs, 2),
+			summarizeFacets(c.RPCMethods, 2),
+			summarizeFacets(c.APIKeys, 2),
+		}))
+	}
+	if err := f.renderTable(tw); err != nil {
+		return err
+	}
+	note := fmt.Sprintf("%s error log(s) in %s cluster(s)", formatInt64(int64(report.Scanned)), formatInt64(int64(report.TotalClusters)))
+	if len(report.Clusters) < report.TotalClusters {
+		note += fmt.Sprintf(", largest %d shown", len(report.Clusters))
+	}
+	if report.Truncated {
+		note += "; only the most recent logs were read (raise --max-rows for more)"
+	}
+	_, err := fmt.Fprintln(f.w, "\n"+note+".")
+	return err
+}
+
+func (f *HumanFormatter) writeLogLatency(data interface{}) error {
+	report, ok := data.(api.ErrorLatencyReport)
+	if !ok {
+		return f.Write(data)
+	}
+	if len(report.Groups) == 0 {
+		_, err := fmt.Fprintln(f.w, "No error logs found.")
+		return err
+	}
+	header := table.Row{"Group", "Count", "p50", "p90", "p99", "Max", "Backend p50", "Gateway p50", "Gateway Share"}
+	if report.Interval != "" {
+		header = append(table.Row{"Bucket"}, header...)
+	}
+	tw := table.NewWriter()
+	tw.AppendHeader(header)
+	for _, g := range report.Groups {
+		share := fmt.Sprintf("%.0f%%", g.GatewayShare*100)
+		if g.GatewayDominant {
+			share += " (gateway)"
+		}
+		row := table.Row{
+			truncateWithEllipsis(g.Group, 50),
+			formatInt64(int64(g.Count)),
+			formatLatencyMs(g.P50Ms),
+			formatLatencyMs(g.P90Ms),
+			formatLatencyMs(g.P99Ms),
+			formatLatencyMs(g.MaxMs),
+			formatLatencyMs(g.BackendP50Ms),
+			formatLatencyMs(g.GatewayP50Ms),
+			share,
+		}
+		if report.Interval != "" {
+			row = append(table.Row{f.formatTime(g.Bucket)}, row...)
+		}
+		tw.AppendRow(f.formatTableRow(row))
+	}
+	if err := f.renderTable(tw); err != nil {
+		return err
+	}
+	note := fmt.Sprintf("Latency of %s error log(s); successful requests are not logged", formatInt64(int64(report.Scanned)))
+	if report.Truncated {
+		note += "; only the most recent logs were read (raise --max-rows for more)"
+	}
+	_, err := fmt.Fprintln(f.w, "\n"+note+".")
+	return err
+}
+
+func formatLatencyMs(ms float64) string {
+	return fmt.Sprintf("%.0f ms", ms)
+}
+
+// summarizeFacets lists the top facet values with their counts.
+func summarizeFacets(entries []api.FacetEntry, limit int) string {
+	if len(entries) == 0 {
+		return "-"
+	}
+	parts := make([]string, 0, limit+1)
+	for i, entry := range entries {
+		if i == limit {
+			parts = append(parts, fmt.Sprintf("+%d more", len(entries)-limit))
+			break
+		}
+		parts = append(parts, fmt.Sprintf("%s (%d)", truncateWithEllipsis(entry.Value, 40), entry.Count))
+	}
+	return strings.Join(parts, ", ")
+}
+
 func (f *HumanFormatter) writeLogsStats(data interface{}) error {
 	stats, ok := data.([]api.ErrorStats)
 	if !ok {
@@ -561,6 +883,422 @@ func (f *HumanFormatter) writeEndpoints(data interface{}) error {
 	return f.renderTable(tw)
 }
 
+func (f *HumanFormatter) writeRPCCall(data interface{}) error {
+	summary, ok := data.(rpc.CallSummary)
+	if !ok {
+		return f.Write(data)
+	}
+	if err := f.renderKeyValueRows([][2]string{
+		{"Chain", summary.Chain},
+		{"Network", summary.Network},
+		{"Endpoint", summary.Endpoint},
+		{"Method", summary.Method},
+		{"HTTP status", fmt.Sprintf("%d", summary.HTTPStatus)},
+		{"Latency", fmt.Sprintf("%.1f ms", summary.LatencyMs)},
+	}); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(f.w); err != nil {
+		return err
+	}
+	return f.writeRawJSON(summary.Result)
+}
+
+func (f *HumanFormatter) writeRPCBatch(data interface{}) error {
+	summary, ok := data.(rpc.BatchSummary)
+	if !ok {
+		return f.Write(data)
+	}
+	if err := f.renderKeyValueRows([][2]string{
+		{"Chain", summary.Chain},
+		{"Network", summary.Network},
+		{"Endpoint", summary.Endpoint},
+		{"Mode", summary.Mode},
+		{"Requests", fmt.Sprintf("%d (%d ok, %d failed)", summary.Requests, summary.Succeeded, summary.Failed)},
+		{"Total", fmt.Sprintf("%.1f ms", summary.TotalMs)},
+	}); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(f.w); err != nil {
+		return err
+	}
+
+	tw := table.NewWriter()
+	tw.AppendHeader(table.Row{"ID", "Method", "Status", "HTTP", "Latency", "Result"})
+	for _, item := range summary.Items {
+		status := "ok"
+		detail := string(item.Result)
+		switch {
+		case item.TransportError != "":
+			status = "failed"
+			detail = item.TransportError
+		case item.Error != nil:
+			status = "rpc error"
+			detail = fmt.Sprintf("%d: %s", item.Error.Code, item.Error.Message)
+		}
+		httpStatus := ""
+		if item.HTTPStatus > 0 {
+			httpStatus = strconv.Itoa(item.HTTPStatus)
+		}
+		tw.AppendRow(f.formatTableRow(table.Row{
+			string(item.ID),
+			item.Method,
+			status,
+			httpStatus,
+			fmt.Sprintf("%.1f ms", item.LatencyMs),
+			truncateWithEllipsis(detail, 60),
+		}))
+	}
+	return f.renderTable(tw)
+}
+
+func (f *HumanFormatter) writeEndpointProbe(data interface{}) error {
+	results, ok := data.([]rpc.ProbeResult)
+	if !ok {
+		return f.Write(data)
+	}
+	if len(results) == 0 {
+		_, err := fmt.Fprintln(f.w, "No endpoints probed.")
+		return err
+	}
+	tw := table.NewWriter()
+	tw.AppendHeader(table.Row{"Chain", "Network", "Node Type", "Protocol", "p50", "p95", "Success", "Head Block", "Error"})
+	for _, r := range results {
+		p50, p95, head := "-", "-", "-"
+		if r.Succeeded > 0 {
+			p50 = fmt.Sprintf("%.0f ms", r.P50Ms)
+			p95 = fmt.Sprintf("%.0f ms", r.P95Ms)
+		}
+		if r.HeadBlock > 0 {
+			head = strconv.FormatUint(r.HeadBlock, 10)
+		}
+		tw.AppendRow(f.formatTableRow(table.Row{
+			r.Chain,
+			r.Network,
+			r.NodeType,
+			r.Protocol,
+			p50,
+			p95,
+			fmt.Sprintf("%d/%d", r.Succeeded, r.Samples),
+			head,
+			r.ErrorClass,
+		}))
+	}
+	return f.renderTable(tw)
+}
+
+func (f *HumanFormatter) writeEndpointVerify(data interface{}) error {
+	results, ok := data.([]rpc.VerifyResult)
+	if !ok {
+		return f.Write(data)
+	}
+	if len(results) == 0 {
+		_, err := fmt.Fprintln(f.w, "No endpoints verified.")
+		return err
+	}
+	tw := table.NewWriter()
+	tw.AppendHeader(table.Row{"Chain", "Network", "Node Type", "Protocol", "Status", "Expected", "Observed"})
+	for _, r := range results {
+		observed := r.Observed
+		if r.ObservedAs != "" && r.Status != "match" {
+			observed += " (" + r.ObservedAs + ")"
+		}
+		if r.Status == "error" {
+			observed = r.ErrorClass
+		}
+		tw.AppendRow(f.formatTableRow(table.Row{
+			r.Chain,
+			r.Network,
+			r.NodeType,
+			r.Protocol,
+			r.Status,
+			r.Expected,
+			observed,
+		}))
+	}
+	return f.renderTable(tw)
+}
+
+func (f *HumanFormatter) writeProxySummary(data interface{}) error {
+	summary, ok := data.(proxy.Summary)
+	if !ok {
+		return f.Write(data)
+	}
+	rows := [][2]string{
+		{"Chain", summary.Chain},
+		{"Network", summary.Network},
+		{"Listen", summary.Listen},
+	}
+	if summary.HTTPEndpoint != "" {
+		rows = append(rows, [2]string{"HTTP Upstream", summary.HTTPEndpoint})
+	}
+	if summary.WSEndpoint != "" {
+		rows = append(rows, [2]string{"WS Upstream", summary.WSEndpoint})
+	}
+	rows = append(rows, [2]string{"Uptime", (time.Duration(summary.UptimeSeconds * float64(time.Second))).Round(time.Second).String()})
+	if err := f.renderKeyValueRows(rows); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(f.w); err != nil {
+		return err
+	}
+	return f.writeProxyMethods(summary.Methods)
+}
+
+func (f *HumanFormatter) writeGatewaySummary(data interface{}) error {
+	summary, ok := data.(proxy.GatewaySummary)
+	if !ok {
+		return f.Write(data)
+	}
+	if err := f.renderKeyValueRows([][2]string{
+		{"Chain", summary.Chain},
+		{"Network", summary.Network},
+		{"Listen", summary.Listen},
+		{"Uptime", (time.Duration(summary.UptimeSeconds * float64(time.Second))).Round(time.Second).String()},
+	}); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(f.w); err != nil {
+		return err
+	}
+
+	tw := table.NewWriter()
+	tw.AppendHeader(table.Row{"Node", "Type", "Healthy", "Head", "Latency", "Routed", "Failovers", "Last Error"})
+	for _, node := range summary.Nodes {
+		healthy := "yes"
+		if !node.Healthy {
+			healthy = "no"
+		}
+		head, latency := "-", "-"
+		if node.HeadBlock > 0 {
+			head = strconv.FormatUint(node.HeadBlock, 10)
+		}
+		if node.LatencyMs > 0 {
+			latency = fmt.Sprintf("%.0f ms", node.LatencyMs)
+		}
+		reasons := make([]string, 0, len(node.Routed))
+		for reason, n := range node.Routed {
+			reasons = append(reasons, fmt.Sprintf("%s: %d", reason, n))
+		}
+		sort.Strings(reasons)
+		tw.AppendRow(f.formatTableRow(table.Row{
+			node.Name,
+			valueOrNone(node.NodeType),
+			healthy,
+			head,
+			latency,
+			valueOrNone(strings.Join(reasons, ", ")),
+			node.Failovers,
+			truncateWithEllipsis(node.LastError, 50),
+		}))
+	}
+	if err := f.renderTable(tw); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(f.w); err != nil {
+		return err
+	}
+	return f.writeProxyMethods(summary.Methods)
+}
+
+func (f *HumanFormatter) writeCacheSummary(data interface{}) error {
+	summary, ok := data.(proxy.CacheSummary)
+	if !ok {
+		return f.Write(data)
+	}
+	saved := formatInt64(summary.SavedRequests)
+	if summary.Overhead > 0 {
+		saved += fmt.Sprintf(" (%s hits, %s finality lookups)", formatUint64(summary.Hits), formatUint64(summary.Overhead))
+	}
+	rows := [][2]string{
+		{"Chain", summary.Chain},
+		{"Network", summary.Network},
+		{"Listen", summary.Listen},
+		{"Store", summary.Store},
+		{"Uptime", (time.Duration(summary.UptimeSeconds * float64(time.Second))).Round(time.Second).String()},
+		{"Entries", fmt.Sprintf("%s (%.1f MiB)", formatInt64(int64(summary.Entries)), float64(summary.SizeBytes)/(1<<20))},
+		{"Hit ratio", fmt.Sprintf("%.1f%% (%s hits, %s misses, %s uncacheable)", summary.HitRatio*100, formatUint64(summary.Hits), formatUint64(summary.Misses), formatUint64(summary.Uncacheable))},
+		{"Requests saved", saved},
+	}
+	if summary.CycleRequests != nil {
+		value := formatInt64(int64(*summary.CycleRequests))
+		if summary.SavedPctOfCycle != nil {
+			value += fmt.Sprintf(" (cache saved %.2f%%)", *summary.SavedPctOfCycle)
+		}
+		rows = append(rows, [2]string{"Cycle requests", value})
+	}
+	if summary.MonthlyQuota != nil {
+		value := formatInt64(int64(*summary.MonthlyQuota))
+		if summary.SavedPctOfQuota != nil {
+			value += fmt.Sprintf(" (cache saved %.2f%%)", *summary.SavedPctOfQuota)
+		}
+		rows = append(rows, [2]string{"Monthly quota", value})
+	}
+	if err := f.renderKeyValueRows(rows); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(f.w); err != nil {
+		return err
+	}
+	if len(summary.CacheByMethod) > 0 {
+		tw := table.NewWriter()
+		tw.AppendHeader(table.Row{"Method", "Hits", "Misses", "Uncacheable"})
+		for _, m := range summary.CacheByMethod {
+			tw.AppendRow(f.formatTableRow(table.Row{m.Method, formatUint64(m.Hits), formatUint64(m.Misses), formatUint64(m.Uncacheable)}))
+		}
+		if err := f.renderTable(tw); err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintln(f.w); err != nil {
+			return err
+		}
+	}
+	return f.writeProxyMethods(summary.Methods)
+}
+
+func (f *HumanFormatter) writeBenchResult(data interface{}) error {
+	result, ok := data.(rpc.BenchResult)
+	if !ok {
+		return f.Write(data)
+	}
+	sent := formatInt64(int64(result.Sent))
+	if result.Skipped > 0 {
+		sent += fmt.Sprintf(" (%s skipped at --max-in-flight)", formatInt64(int64(result.Skipped)))
+	}
+	latency := "-"
+	if result.Succeeded > 0 {
+		latency = fmt.Sprintf("p50 %.0f ms, p90 %.0f ms, p99 %.0f ms, max %.0f ms", result.P50Ms, result.P90Ms, result.P99Ms, result.MaxMs)
+	}
+	limits := "unknown"
+	if result.RateLimit > 0 || result.BurstLimit > 0 {
+		limits = fmt.Sprintf("%d req/s (burst %d)", result.RateLimit, result.BurstLimit)
+	}
+	rows := [][2]string{
+		{"Chain", result.Chain},
+		{"Network", result.Network},
+		{"Endpoint", result.Endpoint},
+		{"Method", result.Method},
+		{"Target", fmt.Sprintf("%d req/s for %s", result.TargetRPS, time.Duration(result.DurationSeconds*float64(time.Second)))},
+		{"Plan limit", limits},
+		{"Sent", sent},
+		{"Succeeded", fmt.Sprintf("%s (%.1f req/s)", formatInt64(int64(result.Succeeded)), result.SuccessRPS)},
+		{"Failed", formatInt64(int64(result.Failed))},
+		{"Rate limited", formatInt64(int64(result.RateLimited))},
+		{"Achieved", fmt.Sprintf("%.1f req/s over %.1fs", result.AchievedRPS, result.ElapsedSeconds)},
+		{"Latency", latency},
+	}
+	if result.Error != "" {
+		rows = append(rows, [2]string{"Last error", truncateWithEllipsis(result.Error, 120)})
+	}
+	if err := f.renderKeyValueRows(rows); err != nil {
+		return err
+	}
+	classes := result.ErrorClassCounts()
+	if len(classes) == 0 {
+		return nil
+	}
+	if _, err := fmt.Fprintln(f.w); err != nil {
+		return err
+	}
+	tw := table.NewWriter()
+	tw.AppendHeader(table.Row{"Error Class", "Requests", "Share"})
+	for _, c := range classes {
+		tw.AppendRow(f.formatTableRow(table.Row{c.Class, formatInt64(int64(c.Count)), fmt.Sprintf("%.1f%%", float64(c.Count)/float64(max(result.Sent, 1))*100)}))
+	}
+	return f.renderTable(tw)
+}
+
+// writeProxyMethods renders the per-method statistics of a proxy or gateway.
+func (f *HumanFormatter) writeProxyMethods(methods []proxy.MethodStats) error {
+	if len(methods) == 0 {
+		_, err := fmt.Fprintln(f.w, "No requests were proxied.")
+		return err
+	}
+	tw := table.NewWriter()
+	tw.AppendHeader(table.Row{"Method", "Transport", "Requests", "Errors", "Avg", "Max"})
+	for _, m := range methods {
+		failed := strconv.FormatUint(m.Errors, 10)
+		if len(m.ErrorsBy) > 0 {
+			classes := make([]string, 0, len(m.ErrorsBy))
+			for class, n := range m.ErrorsBy {
+				classes = append(classes, fmt.Sprintf("%s: %d", class, n))
+			}
+			sort.Strings(classes)
+			failed += " (" + strings.Join(classes, ", ") + ")"
+		}
+		tw.AppendRow(f.formatTableRow(table.Row{
+			m.Method,
+			m.Transport,
+			m.Requests,
+			failed,
+			fmt.Sprintf("%.1f ms", m.AvgMs),
+			fmt.Sprintf("%.1f ms", m.MaxMs),
+		}))
+	}
+	return f.renderTable(tw)
+}
+
+func (f *HumanFormatter) writeEndpointDiff(data interface{}) error {
+	diff, ok := data.(api.CatalogDiff)
+	if !ok {
+		return f.Write(data)
+	}
+	if diff.Baseline {
+		_, err := fmt.Fprintln(f.w, "No previous snapshot; saved the current catalog as the baseline.")
+		return err
+	}
+	if len(diff.Changes) == 0 {
+		_, err := fmt.Fprintf(f.w, "No catalog changes (%s → %s).\n", diff.From, diff.To)
+		return err
+	}
+	if _, err := fmt.Fprintf(f.w, "%d added, %d removed, %d changed (%s → %s)\n\n", diff.Added, diff.Removed, diff.Changed, diff.From, diff.To); err != nil {
+		return err
+	}
+
+	tw := table.NewWriter()
+	tw.AppendHeader(table.Row{"Change", "Level", "Chain", "Network", "Node Type", "Detail"})
+	for _, c := range diff.Changes {
+		detail := c.New
+		switch {
+		case c.Field != "":
+			detail = fmt.Sprintf("%s: %s → %s", c.Field, valueOrNone(c.Old), valueOrNone(c.New))
+		case c.Kind == api.ChangeRemoved:
+			detail = c.Old
+		}
+		tw.AppendRow(f.formatTableRow(table.Row{
+			c.Kind,
+			c.Level,
+			c.Chain,
+			c.Network,
+			c.NodeType,
+			detail,
+		}))
+	}
+	return f.renderTable(tw)
+}
+
+func valueOrNone(value string) string {
+	if value == "" {
+		return "(none)"
+	}
+	return value
+}
+
+// writeRawJSON pretty-prints an already-encoded JSON value.
+func (f *HumanFormatter) writeRawJSON(raw json.RawMessage) error {
+	if len(raw) == 0 {
+		_, err := fmt.Fprintln(f.w, "null")
+		return err
+	}
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, raw, "", "  "); err != nil {
+		_, err = fmt.Fprintln(f.w, string(raw))
+		return err
+	}
+	_, err := fmt.Fprintln(f.w, buf.String())
+	return err
+}
+
 func (f *HumanFormatter) writeAccountInfo(data interface{}) error {
 	info, ok := data.(*api.AccountInfo)
 	if !ok {
@@ -3,11 +3,11 @@ package output
 import (
 	"encoding/json"
 	"io"
-	"time"
 )
 
 type JSONFormatter struct {
-	w io.Writer
+	w    io.Writer
+	meta MetaSource
 }
 
 func NewJSONFormatter(w io.Writer) *JSONFormatter {
@@ -18,21 +18,23 @@ func (f *JSONFormatter) Success(command string, data interface{}) error {
 	resp := Response{
 		OK:   true,
 		Data: data,
-		Meta: &Meta{
-			Command:   command,
-			Timestamp: time.Now().UTC().Format(time.RFC3339),
-		},
+		Meta: buildMeta(command, f.meta),
 	}
 	return f.encode(resp)
 }
 
 func (f *JSONFormatter) Error(code string, message string, help string) error {
+	return f.ErrorWithDetails(code, message, help, nil)
+}
+
+func (f *JSONFormatter) ErrorWithDetails(code string, message string, help string, details interface{}) error {
 	resp := Response{
 		OK: false,
 		Error: &ErrorBody{
 			Code:    code,
 			Message: message,
 			Help:    help,
+			Details: details,
 		},
 	}
 	if err := f.encode(resp); err != nil {
@@ -3,13 +3,13 @@ package output
 import (
 	"encoding/json"
 	"io"
-	"time"
 
 	toon "github.com/toon-format/toon-go"
 )
 
 type TOONFormatter struct {
-	w io.Writer
+	w    io.Writer
+	meta MetaSource
 }
 
 func NewTOONFormatter(w io.Writer) *TOONFormatter {
@@ -20,21 +20,23 @@ func (f *TOONFormatter) Success(command string, data interface{}) error {
 	resp := Response{
 		OK:   true,
 		Data: data,
-		Meta: &Meta{
-			Command:   command,
-			Timestamp: time.Now().UTC().Format(time.RFC3339),
-		},
+		Meta: buildMeta(command, f.meta),
 	}
 	return f.encode(resp)
 }
 
 func (f *TOONFormatter) Error(code string, message string, help string) error {
+	return f.ErrorWithDetails(code, message, help, nil)
+}
+
+func (f *TOONFormatter) ErrorWithDetails(code string, message string, help string, details interface{}) error {
 	resp := Response{
 		OK: false,
 		Error: &ErrorBody{
 			Code:    code,
 			Message: message,
 			Help:    help,
+			Details: details,
 		},
 	}
 	if err := f.encode(resp); err != nil {
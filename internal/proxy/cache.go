@@ -0,0 +1,565 @@
+package proxy
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dwellir-public/cli/internal/rpc"
+)
+
+const (
+	// finalityRefresh is how long a finalized block number is trusted before
+	// it is fetched again.
+	finalityRefresh = 12 * time.Second
+
+	// defaultConfirmations approximates finality on chains without the
+	// "finalized" block tag.
+	defaultConfirmations = 64
+)
+
+// Cache lookup outcomes recorded in metrics.
+const (
+	CacheHit         = "hit"
+	CacheMiss        = "miss"
+	CacheUncacheable = "uncacheable"
+)
+
+// CacheStore holds cached JSON-RPC results under opaque keys.
+type CacheStore interface {
+	Get(key string) ([]byte, bool)
+	Put(key string, value []byte)
+	// Size reports the number of entries and their total size in bytes.
+	Size() (entries int, bytes int64)
+}
+
+// MemoryStore is an in-memory LRU store bounded by total value size.
+type MemoryStore struct {
+	maxBytes int64
+
+	mu    sync.Mutex
+	bytes int64
+	order *list.List
+	items map[string]*list.Element
+}
+
+type memoryEntry struct {
+	key   string
+	value []byte
+}
+
+// NewMemoryStore returns an empty store holding at most maxBytes of values.
+func NewMemoryStore(maxBytes int64) *MemoryStore {
+	return &MemoryStore{maxBytes: maxBytes, order: list.New(), items: map[string]*list.Element{}}
+}
+
+func (s *MemoryStore) Get(key string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	elem, ok := s.items[key]
+	if !ok {
+		return nil, false
+	}
+	s.order.MoveToFront(elem)
+	return elem.Value.(*memoryEntry).value, true
+}
+
+func (s *MemoryStore) Put(key string, value []byte) {
+	if int64(len(value)) > s.maxBytes {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if elem, ok := s.items[key]; ok {
+		s.bytes -= int64(len(elem.Value.(*memoryEntry).value))
+		s.order.Remove(elem)
+	}
+	s.items[key] = s.order.PushFront(&memoryEntry{key: key, value: value})
+	s.bytes += int64(len(value))
+	for s.bytes > s.maxBytes {
+		oldest := s.order.Back()
+		entry := oldest.Value.(*memoryEntry)
+		s.order.Remove(oldest)
+		delete(s.items, entry.key)
+		s.bytes -= int64(len(entry.value))
+	}
+}
+
+func (s *MemoryStore) Size() (int, int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.items), s.bytes
+}
+
+// DiskStore keeps one file per entry in a directory and evicts the least
+// recently used files once their total size exceeds the cap. Entries survive
+// restarts.
+type DiskStore struct {
+	dir      string
+	maxBytes int64
+
+	mu    sync.Mutex
+	bytes int64
+	files map[string]diskEntry
+}
+
+type diskEntry struct {
+	size int64
+	used time.Time
+}
+
+// OpenDiskStore opens (creating if needed) a store in dir and indexes the
+// entries already there.
+func OpenDiskStore(dir string, maxBytes int64) (*DiskStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	s := &DiskStore{dir: dir, maxBytes: maxBytes, files: map[string]diskEntry{}}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".json") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		s.files[name] = diskEntry{size: info.Size(), used: info.ModTime()}
+		s.bytes += info.Size()
+	}
+	s.mu.Lock()
+	s.evictLocked()
+	s.mu.Unlock()
+	return s, nil
+}
+
+func (s *DiskStore) fileName(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:]) + ".json"
+}
+
+func (s *DiskStore) Get(key string) ([]byte, bool) {
+	name := s.fileName(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.files[name]
+	if !ok {
+		return nil, false
+	}
+	value, err := os.ReadFile(filepath.Join(s.dir, name))
+	if err != nil {
+		s.bytes -= entry.size
+		delete(s.files, name)
+		return nil, false
+	}
+	now := time.Now()
+	entry.used = now
+	s.files[name] = entry
+	// The modification time doubles as the last-use time across restarts.
+	_ = os.Chtimes(filepath.Join(s.dir, name), now, now)
+	return value, true
+}
+
+func (s *DiskStore) Put(key string, value []byte) {
+	if int64(len(value)) > s.maxBytes {
+		return
+	}
+	name := s.fileName(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tmp, err := os.CreateTemp(s.dir, ".entry-*")
+	if err != nil {
+		return
+	}
+	_, writeErr := tmp.Write(value)
+	closeErr := tmp.Close()
+	if writeErr != nil || closeErr != nil || os.Rename(tmp.Name(), filepath.Join(s.dir, name)) != nil {
+		_ = os.Remove(tmp.Name())
+		return
+	}
+	if old, ok := s.files[name]; ok {
+		s.bytes -= old.size
+	}
+	s.files[name] = diskEntry{size: int64(len(value)), used: time.Now()}
+	s.bytes += int64(len(value))
+	s.evictLocked()
+}
+
+func (s *DiskStore) evictLocked() {
+	if s.bytes <= s.maxBytes {
+		return
+	}
+	names := make([]string, 0, len(s.files))
+	for name := range s.files {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return s.files[names[i]].used.Before(s.files[names[j]].used) })
+	for _, name := range names {
+		if s.bytes <= s.maxBytes {
+			return
+		}
+		_ = os.Remove(filepath.Join(s.dir, name))
+		s.bytes -= s.files[name].size
+		delete(s.files, name)
+	}
+}
+
+func (s *DiskStore) Size() (int, int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.files), s.bytes
+}
+
+// CacheMethodStats counts cache lookups for one method.
+type CacheMethodStats struct {
+	Method      string `json:"method"`
+	Hits        uint64 `json:"hits"`
+	Misses      uint64 `json:"misses"`
+	Uncacheable uint64 `json:"uncacheable"`
+}
+
+// CachingBackend answers immutable calls (chain id, finalized blocks, and
+// receipts and transactions in finalized blocks) from a store and forwards
+// everything else. A batch is answered from the store only when every call in
+// it is cached.
+type CachingBackend struct {
+	Upstream Backend
+	Store    CacheStore
+	Metrics  *Metrics
+	// Confirmations approximates finality when the chain does not support the
+	// "finalized" block tag.
+	Confirmations uint64
+
+	mu          sync.Mutex
+	finalized   uint64
+	finalizedAt time.Time
+	overhead    uint64
+	methods     map[string]*CacheMethodStats
+}
+
+// NewCachingBackend returns a backend caching upstream answers in store.
+func NewCachingBackend(upstream Backend, store CacheStore, metrics *Metrics) *CachingBackend {
+	return &CachingBackend{
+		Upstream:      upstream,
+		Store:         store,
+		Metrics:       metrics,
+		Confirmations: defaultConfirmations,
+		methods:       map[string]*CacheMethodStats{},
+	}
+}
+
+func (c *CachingBackend) Forward(ctx context.Context, payload []byte, calls []Call) (*Reply, error) {
+	started := time.Now()
+	keys := make([]string, len(calls))
+	results := make([]json.RawMessage, len(calls))
+	hits := 0
+	for i, call := range calls {
+		key, ok := cacheKey(call)
+		if !ok {
+			c.record(call.Method, CacheUncacheable)
+			continue
+		}
+		keys[i] = key
+		if value, found := c.Store.Get(key); found {
+			results[i] = value
+			hits++
+		}
+	}
+
+	if hits == len(calls) {
+		responses := make([]rpc.Response, len(calls))
+		for i, call := range calls {
+			c.record(call.Method, CacheHit)
+			responses[i] = rpc.Response{JSONRPC: "2.0", ID: call.ID, Result: results[i]}
+		}
+		var body []byte
+		if len(calls) == 1 && !strings.HasPrefix(strings.TrimSpace(string(payload)), "[") {
+			body, _ = json.Marshal(responses[0])
+		} else {
+			body, _ = json.Marshal(responses)
+		}
+		return &Reply{StatusCode: http.StatusOK, Body: body, Latency: time.Since(started)}, nil
+	}
+
+	for i, call := range calls {
+		if keys[i] != "" {
+			c.record(call.Method, CacheMiss)
+		}
+	}
+	reply, err := c.Upstream.Forward(ctx, payload, calls)
+	if err != nil || reply.StatusCode != http.StatusOK {
+		return reply, err
+	}
+	responses, ok := parseResponses(reply.Body)
+	if !ok {
+		return reply, nil
+	}
+	byID := make(map[string]*rpc.Response, len(responses))
+	for i := range responses {
+		byID[idKey(responses[i].ID)] = &responses[i]
+	}
+	for i, call := range calls {
+		if keys[i] == "" || results[i] != nil {
+			continue
+		}
+		resp, found := byID[idKey(call.ID)]
+		if !found || resp.Error != nil || !c.immutable(ctx, call, resp.Result) {
+			continue
+		}
+		c.Store.Put(keys[i], resp.Result)
+	}
+	entries, size := c.Store.Size()
+	c.Metrics.Set("cache_entries", "Entries held by the cache.", float64(entries))
+	c.Metrics.Set("cache_size_bytes", "Total size of cached results.", float64(size))
+	return reply, nil
+}
+
+func (c *CachingBackend) record(method, outcome string) {
+	method = methodName(Call{Method: method})
+	c.mu.Lock()
+	stats, ok := c.methods[method]
+	if !ok {
+		stats = &CacheMethodStats{Method: method}
+		c.methods[method] = stats
+	}
+	switch outcome {
+	case CacheHit:
+		stats.Hits++
+	case CacheMiss:
+		stats.Misses++
+	default:
+		stats.Uncacheable++
+	}
+	c.mu.Unlock()
+	c.Metrics.Add("cache_lookups_total", "Calls looked up in the cache, by method and outcome.", 1, "method", method, "result", outcome)
+}
+
+// Stats returns per-method lookup counts ordered by hits, and the number of
+// upstream calls the cache made itself to learn the finalized block.
+func (c *CachingBackend) Stats() ([]CacheMethodStats, uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats := make([]CacheMethodStats, 0, len(c.methods))
+	for _, s := range c.methods {
+		stats = append(stats, *s)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Hits != stats[j].Hits {
+			return stats[i].Hits > stats[j].Hits
+		}
+		return stats[i].Method < stats[j].Method
+	})
+	return stats, c.overhead
+}
+
+// cacheKey returns the store key of a call whose answer may be immutable.
+// Whether the answer really is immutable is decided by immutable once the
+// result is known.
+func cacheKey(call Call) (string, bool) {
+	switch call.Method {
+	case "eth_chainId", "net_version",
+		"eth_getBlockByHash", "eth_getBlockByNumber",
+		"eth_getTransactionReceipt", "eth_getTransactionByHash":
+	default:
+		return "", false
+	}
+	params := []byte("[]")
+	if trimmed := bytes.TrimSpace(call.Params); len(trimmed) > 0 && !bytes.Equal(trimmed, []byte("null")) {
+		var compact bytes.Buffer
+		if err := json.Compact(&compact, trimmed); err != nil {
+			return "", false
+		}
+		params = compact.Bytes()
+	}
+	if call.Method == "eth_getBlockByNumber" {
+		var args []json.RawMessage
+		if json.Unmarshal(params, &args) != nil || len(args) == 0 {
+			return "", false
+		}
+		var number string
+		if json.Unmarshal(args[0], &number) != nil || !strings.HasPrefix(number, "0x") {
+			// Block tags such as latest move with the chain.
+			return "", false
+		}
+		// Normalize 0x0a and 0xa to the same entry.
+		n, err := strconv.ParseUint(strings.TrimPrefix(number, "0x"), 16, 64)
+		if err != nil {
+			return "", false
+		}
+		args[0], _ = json.Marshal("0x" + strconv.FormatUint(n, 16))
+		params, _ = json.Marshal(args)
+	}
+	return call.Method + string(params), true
+}
+
+// immutable reports whether result may be cached: chain identity always,
+// blocks by hash once found, and blocks, receipts, and transactions once
+// their block is final.
+func (c *CachingBackend) immutable(ctx context.Context, call Call, result json.RawMessage) bool {
+	if len(result) == 0 || string(result) == "null" {
+		return false
+	}
+	switch call.Method {
+	case "eth_chainId", "net_version", "eth_getBlockByHash":
+		return true
+	}
+	var block struct {
+		Number      string `json:"number"`
+		BlockNumber string `json:"blockNumber"`
+	}
+	if err := json.Unmarshal(result, &block); err != nil {
+		return false
+	}
+	raw := block.BlockNumber
+	if call.Method == "eth_getBlockByNumber" {
+		raw = block.Number
+	}
+	number, err := strconv.ParseUint(strings.TrimPrefix(raw, "0x"), 16, 64)
+	if err != nil || raw == "" {
+		return false
+	}
+	finalized, err := c.finalizedBlock(ctx)
+	return err == nil && number <= finalized
+}
+
+// finalizedBlock returns the latest finalized block, asking the upstream at
+// most once per finalityRefresh.
+func (c *CachingBackend) finalizedBlock(ctx context.Context) (uint64, error) {
+	c.mu.Lock()
+	if !c.finalizedAt.IsZero() && time.Since(c.finalizedAt) < finalityRefresh {
+		finalized := c.finalized
+		c.mu.Unlock()
+		return finalized, nil
+	}
+	c.mu.Unlock()
+
+	finalized, err := c.fetchFinalized(ctx)
+	if err != nil {
+		return 0, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.finalized = max(c.finalized, finalized)
+	c.finalizedAt = time.Now()
+	return c.finalized, nil
+}
+
+func (c *CachingBackend) fetchFinalized(ctx context.Context) (uint64, error) {
+	result, err := c.upstreamCall(ctx, "eth_getBlockByNumber", `["finalized",false]`)
+	if err == nil && string(result) != "null" {
+		var block struct {
+			Number json.RawMessage `json:"number"`
+		}
+		if json.Unmarshal(result, &block) == nil {
+			if n, err := parseBlockNumber(block.Number); err == nil {
+				return n, nil
+			}
+		}
+	}
+	result, err = c.upstreamCall(ctx, "eth_blockNumber", `[]`)
+	if err != nil {
+		return 0, err
+	}
+	head, err := parseBlockNumber(result)
+	if err != nil {
+		return 0, err
+	}
+	if head < c.Confirmations {
+		return 0, nil
+	}
+	return head - c.Confirmations, nil
+}
+
+func (c *CachingBackend) upstreamCall(ctx context.Context, method, params string) (json.RawMessage, error) {
+	call := Call{ID: json.RawMessage(`"dwellir-cache"`), Method: method, Params: json.RawMessage(params)}
+	payload, _ := json.Marshal(rpc.Request{JSONRPC: "2.0", ID: call.ID, Method: call.Method, Params: call.Params})
+	c.mu.Lock()
+	c.overhead++
+	c.mu.Unlock()
+	reply, err := c.Upstream.Forward(ctx, payload, []Call{call})
+	if err != nil {
+		return nil, err
+	}
+	var resp rpc.Response
+	if err := json.Unmarshal(reply.Body, &resp); err != nil {
+		return nil, fmt.Errorf("parsing %s response: %w", method, err)
+	}
+	if resp.Error != nil {
+		return nil, resp.Error
+	}
+	if resp.Result == nil {
+		return nil, errors.New(method + " returned no result")
+	}
+	return resp.Result, nil
+}
+
+func parseBlockNumber(raw json.RawMessage) (uint64, error) {
+	var text string
+	if err := json.Unmarshal(raw, &text); err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(strings.TrimPrefix(text, "0x"), 16, 64)
+}
+
+// CacheSummary is reported when a caching forwarder shuts down.
+type CacheSummary struct {
+	Chain         string  `json:"chain"`
+	Network       string  `json:"network"`
+	Listen        string  `json:"listen"`
+	Store         string  `json:"store"`
+	UptimeSeconds float64 `json:"uptime_seconds"`
+	Entries       int     `json:"entries"`
+	SizeBytes     int64   `json:"size_bytes"`
+	Hits          uint64  `json:"hits"`
+	Misses        uint64  `json:"misses"`
+	Uncacheable   uint64  `json:"uncacheable"`
+	HitRatio      float64 `json:"hit_ratio"`
+	// SavedRequests is the number of calls answered locally, net of the
+	// calls the cache made itself to learn the finalized block.
+	SavedRequests int64  `json:"saved_requests"`
+	Overhead      uint64 `json:"overhead_requests"`
+	// CycleRequests and MonthlyQuota come from 'usage summary' and the
+	// subscription, when available.
+	CycleRequests   *int               `json:"cycle_requests,omitempty"`
+	MonthlyQuota    *int               `json:"monthly_quota,omitempty"`
+	SavedPctOfCycle *float64           `json:"saved_pct_of_cycle,omitempty"`
+	SavedPctOfQuota *float64           `json:"saved_pct_of_quota,omitempty"`
+	CacheByMethod   []CacheMethodStats `json:"cache_by_method"`
+	Methods         []MethodStats      `json:"methods"`
+}
+
+// NewCacheSummary totals the backend's lookups. Usage figures are filled in
+// by the caller.
+func NewCacheSummary(c *CachingBackend) CacheSummary {
+	byMethod, overhead := c.Stats()
+	summary := CacheSummary{CacheByMethod: byMethod, Overhead: overhead}
+	for _, s := range byMethod {
+		summary.Hits += s.Hits
+		summary.Misses += s.Misses
+		summary.Uncacheable += s.Uncacheable
+	}
+	if lookups := summary.Hits + summary.Misses; lookups > 0 {
+		summary.HitRatio = float64(summary.Hits) / float64(lookups)
+	}
+	summary.SavedRequests = int64(summary.Hits) - int64(overhead)
+	summary.Entries, summary.SizeBytes = c.Store.Size()
+	return summary
+}
@@ -0,0 +1,256 @@
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeChain answers like an EVM node whose finalized block is 100 and records
+// every call it receives.
+type fakeChain struct {
+	mu           sync.Mutex
+	calls        []string
+	noFinalized  bool
+	head         uint64
+	missingBlock bool
+}
+
+func (f *fakeChain) Forward(ctx context.Context, payload []byte, calls []Call) (*Reply, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	responses := make([]string, 0, len(calls))
+	for _, call := range calls {
+		var params []string
+		_ = json.Unmarshal(call.Params, &params)
+		f.calls = append(f.calls, call.Method+" "+strings.Join(params, ","))
+
+		result := `"0x1"`
+		switch call.Method {
+		case "eth_getBlockByNumber":
+			number := "0x0"
+			if len(params) > 0 {
+				number = params[0]
+			}
+			switch {
+			case number == "finalized" && f.noFinalized:
+				responses = append(responses, fmt.Sprintf(`{"jsonrpc":"2.0","id":%s,"error":{"code":-32602,"message":"unknown block tag"}}`, call.ID))
+				continue
+			case number == "finalized":
+				number = "0x64"
+			}
+			result = fmt.Sprintf(`{"number":%q,"hash":"0xabc"}`, number)
+		case "eth_getTransactionReceipt":
+			result = `{"blockNumber":"0x10","status":"0x1"}`
+			if f.missingBlock {
+				result = "null"
+			}
+		case "eth_blockNumber":
+			result = fmt.Sprintf(`"0x%x"`, f.head)
+		}
+		responses = append(responses, fmt.Sprintf(`{"jsonrpc":"2.0","id":%s,"result":%s}`, call.ID, result))
+	}
+	body := responses[0]
+	if strings.HasPrefix(strings.TrimSpace(string(payload)), "[") {
+		body = "[" + strings.Join(responses, ",") + "]"
+	}
+	return &Reply{StatusCode: http.StatusOK, Body: []byte(body)}, nil
+}
+
+func (f *fakeChain) count(prefix string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	n := 0
+	for _, call := range f.calls {
+		if strings.HasPrefix(call, prefix) {
+			n++
+		}
+	}
+	return n
+}
+
+func forward(t *testing.T, backend Backend, payload string) string {
+	t.Helper()
+	calls, _, err := ParsePayload([]byte(payload))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	reply, err := backend.Forward(context.Background(), []byte(payload), calls)
+	if err != nil {
+		t.Fatalf("forward: %v", err)
+	}
+	return string(reply.Body)
+}
+
+func TestCachingBackendCachesFinalizedBlocks(t *testing.T) {
+	upstream := &fakeChain{}
+	cache := NewCachingBackend(upstream, NewMemoryStore(1<<20), NewMetrics("test"))
+
+	for i := 0; i < 3; i++ {
+		body := forward(t, cache, fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"method":"eth_getBlockByNumber","params":["0x010",false]}`, i))
+		if !strings.Contains(body, fmt.Sprintf(`"id":%d`, i)) || !strings.Contains(body, `"number":"0x010"`) {
+			t.Fatalf("unexpected response: %s", body)
+		}
+	}
+	if n := upstream.count("eth_getBlockByNumber 0x010"); n != 1 {
+		t.Fatalf("expected one upstream fetch of a finalized block, got %d", n)
+	}
+	// The normalized block number shares the entry.
+	forward(t, cache, `{"jsonrpc":"2.0","id":9,"method":"eth_getBlockByNumber","params":["0x10",false]}`)
+	if n := upstream.count("eth_getBlockByNumber 0x10"); n != 0 {
+		t.Fatalf("expected 0x10 to hit the 0x010 entry, got %d fetches", n)
+	}
+
+	// Blocks past the finalized one are forwarded every time.
+	for i := 0; i < 2; i++ {
+		forward(t, cache, `{"jsonrpc":"2.0","id":1,"method":"eth_getBlockByNumber","params":["0x70",false]}`)
+	}
+	if n := upstream.count("eth_getBlockByNumber 0x70"); n != 2 {
+		t.Fatalf("expected unfinalized blocks to bypass the cache, got %d fetches", n)
+	}
+	if n := upstream.count("eth_getBlockByNumber finalized"); n != 1 {
+		t.Fatalf("expected the finalized block to be looked up once, got %d", n)
+	}
+
+	summary := NewCacheSummary(cache)
+	if summary.Hits != 3 || summary.Misses != 3 || summary.Overhead != 1 || summary.SavedRequests != 2 || summary.Entries != 1 {
+		t.Fatalf("unexpected summary: %+v", summary)
+	}
+	if summary.HitRatio != 0.5 {
+		t.Fatalf("unexpected hit ratio %v", summary.HitRatio)
+	}
+}
+
+func TestCachingBackendChainIDAndReceipts(t *testing.T) {
+	upstream := &fakeChain{missingBlock: true}
+	cache := NewCachingBackend(upstream, NewMemoryStore(1<<20), NewMetrics("test"))
+
+	for i := 0; i < 2; i++ {
+		forward(t, cache, `{"jsonrpc":"2.0","id":1,"method":"eth_chainId"}`)
+		forward(t, cache, `{"jsonrpc":"2.0","id":2,"method":"eth_getTransactionReceipt","params":["0xfeed"]}`)
+		forward(t, cache, `{"jsonrpc":"2.0","id":3,"method":"eth_blockNumber"}`)
+	}
+	if upstream.count("eth_chainId") != 1 || upstream.count("eth_blockNumber") != 2 {
+		t.Fatalf("unexpected upstream calls: %v", upstream.calls)
+	}
+	// Receipts of pending transactions (null) are not cached...
+	if n := upstream.count("eth_getTransactionReceipt"); n != 2 {
+		t.Fatalf("expected null receipts to be refetched, got %d", n)
+	}
+	// ...but mined, finalized ones are.
+	upstream.missingBlock = false
+	for i := 0; i < 3; i++ {
+		forward(t, cache, `{"jsonrpc":"2.0","id":2,"method":"eth_getTransactionReceipt","params":["0xfeed"]}`)
+	}
+	if n := upstream.count("eth_getTransactionReceipt"); n != 3 {
+		t.Fatalf("expected the finalized receipt to be cached, got %d fetches", n)
+	}
+}
+
+func TestCachingBackendBatches(t *testing.T) {
+	upstream := &fakeChain{}
+	cache := NewCachingBackend(upstream, NewMemoryStore(1<<20), NewMetrics("test"))
+
+	batch := `[{"jsonrpc":"2.0","id":1,"method":"eth_chainId"},{"jsonrpc":"2.0","id":2,"method":"net_version"}]`
+	forward(t, cache, batch)
+	body := forward(t, cache, batch)
+	if upstream.count("eth_chainId") != 1 || upstream.count("net_version") != 1 {
+		t.Fatalf("expected the second batch to be answered locally: %v", upstream.calls)
+	}
+	var responses []map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(body), &responses); err != nil || len(responses) != 2 || string(responses[1]["id"]) != "2" {
+		t.Fatalf("unexpected batch response: %s", body)
+	}
+
+	forward(t, cache, `[{"jsonrpc":"2.0","id":1,"method":"eth_chainId"},{"jsonrpc":"2.0","id":2,"method":"eth_gasPrice"}]`)
+	if upstream.count("eth_chainId") != 2 {
+		t.Fatalf("expected a partially cached batch to be forwarded whole: %v", upstream.calls)
+	}
+}
+
+func TestCachingBackendFallsBackToConfirmations(t *testing.T) {
+	upstream := &fakeChain{noFinalized: true, head: 200}
+	cache := NewCachingBackend(upstream, NewMemoryStore(1<<20), NewMetrics("test"))
+	cache.Confirmations = 50
+
+	for _, block := range []string{"0x96", "0x97"} { // 150 is final at head 200, 151 is not
+		for i := 0; i < 2; i++ {
+			forward(t, cache, fmt.Sprintf(`{"jsonrpc":"2.0","id":1,"method":"eth_getBlockByNumber","params":[%q,false]}`, block))
+		}
+	}
+	if upstream.count("eth_getBlockByNumber 0x96") != 1 || upstream.count("eth_getBlockByNumber 0x97") != 2 {
+		t.Fatalf("unexpected upstream calls: %v", upstream.calls)
+	}
+}
+
+func TestCacheKey(t *testing.T) {
+	for _, tt := range []struct {
+		method, params string
+		ok             bool
+	}{
+		{"eth_chainId", ``, true},
+		{"eth_getBlockByNumber", `["latest", false]`, false},
+		{"eth_getBlockByNumber", `["0x10", true]`, true},
+		{"eth_getBlockByHash", `["0xabc", false]`, true},
+		{"eth_getBalance", `["0x1", "0x10"]`, false},
+	} {
+		if _, ok := cacheKey(Call{Method: tt.method, Params: json.RawMessage(tt.params)}); ok != tt.ok {
+			t.Errorf("cacheKey(%s %s) ok = %v, want %v", tt.method, tt.params, ok, tt.ok)
+		}
+	}
+	a, _ := cacheKey(Call{Method: "eth_getBlockByHash", Params: json.RawMessage(`[ "0xabc",  false ]`)})
+	b, _ := cacheKey(Call{Method: "eth_getBlockByHash", Params: json.RawMessage(`["0xabc",false]`)})
+	if a != b {
+		t.Fatalf("expected whitespace-insensitive keys, got %q and %q", a, b)
+	}
+}
+
+func TestMemoryStoreEvictsLeastRecentlyUsed(t *testing.T) {
+	store := NewMemoryStore(10)
+	store.Put("a", []byte("aaaa"))
+	store.Put("b", []byte("bbbb"))
+	store.Get("a")
+	store.Put("c", []byte("cccc"))
+	if _, ok := store.Get("b"); ok {
+		t.Fatal("expected b to be evicted")
+	}
+	if _, ok := store.Get("a"); !ok {
+		t.Fatal("expected a to survive")
+	}
+	if entries, size := store.Size(); entries != 2 || size != 8 {
+		t.Fatalf("unexpected size: %d entries, %d bytes", entries, size)
+	}
+	store.Put("huge", []byte("0123456789abc"))
+	if _, ok := store.Get("huge"); ok {
+		t.Fatal("expected values larger than the cap to be skipped")
+	}
+}
+
+func TestDiskStorePersistsAndEvicts(t *testing.T) {
+	dir := t.TempDir()
+	store, err := OpenDiskStore(dir, 10)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	store.Put("a", []byte("aaaa"))
+	store.Put("b", []byte("bbbb"))
+
+	reopened, err := OpenDiskStore(dir, 10)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	if value, ok := reopened.Get("a"); !ok || string(value) != "aaaa" {
+		t.Fatalf("expected a to persist, got %q %v", value, ok)
+	}
+	reopened.Put("c", []byte("cccc"))
+	if _, ok := reopened.Get("b"); ok {
+		t.Fatal("expected the least recently used entry to be evicted")
+	}
+	if entries, size := reopened.Size(); entries != 2 || size != 8 {
+		t.Fatalf("unexpected size: %d entries, %d bytes", entries, size)
+	}
+}
@@ -0,0 +1,440 @@
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/websocket"
+
+	"github.com/dwellir-public/cli/internal/rpc"
+)
+
+const (
+	defaultAttemptTimeout = 10 * time.Second
+
+	// fullNodeStateWindow is how many recent blocks of state a full (pruned)
+	// node keeps; state reads at older blocks need an archive node.
+	fullNodeStateWindow = 128
+
+	// maxHeadLag is how far a node may trail the best head seen across the
+	// gateway's nodes before it is taken out of rotation.
+	maxHeadLag = 10
+)
+
+// Routing reasons recorded in metrics and node status.
+const (
+	RouteDefault            = "default"
+	RouteArchive            = "archive"
+	RouteArchiveUnavailable = "archive_unavailable"
+	RouteFailover           = "failover"
+	RouteWebSocket          = "websocket"
+)
+
+// archiveMethodPrefixes name method families that only archive nodes serve.
+var archiveMethodPrefixes = []string{"debug_", "trace_", "arbtrace_"}
+
+// writeMethodPrefixes and writeMethodWords mark calls that change state or
+// broadcast something, such as eth_sendRawTransaction, author_submitExtrinsic,
+// or broadcast_tx_sync. Replaying them on another node after the first may
+// have run them could broadcast twice.
+var (
+	writeMethodPrefixes = []string{"personal_", "eth_sign", "wallet_", "admin_", "miner_", "engine_"}
+	writeMethodWords    = []string{"send", "submit", "broadcast"}
+)
+
+// historicalStateParams maps state-reading methods to the position of their
+// block parameter.
+var historicalStateParams = map[string]int{
+	"eth_getBalance":          1,
+	"eth_getCode":             1,
+	"eth_getTransactionCount": 1,
+	"eth_getStorageAt":        2,
+	"eth_call":                1,
+	"eth_getProof":            2,
+	"eth_createAccessList":    1,
+}
+
+// Node is one upstream a gateway can route to. HTTP and WS are probe targets
+// with the API key already injected; either may have an empty URL.
+type Node struct {
+	Name     string
+	NodeType string
+	HTTP     rpc.ProbeTarget
+	WS       rpc.ProbeTarget
+
+	backend *HTTPBackend
+	status  NodeStatus
+}
+
+// Archive reports whether the node keeps historical state.
+func (n *Node) Archive() bool {
+	return strings.EqualFold(n.NodeType, "archive")
+}
+
+// NodeStatus is the health and routing record of one node.
+type NodeStatus struct {
+	Name       string            `json:"name"`
+	NodeType   string            `json:"node_type"`
+	Endpoint   string            `json:"endpoint,omitempty"`
+	WSEndpoint string            `json:"ws_endpoint,omitempty"`
+	Healthy    bool              `json:"healthy"`
+	HeadBlock  uint64            `json:"head_block,omitempty"`
+	LatencyMs  float64           `json:"latency_ms,omitempty"`
+	CheckedAt  *time.Time        `json:"checked_at,omitempty"`
+	LastError  string            `json:"last_error,omitempty"`
+	Routed     map[string]uint64 `json:"routed,omitempty"`
+	Failovers  uint64            `json:"failovers"`
+}
+
+// Gateway is a Backend that spreads calls over a chain's nodes: archive-only
+// calls go to archive nodes, everything else prefers full nodes, and a node
+// answering with 5xx or timing out is skipped for the next one. Payloads with
+// write calls only move on when the node could not be reached at all.
+type Gateway struct {
+	Metrics *Metrics
+	// AttemptTimeout bounds each upstream attempt before failing over.
+	AttemptTimeout time.Duration
+
+	mu    sync.Mutex
+	nodes []*Node
+	head  uint64
+}
+
+// NewGateway returns a gateway over nodes. Nodes are considered healthy until
+// the first health check says otherwise.
+func NewGateway(nodes []*Node, metrics *Metrics) *Gateway {
+	for _, node := range nodes {
+		if node.HTTP.URL != "" {
+			node.backend = NewHTTPBackend(node.HTTP.URL)
+		}
+		node.status = NodeStatus{
+			Name:       node.Name,
+			NodeType:   node.NodeType,
+			Endpoint:   node.HTTP.Endpoint,
+			WSEndpoint: node.WS.Endpoint,
+			Healthy:    true,
+			Routed:     map[string]uint64{},
+		}
+		metrics.Set("upstream_healthy", "Whether an upstream node is in rotation (1) or not (0).", 1, "upstream", node.Name)
+	}
+	return &Gateway{Metrics: metrics, AttemptTimeout: defaultAttemptTimeout, nodes: nodes}
+}
+
+// HasWebSocket reports whether any node has a WebSocket endpoint.
+func (g *Gateway) HasWebSocket() bool {
+	for _, node := range g.nodes {
+		if node.WS.URL != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *Gateway) Forward(ctx context.Context, payload []byte, calls []Call) (*Reply, error) {
+	g.mu.Lock()
+	head := g.head
+	g.mu.Unlock()
+	archive, writes := false, false
+	for _, call := range calls {
+		archive = archive || NeedsArchive(call, head)
+		writes = writes || IsWriteCall(call.Method)
+	}
+
+	var (
+		lastReply *Reply
+		lastErr   error
+	)
+	for i, node := range g.route(archive, func(n *Node) bool { return n.backend != nil }) {
+		reason := RouteDefault
+		switch {
+		case i > 0:
+			reason = RouteFailover
+		case archive && node.Archive():
+			reason = RouteArchive
+		case archive:
+			reason = RouteArchiveUnavailable
+		}
+		g.recordRoute(node, reason)
+
+		attemptCtx, cancel := context.WithTimeout(ctx, g.AttemptTimeout)
+		reply, err := node.backend.Forward(attemptCtx, payload, calls)
+		cancel()
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		cause := ""
+		switch {
+		case err != nil && errors.Is(err, context.DeadlineExceeded):
+			cause = "timeout"
+		case err != nil:
+			cause = ErrorUnavailable
+		case reply.StatusCode >= 500:
+			cause = ErrorHTTP5xx
+		default:
+			return reply, nil
+		}
+		g.recordFailure(node, cause, err)
+		lastReply, lastErr = reply, err
+		if writes && !isDialError(err) {
+			// The node may have run the call before failing.
+			break
+		}
+	}
+	if lastReply != nil {
+		return lastReply, nil
+	}
+	if lastErr == nil {
+		lastErr = errors.New("no upstream node serves HTTP")
+	}
+	return nil, lastErr
+}
+
+// DialWebSocket connects to the best node with a WebSocket endpoint, trying
+// the next one when a dial fails. Archive nodes are preferred because a
+// connection cannot be rerouted per call.
+func (g *Gateway) DialWebSocket(ctx context.Context) (*websocket.Conn, error) {
+	var lastErr error
+	for _, node := range g.route(true, func(n *Node) bool { return n.WS.URL != "" }) {
+		ws, err := rpc.DialWebSocket(ctx, node.WS.URL)
+		if err == nil {
+			g.recordRoute(node, RouteWebSocket)
+			return ws, nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		g.recordFailure(node, ErrorUnavailable, err)
+		lastErr = err
+	}
+	if lastErr == nil {
+		lastErr = errors.New("no upstream node serves WebSocket")
+	}
+	return nil, lastErr
+}
+
+// route orders the nodes accepted by usable: healthy before unhealthy, then
+// archive nodes first for archive calls and full nodes first otherwise, then
+// by health-check latency.
+func (g *Gateway) route(archive bool, usable func(*Node) bool) []*Node {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	var order []*Node
+	for _, node := range g.nodes {
+		if usable(node) {
+			order = append(order, node)
+		}
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		a, b := order[i], order[j]
+		if a.status.Healthy != b.status.Healthy {
+			return a.status.Healthy
+		}
+		if a.Archive() != b.Archive() {
+			return a.Archive() == archive
+		}
+		return a.status.LatencyMs < b.status.LatencyMs
+	})
+	return order
+}
+
+func (g *Gateway) recordRoute(node *Node, reason string) {
+	g.mu.Lock()
+	node.status.Routed[reason]++
+	g.mu.Unlock()
+	g.Metrics.Add("routed_total", "Requests sent to each upstream node, by routing reason.", 1, "upstream", node.Name, "reason", reason)
+}
+
+// recordFailure takes a node out of rotation until the next successful
+// health check.
+func (g *Gateway) recordFailure(node *Node, cause string, err error) {
+	g.mu.Lock()
+	node.status.Failovers++
+	node.status.Healthy = false
+	node.status.LastError = cause
+	if err != nil {
+		node.status.LastError = err.Error()
+	}
+	g.mu.Unlock()
+	g.Metrics.Add("failovers_total", "Upstream attempts that failed with a 5xx, a timeout, or a connection error, by cause.", 1, "upstream", node.Name, "cause", cause)
+	g.Metrics.Set("upstream_healthy", "Whether an upstream node is in rotation (1) or not (0).", 0, "upstream", node.Name)
+}
+
+// CheckHealth probes every node once, concurrently. A node is healthy when
+// its probe succeeds and its head is within maxHeadLag blocks of the best head.
+func (g *Gateway) CheckHealth(ctx context.Context, timeout time.Duration) {
+	results := make([]rpc.ProbeResult, len(g.nodes))
+	var wg sync.WaitGroup
+	for i, node := range g.nodes {
+		target := node.HTTP
+		if target.URL == "" {
+			target = node.WS
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = rpc.Probe(ctx, []rpc.ProbeTarget{target}, rpc.ProbeOptions{Samples: 1, Timeout: timeout})[0]
+		}()
+	}
+	wg.Wait()
+	if ctx.Err() != nil {
+		return
+	}
+
+	best := uint64(0)
+	for _, result := range results {
+		best = max(best, result.HeadBlock)
+	}
+	now := time.Now().UTC()
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.head = max(g.head, best)
+	for i, node := range g.nodes {
+		result := results[i]
+		status := &node.status
+		status.CheckedAt = &now
+		status.HeadBlock = result.HeadBlock
+		status.LatencyMs = result.P50Ms
+		status.Healthy = result.Succeeded > 0
+		status.LastError = result.Error
+		if status.Healthy && result.HeadBlock > 0 && result.HeadBlock+maxHeadLag < best {
+			status.Healthy = false
+			status.LastError = "lagging " + strconv.FormatUint(best-result.HeadBlock, 10) + " blocks behind"
+		}
+
+		healthy := 0.0
+		if status.Healthy {
+			healthy = 1
+		}
+		g.Metrics.Set("upstream_healthy", "Whether an upstream node is in rotation (1) or not (0).", healthy, "upstream", node.Name)
+		if result.HeadBlock > 0 {
+			g.Metrics.Set("upstream_head_block", "Head block reported by the last health check.", float64(result.HeadBlock), "upstream", node.Name)
+		}
+		if result.Succeeded > 0 {
+			g.Metrics.Set("upstream_health_check_seconds", "Latency of the last health check.", result.P50Ms/1000, "upstream", node.Name)
+		}
+	}
+}
+
+// RunHealthChecks checks health now and then every interval until ctx is done.
+func (g *Gateway) RunHealthChecks(ctx context.Context, interval, timeout time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		g.CheckHealth(ctx, timeout)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Status returns a copy of every node's health and routing record.
+func (g *Gateway) Status() []NodeStatus {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	statuses := make([]NodeStatus, 0, len(g.nodes))
+	for _, node := range g.nodes {
+		status := node.status
+		status.Routed = make(map[string]uint64, len(node.status.Routed))
+		for reason, n := range node.status.Routed {
+			status.Routed[reason] = n
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+// IsWriteCall reports whether method changes state or broadcasts, so a
+// payload carrying it must not be sent to a second node once one may have
+// received it.
+func IsWriteCall(method string) bool {
+	lower := strings.ToLower(method)
+	for _, prefix := range writeMethodPrefixes {
+		if strings.HasPrefix(lower, prefix) {
+			return true
+		}
+	}
+	for _, word := range writeMethodWords {
+		if strings.Contains(lower, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// isDialError reports whether err happened while connecting, before any of
+// the request was sent.
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// NeedsArchive reports whether call needs an archive node: debug and trace
+// methods, and state reads pinned to a block older than a full node keeps.
+// head is the best known block, or 0 when unknown, in which case any pinned
+// block number counts as historical.
+func NeedsArchive(call Call, head uint64) bool {
+	for _, prefix := range archiveMethodPrefixes {
+		if strings.HasPrefix(call.Method, prefix) {
+			return true
+		}
+	}
+	index, ok := historicalStateParams[call.Method]
+	if !ok {
+		return false
+	}
+	var params []json.RawMessage
+	if err := json.Unmarshal(call.Params, &params); err != nil || len(params) <= index {
+		return false
+	}
+	return isHistoricalBlock(params[index], head)
+}
+
+func isHistoricalBlock(raw json.RawMessage, head uint64) bool {
+	var tag string
+	if err := json.Unmarshal(raw, &tag); err != nil {
+		// EIP-1898 block parameter: {"blockNumber": "0x.."} or {"blockHash": "0x.."}.
+		var block struct {
+			BlockNumber string `json:"blockNumber"`
+			BlockHash   string `json:"blockHash"`
+		}
+		if err := json.Unmarshal(raw, &block); err != nil {
+			return false
+		}
+		if block.BlockHash != "" {
+			return true
+		}
+		tag = block.BlockNumber
+	}
+	switch strings.ToLower(tag) {
+	case "", "latest", "pending", "safe", "finalized":
+		return false
+	case "earliest":
+		return true
+	}
+	number, err := strconv.ParseUint(strings.TrimPrefix(strings.ToLower(tag), "0x"), 16, 64)
+	if err != nil {
+		return false
+	}
+	return head == 0 || number+fullNodeStateWindow < head
+}
+
+// GatewaySummary is reported when a gateway shuts down.
+type GatewaySummary struct {
+	Chain         string        `json:"chain"`
+	Network       string        `json:"network"`
+	Listen        string        `json:"listen"`
+	UptimeSeconds float64       `json:"uptime_seconds"`
+	Nodes         []NodeStatus  `json:"nodes"`
+	Methods       []MethodStats `json:"methods"`
+}
@@ -0,0 +1,246 @@
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dwellir-public/cli/internal/rpc"
+)
+
+func newTestNode(t *testing.T, name, nodeType string, handler http.HandlerFunc) *Node {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return &Node{
+		Name:     name,
+		NodeType: nodeType,
+		HTTP:     rpc.ProbeTarget{Protocol: "https", Endpoint: "https://" + name + "/<key>", URL: server.URL, Kind: rpc.ProbeEVM},
+	}
+}
+
+func jsonRPCResult(result string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var call Call
+		_ = json.NewDecoder(r.Body).Decode(&call)
+		_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":%q}`, call.ID, result)
+	}
+}
+
+func TestGatewayFailsOverOn5xx(t *testing.T) {
+	var fullCalls atomic.Int64
+	full := newTestNode(t, "full", "full", func(w http.ResponseWriter, r *http.Request) {
+		fullCalls.Add(1)
+		http.Error(w, "bad gateway", http.StatusBadGateway)
+	})
+	archive := newTestNode(t, "archive", "archive", jsonRPCResult("from-archive"))
+	gateway := NewGateway([]*Node{archive, full}, NewMetrics("test"))
+
+	payload := []byte(`{"jsonrpc":"2.0","id":1,"method":"eth_blockNumber"}`)
+	calls, _, _ := ParsePayload(payload)
+	reply, err := gateway.Forward(context.Background(), payload, calls)
+	if err != nil || reply.StatusCode != http.StatusOK || !strings.Contains(string(reply.Body), "from-archive") {
+		t.Fatalf("expected the archive node to answer after failover, got %+v err=%v", reply, err)
+	}
+
+	// The full node is out of rotation now, so the next call skips it.
+	if _, err := gateway.Forward(context.Background(), payload, calls); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fullCalls.Load() != 1 {
+		t.Fatalf("expected the failed node to be skipped, got %d calls", fullCalls.Load())
+	}
+
+	status := map[string]NodeStatus{}
+	for _, s := range gateway.Status() {
+		status[s.Name] = s
+	}
+	if status["full"].Healthy || status["full"].Failovers != 1 || status["full"].Routed[RouteDefault] != 1 {
+		t.Fatalf("unexpected full node status: %+v", status["full"])
+	}
+	if status["archive"].Routed[RouteFailover] != 1 || status["archive"].Routed[RouteDefault] != 1 {
+		t.Fatalf("unexpected archive node status: %+v", status["archive"])
+	}
+
+	var metrics strings.Builder
+	_ = gateway.Metrics.WritePrometheus(&metrics)
+	for _, want := range []string{
+		`test_failovers_total{upstream="full",cause="http_5xx"} 1`,
+		`test_routed_total{upstream="archive",reason="failover"} 1`,
+		`test_upstream_healthy{upstream="full"} 0`,
+	} {
+		if !strings.Contains(metrics.String(), want) {
+			t.Fatalf("metrics missing %q:\n%s", want, metrics.String())
+		}
+	}
+}
+
+func TestGatewayFailsOverOnTimeout(t *testing.T) {
+	slow := newTestNode(t, "full", "full", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(500 * time.Millisecond):
+		}
+	})
+	fast := newTestNode(t, "full-2", "full", jsonRPCResult("fast"))
+	gateway := NewGateway([]*Node{slow, fast}, NewMetrics("test"))
+	gateway.AttemptTimeout = 50 * time.Millisecond
+
+	payload := []byte(`{"jsonrpc":"2.0","id":1,"method":"eth_chainId"}`)
+	calls, _, _ := ParsePayload(payload)
+	reply, err := gateway.Forward(context.Background(), payload, calls)
+	if err != nil || !strings.Contains(string(reply.Body), "fast") {
+		t.Fatalf("expected failover to the second node, got %+v err=%v", reply, err)
+	}
+	if status := gateway.Status()[0]; status.Healthy || status.Failovers != 1 {
+		t.Fatalf("expected the slow node to be marked unhealthy, got %+v", status)
+	}
+}
+
+func TestGatewayDoesNotReplayWriteCalls(t *testing.T) {
+	var calls5xx, callsOK atomic.Int64
+	failing := newTestNode(t, "full", "full", func(w http.ResponseWriter, r *http.Request) {
+		calls5xx.Add(1)
+		http.Error(w, "bad gateway", http.StatusBadGateway)
+	})
+	healthy := newTestNode(t, "full-2", "full", func(w http.ResponseWriter, r *http.Request) {
+		callsOK.Add(1)
+		jsonRPCResult("0xhash")(w, r)
+	})
+	gateway := NewGateway([]*Node{failing, healthy}, NewMetrics("test"))
+
+	payload := []byte(`[{"jsonrpc":"2.0","id":1,"method":"eth_chainId"},{"jsonrpc":"2.0","id":2,"method":"eth_sendRawTransaction","params":["0x01"]}]`)
+	calls, _, _ := ParsePayload(payload)
+	reply, err := gateway.Forward(context.Background(), payload, calls)
+	if err != nil || reply.StatusCode != http.StatusBadGateway {
+		t.Fatalf("expected the 5xx to be passed through, got %+v err=%v", reply, err)
+	}
+	if calls5xx.Load() != 1 || callsOK.Load() != 0 {
+		t.Fatalf("expected the write not to be replayed, got %d and %d calls", calls5xx.Load(), callsOK.Load())
+	}
+
+	// A node that cannot be reached never saw the call, so moving on is safe.
+	unreachable := newTestNode(t, "down", "full", jsonRPCResult("unused"))
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+	unreachable.HTTP.URL = closed.URL
+	gateway = NewGateway([]*Node{unreachable, healthy}, NewMetrics("test"))
+	single := []byte(`{"jsonrpc":"2.0","id":1,"method":"eth_sendRawTransaction","params":["0x01"]}`)
+	calls, _, _ = ParsePayload(single)
+	reply, err = gateway.Forward(context.Background(), single, calls)
+	if err != nil || !strings.Contains(string(reply.Body), "0xhash") || callsOK.Load() != 1 {
+		t.Fatalf("expected failover after a dial error, got %+v err=%v", reply, err)
+	}
+}
+
+func TestIsWriteCall(t *testing.T) {
+	for method, want := range map[string]bool{
+		"eth_sendRawTransaction":  true,
+		"eth_sendTransaction":     true,
+		"personal_unlockAccount":  true,
+		"author_submitExtrinsic":  true,
+		"sendTransaction":         true,
+		"broadcast_tx_sync":       true,
+		"eth_call":                false,
+		"eth_getTransactionCount": false,
+		"getLatestBlockhash":      false,
+	} {
+		if got := IsWriteCall(method); got != want {
+			t.Errorf("IsWriteCall(%q) = %v, want %v", method, got, want)
+		}
+	}
+}
+
+func TestGatewayRoutesArchiveCalls(t *testing.T) {
+	full := newTestNode(t, "full", "full", jsonRPCResult("full"))
+	archive := newTestNode(t, "archive", "archive", jsonRPCResult("archive"))
+	gateway := NewGateway([]*Node{archive, full}, NewMetrics("test"))
+
+	for payload, want := range map[string]string{
+		`{"jsonrpc":"2.0","id":1,"method":"eth_blockNumber"}`:                                                                "full",
+		`{"jsonrpc":"2.0","id":2,"method":"debug_traceTransaction","params":["0xabc"]}`:                                      "archive",
+		`{"jsonrpc":"2.0","id":3,"method":"eth_getBalance","params":["0x1", "0x10"]}`:                                        "archive",
+		`{"jsonrpc":"2.0","id":4,"method":"eth_getBalance","params":["0x1", "latest"]}`:                                      "full",
+		`[{"jsonrpc":"2.0","id":5,"method":"eth_chainId"},{"jsonrpc":"2.0","id":6,"method":"trace_block","params":["0x1"]}]`: "archive",
+	} {
+		calls, _, err := ParsePayload([]byte(payload))
+		if err != nil {
+			t.Fatalf("parse %s: %v", payload, err)
+		}
+		reply, err := gateway.Forward(context.Background(), []byte(payload), calls)
+		if err != nil {
+			t.Fatalf("forward %s: %v", payload, err)
+		}
+		if !strings.Contains(string(reply.Body), `"`+want+`"`) {
+			t.Fatalf("%s: expected the %s node, got %s", payload, want, reply.Body)
+		}
+	}
+}
+
+func TestNeedsArchive(t *testing.T) {
+	tests := []struct {
+		method string
+		params string
+		head   uint64
+		want   bool
+	}{
+		{"debug_traceTransaction", `["0xabc"]`, 0, true},
+		{"trace_block", `["latest"]`, 0, true},
+		{"eth_blockNumber", ``, 0, false},
+		{"eth_getBalance", `["0x1"]`, 0, false},
+		{"eth_getBalance", `["0x1","latest"]`, 1000, false},
+		{"eth_getBalance", `["0x1","earliest"]`, 1000, true},
+		{"eth_getBalance", `["0x1","0x3e8"]`, 1000, false},
+		{"eth_getBalance", `["0x1","0x64"]`, 1000, true},
+		{"eth_getBalance", `["0x1","0x3e8"]`, 0, true},
+		{"eth_call", `[{"to":"0x1"},{"blockHash":"0xabc"}]`, 1000, true},
+		{"eth_call", `[{"to":"0x1"},{"blockNumber":"0x3e0"}]`, 1000, false},
+		{"eth_getStorageAt", `["0x1","0x0","0x1"]`, 1000, true},
+	}
+	for _, tt := range tests {
+		call := Call{Method: tt.method, Params: json.RawMessage(tt.params)}
+		if got := NeedsArchive(call, tt.head); got != tt.want {
+			t.Errorf("NeedsArchive(%s %s, head %d) = %v, want %v", tt.method, tt.params, tt.head, got, tt.want)
+		}
+	}
+}
+
+func TestGatewayHealthCheckDropsLaggingNodes(t *testing.T) {
+	head := func(n int) http.HandlerFunc {
+		return jsonRPCResult(fmt.Sprintf("0x%x", n))
+	}
+	current := newTestNode(t, "archive", "archive", head(1000))
+	lagging := newTestNode(t, "full", "full", head(900))
+	down := newTestNode(t, "full-2", "full", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	})
+	gateway := NewGateway([]*Node{current, lagging, down}, NewMetrics("test"))
+	gateway.CheckHealth(context.Background(), time.Second)
+
+	status := gateway.Status()
+	if !status[0].Healthy || status[0].HeadBlock != 1000 || status[0].CheckedAt == nil {
+		t.Fatalf("expected the current node to be healthy: %+v", status[0])
+	}
+	if status[1].Healthy || !strings.Contains(status[1].LastError, "lagging 100 blocks") {
+		t.Fatalf("expected the lagging node to be dropped: %+v", status[1])
+	}
+	if status[2].Healthy {
+		t.Fatalf("expected the failing node to be dropped: %+v", status[2])
+	}
+
+	// Plain calls fall back to the archive node while no full node is healthy.
+	payload := []byte(`{"jsonrpc":"2.0","id":1,"method":"eth_chainId"}`)
+	calls, _, _ := ParsePayload(payload)
+	if _, err := gateway.Forward(context.Background(), payload, calls); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if routed := gateway.Status()[0].Routed[RouteDefault]; routed != 1 {
+		t.Fatalf("expected the healthy archive node to take the call, got %+v", gateway.Status())
+	}
+}
@@ -0,0 +1,267 @@
+package proxy
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// latencyBuckets are the upper bounds, in seconds, of the latency histogram.
+var latencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Error classes recorded for failed calls.
+const (
+	ErrorRateLimited     = "rate_limited"
+	ErrorHTTP4xx         = "http_4xx"
+	ErrorHTTP5xx         = "http_5xx"
+	ErrorRPC             = "rpc_error"
+	ErrorInvalidResponse = "invalid_response"
+	ErrorUnavailable     = "upstream_unavailable"
+)
+
+// Metrics aggregates per-method request counts, error counts, and latency
+// histograms, and renders them in the Prometheus text exposition format.
+type Metrics struct {
+	namespace string
+	started   time.Time
+
+	mu       sync.Mutex
+	series   map[seriesKey]*series
+	counters map[string]*counter
+}
+
+type seriesKey struct {
+	method    string
+	transport string
+}
+
+type series struct {
+	requests uint64
+	errors   map[string]uint64
+	sum      time.Duration
+	max      time.Duration
+	buckets  []uint64
+}
+
+// counter is a labelled counter or gauge registered with Add or Set.
+type counter struct {
+	help   string
+	kind   string
+	values map[string]float64
+}
+
+// MethodStats is the aggregated view of one method on one transport.
+type MethodStats struct {
+	Method    string            `json:"method"`
+	Transport string            `json:"transport"`
+	Requests  uint64            `json:"requests"`
+	Errors    uint64            `json:"errors"`
+	ErrorsBy  map[string]uint64 `json:"errors_by_class,omitempty"`
+	AvgMs     float64           `json:"avg_ms"`
+	MaxMs     float64           `json:"max_ms"`
+}
+
+// NewMetrics returns an empty registry whose metric names start with namespace.
+func NewMetrics(namespace string) *Metrics {
+	return &Metrics{
+		namespace: namespace,
+		started:   time.Now(),
+		series:    map[seriesKey]*series{},
+		counters:  map[string]*counter{},
+	}
+}
+
+// Observe records one call. errorClass is empty for successful calls.
+func (m *Metrics) Observe(method, transport string, latency time.Duration, errorClass string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := seriesKey{method: method, transport: transport}
+	s, ok := m.series[key]
+	if !ok {
+		s = &series{errors: map[string]uint64{}, buckets: make([]uint64, len(latencyBuckets))}
+		m.series[key] = s
+	}
+	s.requests++
+	if errorClass != "" {
+		s.errors[errorClass]++
+	}
+	s.sum += latency
+	s.max = max(s.max, latency)
+	seconds := latency.Seconds()
+	for i, bound := range latencyBuckets {
+		if seconds <= bound {
+			s.buckets[i]++
+		}
+	}
+}
+
+// Add increments the counter name{labels} by delta. Labels are key/value pairs.
+func (m *Metrics) Add(name, help string, delta float64, labels ...string) {
+	m.update(name, help, "counter", labels, func(v float64) float64 { return v + delta })
+}
+
+// Set sets the gauge name{labels} to value. Labels are key/value pairs.
+func (m *Metrics) Set(name, help string, value float64, labels ...string) {
+	m.update(name, help, "gauge", labels, func(float64) float64 { return value })
+}
+
+func (m *Metrics) update(name, help, kind string, labels []string, apply func(float64) float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	c, ok := m.counters[name]
+	if !ok {
+		c = &counter{help: help, kind: kind, values: map[string]float64{}}
+		m.counters[name] = c
+	}
+	key := formatLabels(labels...)
+	c.values[key] = apply(c.values[key])
+}
+
+// Snapshot returns per-method statistics ordered by request count.
+func (m *Metrics) Snapshot() []MethodStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stats := make([]MethodStats, 0, len(m.series))
+	for key, s := range m.series {
+		st := MethodStats{
+			Method:    key.method,
+			Transport: key.transport,
+			Requests:  s.requests,
+			MaxMs:     float64(s.max.Microseconds()) / 1000,
+		}
+		if s.requests > 0 {
+			st.AvgMs = float64(s.sum.Microseconds()) / 1000 / float64(s.requests)
+		}
+		for class, n := range s.errors {
+			st.Errors += n
+			if st.ErrorsBy == nil {
+				st.ErrorsBy = map[string]uint64{}
+			}
+			st.ErrorsBy[class] = n
+		}
+		stats = append(stats, st)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Requests != stats[j].Requests {
+			return stats[i].Requests > stats[j].Requests
+		}
+		if stats[i].Method != stats[j].Method {
+			return stats[i].Method < stats[j].Method
+		}
+		return stats[i].Transport < stats[j].Transport
+	})
+	return stats
+}
+
+// Uptime reports how long the registry has been collecting.
+func (m *Metrics) Uptime() time.Duration {
+	return time.Since(m.started)
+}
+
+// ServeHTTP exposes the metrics for scraping.
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_ = m.WritePrometheus(w)
+}
+
+// WritePrometheus renders every metric in the Prometheus text format.
+func (m *Metrics) WritePrometheus(w io.Writer) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var b strings.Builder
+	keys := make([]seriesKey, 0, len(m.series))
+	for key := range m.series {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].method != keys[j].method {
+			return keys[i].method < keys[j].method
+		}
+		return keys[i].transport < keys[j].transport
+	})
+
+	requests := m.namespace + "_requests_total"
+	fmt.Fprintf(&b, "# HELP %s JSON-RPC calls forwarded, by method and transport.\n# TYPE %s counter\n", requests, requests)
+	for _, key := range keys {
+		fmt.Fprintf(&b, "%s%s %d\n", requests, formatLabels("method", key.method, "transport", key.transport), m.series[key].requests)
+	}
+
+	errorsName := m.namespace + "_errors_total"
+	fmt.Fprintf(&b, "# HELP %s Failed JSON-RPC calls, by method, transport, and error class.\n# TYPE %s counter\n", errorsName, errorsName)
+	for _, key := range keys {
+		s := m.series[key]
+		classes := make([]string, 0, len(s.errors))
+		for class := range s.errors {
+			classes = append(classes, class)
+		}
+		sort.Strings(classes)
+		for _, class := range classes {
+			fmt.Fprintf(&b, "%s%s %d\n", errorsName, formatLabels("method", key.method, "transport", key.transport, "class", class), s.errors[class])
+		}
+	}
+
+	latency := m.namespace + "_request_duration_seconds"
+	fmt.Fprintf(&b, "# HELP %s Upstream round-trip time of JSON-RPC calls.\n# TYPE %s histogram\n", latency, latency)
+	for _, key := range keys {
+		s := m.series[key]
+		for i, bound := range latencyBuckets {
+			le := strconv.FormatFloat(bound, 'g', -1, 64)
+			fmt.Fprintf(&b, "%s_bucket%s %d\n", latency, formatLabels("method", key.method, "transport", key.transport, "le", le), s.buckets[i])
+		}
+		fmt.Fprintf(&b, "%s_bucket%s %d\n", latency, formatLabels("method", key.method, "transport", key.transport, "le", "+Inf"), s.requests)
+		fmt.Fprintf(&b, "%s_sum%s %g\n", latency, formatLabels("method", key.method, "transport", key.transport), s.sum.Seconds())
+		fmt.Fprintf(&b, "%s_count%s %d\n", latency, formatLabels("method", key.method, "transport", key.transport), s.requests)
+	}
+
+	names := make([]string, 0, len(m.counters))
+	for name := range m.counters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		c := m.counters[name]
+		fullName := m.namespace + "_" + name
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s %s\n", fullName, c.help, fullName, c.kind)
+		labelSets := make([]string, 0, len(c.values))
+		for labels := range c.values {
+			labelSets = append(labelSets, labels)
+		}
+		sort.Strings(labelSets)
+		for _, labels := range labelSets {
+			fmt.Fprintf(&b, "%s%s %g\n", fullName, labels, c.values[labels])
+		}
+	}
+
+	uptime := m.namespace + "_uptime_seconds"
+	fmt.Fprintf(&b, "# HELP %s Seconds since the process started.\n# TYPE %s gauge\n%s %g\n", uptime, uptime, uptime, time.Since(m.started).Seconds())
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// formatLabels renders key/value pairs as a Prometheus label set.
+func formatLabels(pairs ...string) string {
+	if len(pairs) < 2 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteByte('{')
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(pairs[i])
+		b.WriteString("=")
+		b.WriteString(strconv.Quote(pairs[i+1]))
+	}
+	b.WriteByte('}')
+	return b.String()
+}
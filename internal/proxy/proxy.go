@@ -0,0 +1,398 @@
+// Package proxy serves a local JSON-RPC endpoint that forwards requests to a
+// Dwellir node, so applications can talk to localhost without embedding API
+// keys in their own configuration.
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/net/websocket"
+
+	"github.com/dwellir-public/cli/internal/rpc"
+)
+
+// maxRequestBytes caps the size of a request body accepted from clients.
+const maxRequestBytes = 16 << 20
+
+// Transports recorded in metrics.
+const (
+	TransportHTTP = "http"
+	TransportWS   = "ws"
+)
+
+// Call is one JSON-RPC request of a forwarded payload.
+type Call struct {
+	ID     json.RawMessage `json:"id,omitempty"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params,omitempty"`
+}
+
+// ParsePayload decodes a single JSON-RPC request or a batch. batch reports
+// whether the payload was an array.
+func ParsePayload(body []byte) (calls []Call, batch bool, err error) {
+	trimmed := strings.TrimSpace(string(body))
+	if strings.HasPrefix(trimmed, "[") {
+		if err := json.Unmarshal([]byte(trimmed), &calls); err != nil {
+			return nil, true, fmt.Errorf("invalid JSON-RPC batch: %w", err)
+		}
+		if len(calls) == 0 {
+			return nil, true, errors.New("empty JSON-RPC batch")
+		}
+		return calls, true, nil
+	}
+	var call Call
+	if err := json.Unmarshal([]byte(trimmed), &call); err != nil {
+		return nil, false, fmt.Errorf("invalid JSON-RPC request: %w", err)
+	}
+	return []Call{call}, false, nil
+}
+
+// Reply is an upstream answer relayed to the client as-is.
+type Reply struct {
+	StatusCode int
+	Body       []byte
+	Latency    time.Duration
+}
+
+// Backend forwards an encoded JSON-RPC payload upstream. calls is the decoded
+// form of payload, for backends that route or answer by method.
+type Backend interface {
+	Forward(ctx context.Context, payload []byte, calls []Call) (*Reply, error)
+}
+
+// HTTPBackend forwards every payload to a single HTTP(S) endpoint.
+type HTTPBackend struct {
+	client *rpc.Client
+}
+
+// NewHTTPBackend returns a backend for url, which may carry an API key.
+func NewHTTPBackend(url string) *HTTPBackend {
+	return &HTTPBackend{client: rpc.NewClient(url)}
+}
+
+func (b *HTTPBackend) Forward(ctx context.Context, payload []byte, calls []Call) (*Reply, error) {
+	body, status, latency, err := b.client.Post(ctx, json.RawMessage(payload))
+	if err != nil {
+		return nil, err
+	}
+	return &Reply{StatusCode: status, Body: body, Latency: latency}, nil
+}
+
+// Handler serves JSON-RPC over HTTP POST and WebSocket, and the collected
+// metrics on /metrics.
+type Handler struct {
+	// HTTP forwards POSTed payloads; nil disables HTTP forwarding.
+	HTTP Backend
+	// DialWebSocket opens an upstream WebSocket connection; nil disables
+	// WebSocket forwarding.
+	DialWebSocket func(ctx context.Context) (*websocket.Conn, error)
+	Metrics       *Metrics
+	// AllowedOrigins lists browser origins besides localhost that may use the
+	// proxy; "*" allows any origin.
+	AllowedOrigins []string
+
+	wsConns atomic.Int64
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/metrics" && r.Method == http.MethodGet {
+		h.Metrics.ServeHTTP(w, r)
+		return
+	}
+
+	origin := r.Header.Get("Origin")
+	if origin != "" && !h.originAllowed(origin) {
+		http.Error(w, "origin not allowed; restart the proxy with --allow-origin "+origin, http.StatusForbidden)
+		return
+	}
+	if origin != "" {
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+		w.Header().Set("Vary", "Origin")
+	}
+
+	switch {
+	case r.Method == http.MethodOptions:
+		w.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodPost:
+		if h.HTTP == nil {
+			http.Error(w, "HTTP forwarding is not available for this endpoint; connect over WebSocket", http.StatusNotImplemented)
+			return
+		}
+		h.serveHTTP(w, r)
+	case r.Method == http.MethodGet && strings.EqualFold(r.Header.Get("Upgrade"), "websocket"):
+		if h.DialWebSocket == nil {
+			http.Error(w, "WebSocket forwarding is not available for this endpoint", http.StatusNotImplemented)
+			return
+		}
+		server := websocket.Server{
+			// The origin was checked above.
+			Handshake: func(*websocket.Config, *http.Request) error { return nil },
+			Handler:   h.serveWebSocket,
+		}
+		server.ServeHTTP(w, r)
+	default:
+		w.Header().Set("Allow", "POST, OPTIONS")
+		http.Error(w, "send JSON-RPC requests with POST, or connect over WebSocket", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *Handler) originAllowed(origin string) bool {
+	for _, allowed := range h.AllowedOrigins {
+		if allowed == "*" || strings.EqualFold(strings.TrimRight(allowed, "/"), origin) {
+			return true
+		}
+	}
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	host := u.Hostname()
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+func (h *Handler) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestBytes))
+	if err != nil {
+		writeJSON(w, http.StatusRequestEntityTooLarge, errorResponse(nil, -32600, "request body too large"))
+		return
+	}
+	calls, batch, err := ParsePayload(body)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse(nil, -32700, err.Error()))
+		return
+	}
+
+	reply, err := h.HTTP.Forward(r.Context(), body, calls)
+	if err != nil {
+		message := "upstream request failed: " + err.Error()
+		for _, call := range calls {
+			h.Metrics.Observe(methodName(call), TransportHTTP, 0, ErrorUnavailable)
+		}
+		if !batch {
+			writeJSON(w, http.StatusBadGateway, errorResponse(calls[0].ID, -32603, message))
+			return
+		}
+		replies := make([]json.RawMessage, 0, len(calls))
+		for _, call := range calls {
+			replies = append(replies, errorResponse(call.ID, -32603, message))
+		}
+		data, _ := json.Marshal(replies)
+		writeJSON(w, http.StatusBadGateway, data)
+		return
+	}
+
+	classes := ClassifyReply(reply.StatusCode, reply.Body, calls)
+	for i, call := range calls {
+		h.Metrics.Observe(methodName(call), TransportHTTP, reply.Latency, classes[i])
+	}
+	writeJSON(w, reply.StatusCode, reply.Body)
+}
+
+// ClassifyReply returns the error class of every call answered by an
+// upstream reply, or "" for calls that succeeded.
+func ClassifyReply(status int, body []byte, calls []Call) []string {
+	classes := make([]string, len(calls))
+	fill := func(class string) []string {
+		for i := range classes {
+			classes[i] = class
+		}
+		return classes
+	}
+	switch {
+	case status == http.StatusTooManyRequests:
+		return fill(ErrorRateLimited)
+	case status >= 500:
+		return fill(ErrorHTTP5xx)
+	}
+
+	responses, ok := parseResponses(body)
+	if !ok {
+		if status >= 400 {
+			return fill(ErrorHTTP4xx)
+		}
+		return fill(ErrorInvalidResponse)
+	}
+	byID := make(map[string]*rpc.Response, len(responses))
+	for i := range responses {
+		byID[idKey(responses[i].ID)] = &responses[i]
+	}
+	for i, call := range calls {
+		resp, found := byID[idKey(call.ID)]
+		switch {
+		case len(call.ID) == 0 && !found:
+			// Notifications get no response.
+		case !found && len(responses) == 1 && len(calls) == 1:
+			// Some nodes answer errors with a null id.
+			classes[i] = classifyResponse(status, &responses[0])
+		case !found:
+			classes[i] = ErrorInvalidResponse
+		default:
+			classes[i] = classifyResponse(status, resp)
+		}
+	}
+	return classes
+}
+
+func classifyResponse(status int, resp *rpc.Response) string {
+	switch {
+	case resp.Error != nil && resp.Error.Code == http.StatusTooManyRequests:
+		return ErrorRateLimited
+	case resp.Error != nil:
+		return ErrorRPC
+	case status >= 400:
+		return ErrorHTTP4xx
+	}
+	return ""
+}
+
+func parseResponses(body []byte) ([]rpc.Response, bool) {
+	trimmed := strings.TrimSpace(string(body))
+	if strings.HasPrefix(trimmed, "[") {
+		var responses []rpc.Response
+		if err := json.Unmarshal([]byte(trimmed), &responses); err != nil {
+			return nil, false
+		}
+		return responses, true
+	}
+	var resp rpc.Response
+	if err := json.Unmarshal([]byte(trimmed), &resp); err != nil || (resp.Result == nil && resp.Error == nil) {
+		return nil, false
+	}
+	return []rpc.Response{resp}, true
+}
+
+func (h *Handler) serveWebSocket(client *websocket.Conn) {
+	defer client.Close()
+	ctx := client.Request().Context()
+	upstream, err := h.DialWebSocket(ctx)
+	if err != nil {
+		h.Metrics.Add("websocket_dial_failures_total", "Upstream WebSocket connections that could not be opened.", 1)
+		return
+	}
+	defer upstream.Close()
+
+	h.Metrics.Set("websocket_connections", "Open client WebSocket connections.", float64(h.wsConns.Add(1)))
+	defer func() {
+		h.Metrics.Set("websocket_connections", "Open client WebSocket connections.", float64(h.wsConns.Add(-1)))
+	}()
+
+	type pendingCall struct {
+		method string
+		sent   time.Time
+	}
+	var mu sync.Mutex
+	pending := map[string]pendingCall{}
+
+	done := make(chan struct{}, 2)
+	go func() {
+		defer func() { done <- struct{}{} }()
+		for {
+			var msg string
+			if err := websocket.Message.Receive(client, &msg); err != nil {
+				return
+			}
+			if calls, _, err := ParsePayload([]byte(msg)); err == nil {
+				now := time.Now()
+				mu.Lock()
+				for _, call := range calls {
+					if len(call.ID) > 0 {
+						pending[idKey(call.ID)] = pendingCall{method: methodName(call), sent: now}
+					}
+				}
+				mu.Unlock()
+			}
+			if err := websocket.Message.Send(upstream, msg); err != nil {
+				return
+			}
+		}
+	}()
+	go func() {
+		defer func() { done <- struct{}{} }()
+		for {
+			var msg string
+			if err := websocket.Message.Receive(upstream, &msg); err != nil {
+				return
+			}
+			if responses, ok := parseResponses([]byte(msg)); ok {
+				now := time.Now()
+				mu.Lock()
+				for i := range responses {
+					key := idKey(responses[i].ID)
+					call, found := pending[key]
+					if !found {
+						continue
+					}
+					delete(pending, key)
+					h.Metrics.Observe(call.method, TransportWS, now.Sub(call.sent), classifyResponse(http.StatusOK, &responses[i]))
+				}
+				mu.Unlock()
+			}
+			if err := websocket.Message.Send(client, msg); err != nil {
+				return
+			}
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+	}
+}
+
+func methodName(call Call) string {
+	if call.Method == "" {
+		return "unknown"
+	}
+	return call.Method
+}
+
+func idKey(raw json.RawMessage) string {
+	return strings.TrimSpace(string(raw))
+}
+
+func errorResponse(id json.RawMessage, code int, message string) []byte {
+	if len(id) == 0 {
+		id = json.RawMessage("null")
+	}
+	data, _ := json.Marshal(rpc.Response{
+		JSONRPC: "2.0",
+		ID:      id,
+		Error:   &rpc.Error{Code: code, Message: message},
+	})
+	return data
+}
+
+func writeJSON(w http.ResponseWriter, status int, body []byte) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(body)
+}
+
+// Summary is reported when a proxy shuts down.
+type Summary struct {
+	Chain         string        `json:"chain"`
+	Network       string        `json:"network"`
+	NodeType      string        `json:"node_type,omitempty"`
+	Listen        string        `json:"listen"`
+	HTTPEndpoint  string        `json:"http_endpoint,omitempty"`
+	WSEndpoint    string        `json:"ws_endpoint,omitempty"`
+	UptimeSeconds float64       `json:"uptime_seconds"`
+	Methods       []MethodStats `json:"methods"`
+}
@@ -0,0 +1,222 @@
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/net/websocket"
+
+	"github.com/dwellir-public/cli/internal/rpc"
+)
+
+const testKey = "secret-key-123"
+
+func newUpstream(t *testing.T, handler http.HandlerFunc) (*httptest.Server, string) {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/"+testKey, handler)
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server, server.URL + "/" + testKey
+}
+
+func TestHandlerForwardsHTTPAndRecordsMetrics(t *testing.T) {
+	_, upstreamURL := newUpstream(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.Contains(string(body), "eth_fail"):
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":2,"error":{"code":-32601,"message":"method not found"}}`))
+		case strings.HasPrefix(string(body), "["):
+			_, _ = w.Write([]byte(`[{"jsonrpc":"2.0","id":3,"result":"0x1"},{"jsonrpc":"2.0","id":4,"result":"0x2"}]`))
+		default:
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x10"}`))
+		}
+	})
+	metrics := NewMetrics("test")
+	local := httptest.NewServer(&Handler{HTTP: NewHTTPBackend(upstreamURL), Metrics: metrics})
+	defer local.Close()
+
+	for _, payload := range []string{
+		`{"jsonrpc":"2.0","id":1,"method":"eth_blockNumber"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"eth_fail"}`,
+		`[{"jsonrpc":"2.0","id":3,"method":"eth_chainId"},{"jsonrpc":"2.0","id":4,"method":"eth_blockNumber"}]`,
+	} {
+		resp, err := http.Post(local.URL, "application/json", strings.NewReader(payload))
+		if err != nil {
+			t.Fatalf("post: %v", err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK || !strings.Contains(string(body), `"jsonrpc":"2.0"`) {
+			t.Fatalf("unexpected response %d: %s", resp.StatusCode, body)
+		}
+	}
+
+	stats := map[string]MethodStats{}
+	for _, s := range metrics.Snapshot() {
+		stats[s.Method] = s
+	}
+	if stats["eth_blockNumber"].Requests != 2 || stats["eth_chainId"].Requests != 1 {
+		t.Fatalf("unexpected request counts: %+v", stats)
+	}
+	if fail := stats["eth_fail"]; fail.Errors != 1 || fail.ErrorsBy[ErrorRPC] != 1 {
+		t.Fatalf("expected an rpc_error for eth_fail, got %+v", fail)
+	}
+
+	resp, err := http.Get(local.URL + "/metrics")
+	if err != nil {
+		t.Fatalf("metrics: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	for _, want := range []string{
+		`test_requests_total{method="eth_blockNumber",transport="http"} 2`,
+		`test_errors_total{method="eth_fail",transport="http",class="rpc_error"} 1`,
+		`test_request_duration_seconds_count{method="eth_chainId",transport="http"} 1`,
+		`test_request_duration_seconds_bucket{method="eth_chainId",transport="http",le="+Inf"} 1`,
+	} {
+		if !strings.Contains(string(body), want) {
+			t.Fatalf("metrics missing %q:\n%s", want, body)
+		}
+	}
+}
+
+func TestHandlerHidesKeyWhenUpstreamIsDown(t *testing.T) {
+	server, upstreamURL := newUpstream(t, func(http.ResponseWriter, *http.Request) {})
+	server.Close()
+
+	metrics := NewMetrics("test")
+	local := httptest.NewServer(&Handler{HTTP: NewHTTPBackend(upstreamURL), Metrics: metrics})
+	defer local.Close()
+
+	resp, err := http.Post(local.URL, "application/json", strings.NewReader(`{"jsonrpc":"2.0","id":7,"method":"eth_blockNumber"}`))
+	if err != nil {
+		t.Fatalf("post: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadGateway {
+		t.Fatalf("expected 502, got %d: %s", resp.StatusCode, body)
+	}
+	if strings.Contains(string(body), testKey) {
+		t.Fatalf("API key leaked in error response: %s", body)
+	}
+	var reply rpc.Response
+	if err := json.Unmarshal(body, &reply); err != nil || reply.Error == nil || string(reply.ID) != "7" {
+		t.Fatalf("expected a JSON-RPC error for id 7, got %s", body)
+	}
+	if stats := metrics.Snapshot(); len(stats) != 1 || stats[0].ErrorsBy[ErrorUnavailable] != 1 {
+		t.Fatalf("expected an upstream_unavailable error, got %+v", stats)
+	}
+}
+
+func TestHandlerRejectsForeignOrigins(t *testing.T) {
+	_, upstreamURL := newUpstream(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x1"}`))
+	})
+	handler := &Handler{HTTP: NewHTTPBackend(upstreamURL), Metrics: NewMetrics("test"), AllowedOrigins: []string{"https://app.example"}}
+	local := httptest.NewServer(handler)
+	defer local.Close()
+
+	for origin, want := range map[string]int{
+		"":                      http.StatusOK,
+		"http://localhost:3000": http.StatusOK,
+		"http://127.0.0.1:5173": http.StatusOK,
+		"https://app.example":   http.StatusOK,
+		"https://evil.example":  http.StatusForbidden,
+	} {
+		req, _ := http.NewRequest(http.MethodPost, local.URL, strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"eth_chainId"}`))
+		if origin != "" {
+			req.Header.Set("Origin", origin)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("post: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != want {
+			t.Fatalf("origin %q: expected %d, got %d", origin, want, resp.StatusCode)
+		}
+	}
+}
+
+func TestHandlerForwardsWebSocket(t *testing.T) {
+	upstream := httptest.NewServer(websocket.Handler(func(ws *websocket.Conn) {
+		for {
+			var req rpc.Request
+			if err := websocket.JSON.Receive(ws, &req); err != nil {
+				return
+			}
+			_ = websocket.JSON.Send(ws, map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": req.Method})
+		}
+	}))
+	defer upstream.Close()
+
+	metrics := NewMetrics("test")
+	local := httptest.NewServer(&Handler{
+		Metrics: metrics,
+		DialWebSocket: func(ctx context.Context) (*websocket.Conn, error) {
+			return rpc.DialWebSocket(ctx, "ws"+strings.TrimPrefix(upstream.URL, "http"))
+		},
+	})
+	defer local.Close()
+
+	ws, err := websocket.Dial("ws"+strings.TrimPrefix(local.URL, "http"), "", "http://localhost/")
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer ws.Close()
+	_ = ws.SetDeadline(time.Now().Add(5 * time.Second))
+	if err := websocket.Message.Send(ws, `{"jsonrpc":"2.0","id":5,"method":"eth_chainId"}`); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	var reply rpc.Response
+	if err := websocket.JSON.Receive(ws, &reply); err != nil {
+		t.Fatalf("receive: %v", err)
+	}
+	if string(reply.ID) != "5" || string(reply.Result) != `"eth_chainId"` {
+		t.Fatalf("unexpected reply: %+v", reply)
+	}
+
+	// The reply is relayed before it is recorded; give the pipe a moment.
+	deadline := time.Now().Add(time.Second)
+	for {
+		stats := metrics.Snapshot()
+		if len(stats) == 1 && stats[0].Method == "eth_chainId" && stats[0].Transport == TransportWS {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected a ws observation, got %+v", stats)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestClassifyReply(t *testing.T) {
+	calls := []Call{{ID: json.RawMessage("1"), Method: "a"}, {ID: json.RawMessage("2"), Method: "b"}}
+	tests := []struct {
+		status int
+		body   string
+		want   []string
+	}{
+		{http.StatusTooManyRequests, `rate limited`, []string{ErrorRateLimited, ErrorRateLimited}},
+		{http.StatusBadGateway, `{"jsonrpc":"2.0","id":1,"result":"0x1"}`, []string{ErrorHTTP5xx, ErrorHTTP5xx}},
+		{http.StatusForbidden, `forbidden`, []string{ErrorHTTP4xx, ErrorHTTP4xx}},
+		{http.StatusOK, `<html>`, []string{ErrorInvalidResponse, ErrorInvalidResponse}},
+		{http.StatusOK, `[{"jsonrpc":"2.0","id":1,"result":"0x1"},{"jsonrpc":"2.0","id":2,"error":{"code":-32000,"message":"x"}}]`, []string{"", ErrorRPC}},
+		{http.StatusOK, `[{"jsonrpc":"2.0","id":2,"result":"0x1"}]`, []string{ErrorInvalidResponse, ""}},
+	}
+	for _, tt := range tests {
+		got := ClassifyReply(tt.status, []byte(tt.body), calls)
+		if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+			t.Fatalf("status %d body %s: got %v, want %v", tt.status, tt.body, got, tt.want)
+		}
+	}
+}
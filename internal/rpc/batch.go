@@ -0,0 +1,252 @@
+package rpc
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrBatchUnsupported is returned when an endpoint answers a batch with
+// something other than an array of responses or an error about its size.
+var ErrBatchUnsupported = errors.New("endpoint does not support JSON-RPC batches")
+
+// BatchItem is the outcome of one request inside a batch or parallel replay.
+type BatchItem struct {
+	ID             json.RawMessage `json:"id"`
+	Method         string          `json:"method"`
+	Result         json.RawMessage `json:"result,omitempty"`
+	Error          *Error          `json:"error,omitempty"`
+	TransportError string          `json:"transport_error,omitempty"`
+	HTTPStatus     int             `json:"http_status,omitempty"`
+	LatencyMs      float64         `json:"latency_ms"`
+}
+
+// OK reports whether the request produced a result.
+func (b BatchItem) OK() bool {
+	return b.Error == nil && b.TransportError == ""
+}
+
+// BatchSummary is the structured outcome of replaying a request set against a resolved endpoint.
+type BatchSummary struct {
+	Chain     string      `json:"chain"`
+	Network   string      `json:"network"`
+	NodeType  string      `json:"node_type,omitempty"`
+	Endpoint  string      `json:"endpoint"`
+	Mode      string      `json:"mode"`
+	Requests  int         `json:"requests"`
+	Succeeded int         `json:"succeeded"`
+	Failed    int         `json:"failed"`
+	TotalMs   float64     `json:"total_ms"`
+	Items     []BatchItem `json:"items"`
+}
+
+// ParseRequests reads a JSON array of request objects or NDJSON (one request
+// object per line). Missing jsonrpc versions are filled in and missing ids are
+// assigned the lowest numbers not used by any explicit id; duplicate ids are
+// rejected because responses are correlated by id.
+func ParseRequests(data []byte) ([]Request, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return nil, fmt.Errorf("no requests found in input")
+	}
+
+	var reqs []Request
+	if trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &reqs); err != nil {
+			return nil, fmt.Errorf("parsing request array: %w", err)
+		}
+	} else {
+		scanner := bufio.NewScanner(bytes.NewReader(trimmed))
+		scanner.Buffer(make([]byte, 0, 64*1024), maxResponseBytes)
+		line := 0
+		for scanner.Scan() {
+			line++
+			text := bytes.TrimSpace(scanner.Bytes())
+			if len(text) == 0 {
+				continue
+			}
+			var req Request
+			if err := json.Unmarshal(text, &req); err != nil {
+				return nil, fmt.Errorf("parsing request on line %d: %w", line, err)
+			}
+			reqs = append(reqs, req)
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("reading requests: %w", err)
+		}
+	}
+	if len(reqs) == 0 {
+		return nil, fmt.Errorf("no requests found in input")
+	}
+
+	// Explicit ids are collected first so assigned ones never collide with
+	// an id given later in the input.
+	seen := map[string]bool{}
+	for i := range reqs {
+		req := &reqs[i]
+		if strings.TrimSpace(req.Method) == "" {
+			return nil, fmt.Errorf("request %d is missing a method", i+1)
+		}
+		if req.JSONRPC == "" {
+			req.JSONRPC = "2.0"
+		}
+		if missingID(req.ID) {
+			continue
+		}
+		key := idKey(req.ID)
+		if seen[key] {
+			return nil, fmt.Errorf("duplicate request id %s", req.ID)
+		}
+		seen[key] = true
+	}
+	next := 1
+	for i := range reqs {
+		req := &reqs[i]
+		if !missingID(req.ID) {
+			continue
+		}
+		for seen[idKey(json.RawMessage(strconv.Itoa(next)))] {
+			next++
+		}
+		req.ID = json.RawMessage(strconv.Itoa(next))
+		seen[idKey(req.ID)] = true
+	}
+	return reqs, nil
+}
+
+// batchLimitWords appear in errors about a batch being too large.
+var batchLimitWords = []string{"limit", "too large", "too many", "exceed", "maximum"}
+
+func isBatchLimitMessage(message string) bool {
+	lower := strings.ToLower(message)
+	for _, word := range batchLimitWords {
+		if strings.Contains(lower, word) {
+			return true
+		}
+	}
+	return false
+}
+
+func missingID(id json.RawMessage) bool {
+	return len(id) == 0 || string(id) == "null"
+}
+
+// Batch sends reqs as a single JSON-RPC batch and returns one item per request,
+// in request order. Every item carries the latency of the whole batch.
+func (c *Client) Batch(ctx context.Context, reqs []Request) ([]BatchItem, error) {
+	body, status, latency, err := c.post(ctx, reqs)
+	if err != nil {
+		return nil, err
+	}
+
+	var responses []Response
+	if err := json.Unmarshal(body, &responses); err != nil {
+		if status == http.StatusTooManyRequests || (status >= 400 && !json.Valid(body)) {
+			return nil, &HTTPError{StatusCode: status, Body: strings.TrimSpace(string(body))}
+		}
+		// A single error object may reject this batch's size rather than
+		// batching itself; that calls for smaller batches, not none.
+		var single Response
+		if json.Unmarshal(body, &single) == nil && single.Error != nil && isBatchLimitMessage(single.Error.Message) {
+			return nil, single.Error
+		}
+		return nil, ErrBatchUnsupported
+	}
+
+	byID := make(map[string]Response, len(responses))
+	for _, resp := range responses {
+		byID[idKey(resp.ID)] = resp
+	}
+
+	items := make([]BatchItem, 0, len(reqs))
+	for _, req := range reqs {
+		item := BatchItem{
+			ID:         req.ID,
+			Method:     req.Method,
+			HTTPStatus: status,
+			LatencyMs:  millis(latency),
+		}
+		resp, ok := byID[idKey(req.ID)]
+		switch {
+		case !ok:
+			item.TransportError = "no response for request id"
+		case resp.Error != nil:
+			item.Error = resp.Error
+		default:
+			item.Result = resp.Result
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// Parallel sends each request individually with at most concurrency requests
+// in flight and returns items in request order.
+func (c *Client) Parallel(ctx context.Context, reqs []Request, concurrency int) []BatchItem {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	items := make([]BatchItem, len(reqs))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range jobs {
+				items[idx] = c.replay(ctx, reqs[idx])
+			}
+		}()
+	}
+	for idx := range reqs {
+		jobs <- idx
+	}
+	close(jobs)
+	wg.Wait()
+	return items
+}
+
+func (c *Client) replay(ctx context.Context, req Request) BatchItem {
+	item := BatchItem{ID: req.ID, Method: req.Method}
+	started := time.Now()
+	result, err := c.Do(ctx, req)
+	if err != nil {
+		item.LatencyMs = millis(time.Since(started))
+		item.TransportError = err.Error()
+		var httpErr *HTTPError
+		if errors.As(err, &httpErr) {
+			item.HTTPStatus = httpErr.StatusCode
+		}
+		return item
+	}
+	item.HTTPStatus = result.StatusCode
+	item.LatencyMs = millis(result.Latency)
+	if result.Response.Error != nil {
+		item.Error = result.Response.Error
+	} else {
+		item.Result = result.Response.Result
+	}
+	return item
+}
+
+// idKey normalizes an id so that 1 and "1" from a sloppy server still match
+// while staying distinct from other ids.
+func idKey(id json.RawMessage) string {
+	raw := strings.TrimSpace(string(id))
+	if unquoted, err := strconv.Unquote(raw); err == nil {
+		return unquoted
+	}
+	return raw
+}
+
+func millis(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
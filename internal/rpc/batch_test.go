@@ -0,0 +1,139 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestParseRequestsArrayAndNDJSON(t *testing.T) {
+	reqs, err := ParseRequests([]byte(`[{"method":"eth_chainId"},{"id":"b","method":"eth_blockNumber","params":[]}]`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(reqs) != 2 || string(reqs[0].ID) != "1" || reqs[0].JSONRPC != "2.0" || string(reqs[1].ID) != `"b"` {
+		t.Fatalf("unexpected requests: %+v", reqs)
+	}
+
+	reqs, err = ParseRequests([]byte("{\"method\":\"eth_chainId\"}\n\n{\"method\":\"net_version\"}\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(reqs) != 2 || reqs[1].Method != "net_version" || string(reqs[1].ID) != "2" {
+		t.Fatalf("unexpected requests: %+v", reqs)
+	}
+
+	reqs, err = ParseRequests([]byte(`[{"method":"a"},{"id":1,"method":"b"},{"method":"c"},{"id":"3","method":"d"}]`))
+	if err != nil {
+		t.Fatalf("expected assigned ids to avoid explicit ones, got %v", err)
+	}
+	if string(reqs[0].ID) != "2" || string(reqs[2].ID) != "4" {
+		t.Fatalf("unexpected assigned ids: %s, %s", reqs[0].ID, reqs[2].ID)
+	}
+
+	if _, err := ParseRequests([]byte(`[{"id":1,"method":"a"},{"id":"1","method":"b"}]`)); err == nil {
+		t.Fatal("expected duplicate id error")
+	}
+	if _, err := ParseRequests([]byte(`[{"id":1}]`)); err == nil {
+		t.Fatal("expected missing method error")
+	}
+}
+
+func TestBatchCorrelatesResponsesByID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var reqs []Request
+		if err := json.NewDecoder(r.Body).Decode(&reqs); err != nil {
+			t.Fatalf("failed to decode batch: %v", err)
+		}
+		// Answer out of order and omit the last request.
+		_, _ = w.Write([]byte(`[
+			{"jsonrpc":"2.0","id":2,"error":{"code":-32601,"message":"method not found"}},
+			{"jsonrpc":"2.0","id":1,"result":"0x1"}
+		]`))
+	}))
+	defer server.Close()
+
+	reqs, err := ParseRequests([]byte(`[{"method":"eth_chainId"},{"method":"nope"},{"method":"eth_blockNumber"}]`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	items, err := NewClient(server.URL).Batch(context.Background(), reqs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != 3 {
+		t.Fatalf("expected 3 items, got %d", len(items))
+	}
+	if !items[0].OK() || string(items[0].Result) != `"0x1"` {
+		t.Fatalf("unexpected first item: %+v", items[0])
+	}
+	if items[1].Error == nil || items[1].Error.Code != -32601 {
+		t.Fatalf("unexpected second item: %+v", items[1])
+	}
+	if items[2].TransportError == "" {
+		t.Fatalf("expected missing response to be reported: %+v", items[2])
+	}
+}
+
+func TestBatchReportsUnsupportedEndpoint(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":null,"error":{"code":-32600,"message":"batch requests are not supported"}}`))
+	}))
+	defer server.Close()
+
+	reqs, _ := ParseRequests([]byte(`[{"method":"eth_chainId"}]`))
+	_, err := NewClient(server.URL).Batch(context.Background(), reqs)
+	if !errors.Is(err, ErrBatchUnsupported) {
+		t.Fatalf("expected ErrBatchUnsupported, got %v", err)
+	}
+}
+
+func TestBatchReportsSizeLimitAsRPCError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":null,"error":{"code":-32600,"message":"batch size limit exceeded (max 100)"}}`))
+	}))
+	defer server.Close()
+
+	reqs, _ := ParseRequests([]byte(`[{"method":"eth_chainId"}]`))
+	_, err := NewClient(server.URL).Batch(context.Background(), reqs)
+	var rpcErr *Error
+	if errors.Is(err, ErrBatchUnsupported) || !errors.As(err, &rpcErr) || rpcErr.Code != -32600 {
+		t.Fatalf("expected the size limit as a JSON-RPC error, got %v", err)
+	}
+}
+
+func TestParallelKeepsRequestOrder(t *testing.T) {
+	var inFlight, peak atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			prev := peak.Load()
+			if current <= prev || peak.CompareAndSwap(prev, current) {
+				break
+			}
+		}
+		var req Request
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": req.Method})
+	}))
+	defer server.Close()
+
+	reqs, _ := ParseRequests([]byte("{\"method\":\"a\"}\n{\"method\":\"b\"}\n{\"method\":\"c\"}\n{\"method\":\"d\"}\n"))
+	items := NewClient(server.URL).Parallel(context.Background(), reqs, 2)
+	for i, want := range []string{`"a"`, `"b"`, `"c"`, `"d"`} {
+		if !items[i].OK() || string(items[i].Result) != want {
+			t.Fatalf("item %d: unexpected %+v", i, items[i])
+		}
+	}
+	if peak.Load() > 2 {
+		t.Fatalf("expected at most 2 requests in flight, saw %d", peak.Load())
+	}
+}
@@ -0,0 +1,242 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dwellir-public/cli/internal/stats"
+)
+
+const (
+	defaultBenchTimeout     = 10 * time.Second
+	defaultBenchMaxInFlight = 1000
+)
+
+// MaxBenchRPS is the highest request rate Bench schedules.
+const MaxBenchRPS = 1_000_000
+
+// BenchOptions controls a load test. Zero values fall back to defaults,
+// except RPS and Duration which must be set. RPS is at most MaxBenchRPS.
+type BenchOptions struct {
+	Method string
+	Params json.RawMessage
+	RPS    int
+	// Duration is how long requests are scheduled for; requests still in
+	// flight when it ends are awaited.
+	Duration time.Duration
+	// Timeout bounds each request.
+	Timeout time.Duration
+	// MaxInFlight caps outstanding requests. Requests due while the cap is
+	// reached are skipped rather than delayed, so a slow endpoint does not
+	// lower the offered load.
+	MaxInFlight int
+}
+
+// BenchResult summarizes a load test against one endpoint.
+type BenchResult struct {
+	Chain           string         `json:"chain"`
+	Network         string         `json:"network"`
+	NodeType        string         `json:"node_type,omitempty"`
+	Endpoint        string         `json:"endpoint"`
+	Method          string         `json:"method"`
+	TargetRPS       int            `json:"target_rps"`
+	DurationSeconds float64        `json:"duration_seconds"`
+	ElapsedSeconds  float64        `json:"elapsed_seconds"`
+	Sent            int            `json:"sent"`
+	Skipped         int            `json:"skipped,omitempty"`
+	Succeeded       int            `json:"succeeded"`
+	Failed          int            `json:"failed"`
+	RateLimited     int            `json:"rate_limited"`
+	AchievedRPS     float64        `json:"achieved_rps"`
+	SuccessRPS      float64        `json:"success_rps"`
+	P50Ms           float64        `json:"p50_ms"`
+	P90Ms           float64        `json:"p90_ms"`
+	P99Ms           float64        `json:"p99_ms"`
+	MaxMs           float64        `json:"max_ms"`
+	ErrorClasses    map[string]int `json:"error_classes,omitempty"`
+	Error           string         `json:"error,omitempty"`
+	RateLimit       int            `json:"rate_limit,omitempty"`
+	BurstLimit      int            `json:"burst_limit,omitempty"`
+	Warnings        []string       `json:"warnings,omitempty"`
+}
+
+// ErrorClassCount is one row of a bench error breakdown.
+type ErrorClassCount struct {
+	Class string
+	Count int
+}
+
+// ErrorClassCounts returns the error classes ordered by count, then name.
+func (r BenchResult) ErrorClassCounts() []ErrorClassCount {
+	out := make([]ErrorClassCount, 0, len(r.ErrorClasses))
+	for class, count := range r.ErrorClasses {
+		out = append(out, ErrorClassCount{Class: class, Count: count})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Count != out[j].Count {
+			return out[i].Count > out[j].Count
+		}
+		return out[i].Class < out[j].Class
+	})
+	return out
+}
+
+// benchRequestCount returns how many requests fit in d at rps.
+func benchRequestCount(d time.Duration, rps int) int {
+	return int(d/time.Second)*rps + int((d%time.Second)*time.Duration(rps)/time.Second)
+}
+
+// benchStartOffset returns when the i-th request starts. It is computed per
+// request rather than as i times a truncated interval, which would drift
+// ahead of the target rate.
+func benchStartOffset(i, rps int) time.Duration {
+	return time.Duration(i/rps)*time.Second + time.Duration(i%rps)*time.Second/time.Duration(rps)
+}
+
+// Bench drives an open-loop load against url: one request is started every
+// 1/RPS seconds regardless of how long earlier ones take, so queueing at the
+// endpoint shows up as latency and errors instead of a lower request rate.
+// Cancelling ctx stops scheduling; requests aborted that way are not counted.
+// Latency percentiles cover successful requests only.
+func Bench(ctx context.Context, url string, opts BenchOptions) BenchResult {
+	if opts.Timeout <= 0 {
+		opts.Timeout = defaultBenchTimeout
+	}
+	if opts.MaxInFlight < 1 {
+		opts.MaxInFlight = defaultBenchMaxInFlight
+	}
+	if len(opts.Params) == 0 {
+		opts.Params = json.RawMessage("[]")
+	}
+	result := BenchResult{
+		Method:          opts.Method,
+		TargetRPS:       opts.RPS,
+		DurationSeconds: opts.Duration.Seconds(),
+		ErrorClasses:    map[string]int{},
+	}
+	if opts.RPS < 1 || opts.RPS > MaxBenchRPS || opts.Duration <= 0 {
+		return result
+	}
+
+	// The default transport keeps two idle connections per host, which would
+	// make most requests at any real rate pay for a new TLS handshake.
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConnsPerHost = opts.MaxInFlight
+	client := &Client{url: url, httpClient: &http.Client{Timeout: opts.Timeout, Transport: transport}}
+	defer transport.CloseIdleConnections()
+
+	var (
+		mu        sync.Mutex
+		wg        sync.WaitGroup
+		latencies []float64
+		inFlight  = make(chan struct{}, opts.MaxInFlight)
+		lastError error
+		cancelled int
+	)
+	record := func(latency time.Duration, class string, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if class == "" {
+			result.Succeeded++
+			latencies = append(latencies, millis(latency))
+			return
+		}
+		result.Failed++
+		result.ErrorClasses[class]++
+		if class == "rate_limited" {
+			result.RateLimited++
+		}
+		lastError = err
+	}
+
+	total := benchRequestCount(opts.Duration, opts.RPS)
+	started := time.Now()
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+schedule:
+	for i := 0; i < total; i++ {
+		if wait := time.Until(started.Add(benchStartOffset(i, opts.RPS))); wait > 0 {
+			timer.Reset(wait)
+			select {
+			case <-ctx.Done():
+				break schedule
+			case <-timer.C:
+			}
+		} else if ctx.Err() != nil {
+			break schedule
+		}
+		select {
+		case inFlight <- struct{}{}:
+		default:
+			result.Skipped++
+			continue
+		}
+		result.Sent++
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-inFlight }()
+			res, err := client.Call(ctx, opts.Method, opts.Params)
+			if err != nil && ctx.Err() != nil {
+				mu.Lock()
+				cancelled++
+				mu.Unlock()
+				return
+			}
+			var latency time.Duration
+			if res != nil {
+				latency = res.Latency
+			}
+			class, err := classifyBenchReply(res, err)
+			record(latency, class, err)
+		}()
+	}
+	wg.Wait()
+	result.Sent -= cancelled
+
+	elapsed := time.Since(started)
+	result.ElapsedSeconds = elapsed.Round(time.Millisecond).Seconds()
+	if secs := elapsed.Seconds(); secs > 0 {
+		result.AchievedRPS = float64(result.Succeeded+result.Failed) / secs
+		result.SuccessRPS = float64(result.Succeeded) / secs
+	}
+	if len(latencies) > 0 {
+		p := stats.Percentiles(latencies, 50, 90, 99, 100)
+		result.P50Ms, result.P90Ms, result.P99Ms, result.MaxMs = p[0], p[1], p[2], p[3]
+	}
+	if lastError != nil {
+		result.Error = lastError.Error()
+	}
+	if len(result.ErrorClasses) == 0 {
+		result.ErrorClasses = nil
+	}
+	return result
+}
+
+// classifyBenchReply returns the error class of one bench request, or "" when
+// it succeeded. A 429 counts as rate limited even when the body carries a
+// JSON-RPC error.
+func classifyBenchReply(res *CallResult, err error) (string, error) {
+	if err != nil {
+		return ClassifyProbeError(err), err
+	}
+	if res.StatusCode >= 400 {
+		httpErr := &HTTPError{StatusCode: res.StatusCode, Body: errorMessage(res.Response)}
+		return ClassifyProbeError(httpErr), httpErr
+	}
+	if res.Response.Error != nil {
+		return "rpc_error", res.Response.Error
+	}
+	return "", nil
+}
+
+func errorMessage(resp *Response) string {
+	if resp == nil || resp.Error == nil {
+		return ""
+	}
+	return resp.Error.Message
+}
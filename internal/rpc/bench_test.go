@@ -0,0 +1,102 @@
+package rpc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBenchCountsRateLimitsAndErrors(t *testing.T) {
+	var calls atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch calls.Add(1) % 4 {
+		case 0:
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32005,"message":"rate limit exceeded"}}`))
+		case 1:
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32601,"message":"method not found"}}`))
+		default:
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x10"}`))
+		}
+	}))
+	defer server.Close()
+
+	result := Bench(context.Background(), server.URL, BenchOptions{Method: "eth_blockNumber", RPS: 100, Duration: 400 * time.Millisecond})
+	if result.Sent != 40 || result.Succeeded+result.Failed != 40 {
+		t.Fatalf("expected 40 requests, got %+v", result)
+	}
+	if result.RateLimited != 10 || result.ErrorClasses["rate_limited"] != 10 || result.ErrorClasses["rpc_error"] != 10 || result.Succeeded != 20 {
+		t.Fatalf("unexpected outcome: %+v", result)
+	}
+	if result.P50Ms <= 0 || result.MaxMs < result.P99Ms || result.P99Ms < result.P50Ms {
+		t.Fatalf("unexpected latencies: %+v", result)
+	}
+	if result.ElapsedSeconds < 0.35 || result.AchievedRPS <= 0 {
+		t.Fatalf("unexpected pacing: %+v", result)
+	}
+	if classes := result.ErrorClassCounts(); len(classes) != 2 || classes[0].Class != "rate_limited" {
+		t.Fatalf("unexpected class order: %+v", classes)
+	}
+}
+
+func TestBenchSkipsRequestsOverTheInFlightCap(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x10"}`))
+	}))
+	defer server.Close()
+	defer close(release)
+
+	done := make(chan BenchResult)
+	go func() {
+		done <- Bench(context.Background(), server.URL, BenchOptions{Method: "eth_blockNumber", RPS: 100, Duration: 200 * time.Millisecond, MaxInFlight: 2})
+	}()
+	time.Sleep(300 * time.Millisecond)
+	release <- struct{}{}
+	release <- struct{}{}
+	result := <-done
+	if result.Sent != 2 || result.Skipped != 18 || result.Succeeded != 2 {
+		t.Fatalf("expected 2 sent and 18 skipped, got %+v", result)
+	}
+}
+
+func TestBenchStopsOnCancel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x10"}`))
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 150*time.Millisecond)
+	defer cancel()
+	started := time.Now()
+	result := Bench(ctx, server.URL, BenchOptions{Method: "eth_blockNumber", RPS: 20, Duration: time.Minute})
+	if time.Since(started) > 5*time.Second || result.Sent == 0 || result.Sent > 5 || result.Failed != 0 {
+		t.Fatalf("expected an early stop without failures, got %+v", result)
+	}
+}
+
+func TestBenchScheduleKeepsTheTargetRate(t *testing.T) {
+	if got := benchRequestCount(1500*time.Millisecond, 3); got != 4 {
+		t.Fatalf("expected 4 requests in 1.5s at 3 req/s, got %d", got)
+	}
+	if got := benchRequestCount(time.Minute, MaxBenchRPS); got != 60*MaxBenchRPS {
+		t.Fatalf("expected %d requests, got %d", 60*MaxBenchRPS, got)
+	}
+	// A truncated interval of 333333333ns would start the fourth request 1ns
+	// early and drift further with every second.
+	if got := benchStartOffset(3, 3); got != time.Second {
+		t.Fatalf("expected the fourth request at 1s, got %s", got)
+	}
+	if got := benchStartOffset(MaxBenchRPS*10-1, MaxBenchRPS); got != 10*time.Second-time.Microsecond {
+		t.Fatalf("unexpected offset at the maximum rate: %s", got)
+	}
+
+	result := Bench(context.Background(), "http://127.0.0.1:0", BenchOptions{Method: "eth_blockNumber", RPS: MaxBenchRPS + 1, Duration: time.Second})
+	if result.Sent != 0 {
+		t.Fatalf("expected nothing sent above MaxBenchRPS, got %+v", result)
+	}
+}
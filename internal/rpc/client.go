@@ -0,0 +1,231 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+const defaultTimeout = 30 * time.Second
+
+// maxResponseBytes caps how much of a node response is buffered in memory.
+const maxResponseBytes = 64 << 20
+
+// Request is a JSON-RPC 2.0 request object.
+type Request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// Response is a JSON-RPC 2.0 response object.
+type Response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *Error          `json:"error,omitempty"`
+}
+
+// Error is the error member of a JSON-RPC 2.0 response.
+type Error struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("JSON-RPC error %d: %s", e.Code, e.Message)
+}
+
+// HTTPError is returned when the node answers with a non-2xx status and no
+// JSON-RPC payload.
+type HTTPError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("RPC endpoint error (HTTP %d): %s", e.StatusCode, e.Body)
+}
+
+// InvalidResponseError is returned when a node answers with something that is
+// not a JSON-RPC response.
+type InvalidResponseError struct {
+	Message string
+}
+
+func (e *InvalidResponseError) Error() string {
+	return e.Message
+}
+
+// CallResult carries a single JSON-RPC response with transport details.
+type CallResult struct {
+	Response   *Response
+	StatusCode int
+	Latency    time.Duration
+}
+
+// CallSummary is the structured outcome of a single call against a resolved endpoint.
+type CallSummary struct {
+	Chain      string          `json:"chain"`
+	Network    string          `json:"network"`
+	NodeType   string          `json:"node_type,omitempty"`
+	Endpoint   string          `json:"endpoint"`
+	Method     string          `json:"method"`
+	HTTPStatus int             `json:"http_status"`
+	LatencyMs  float64         `json:"latency_ms"`
+	Result     json.RawMessage `json:"result"`
+}
+
+// Client sends JSON-RPC 2.0 requests to a single HTTP(S) endpoint.
+type Client struct {
+	url        string
+	httpClient *http.Client
+	nextID     atomic.Int64
+}
+
+func NewClient(url string) *Client {
+	return &Client{
+		url:        url,
+		httpClient: &http.Client{Timeout: defaultTimeout},
+	}
+}
+
+// NewRequest builds a JSON-RPC 2.0 request with a client-unique numeric id.
+func (c *Client) NewRequest(method string, params json.RawMessage) Request {
+	id := c.nextID.Add(1)
+	return Request{
+		JSONRPC: "2.0",
+		ID:      json.RawMessage(strconv.FormatInt(id, 10)),
+		Method:  method,
+		Params:  params,
+	}
+}
+
+// Call sends one JSON-RPC request and returns its response.
+func (c *Client) Call(ctx context.Context, method string, params json.RawMessage) (*CallResult, error) {
+	return c.Do(ctx, c.NewRequest(method, params))
+}
+
+// Do sends a prepared JSON-RPC request as-is.
+func (c *Client) Do(ctx context.Context, req Request) (*CallResult, error) {
+	body, status, latency, err := c.post(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp Response
+	if err := json.Unmarshal(body, &resp); err != nil || (resp.Result == nil && resp.Error == nil) {
+		if status >= 400 {
+			return nil, &HTTPError{StatusCode: status, Body: strings.TrimSpace(string(body))}
+		}
+		return nil, &InvalidResponseError{Message: "parsing JSON-RPC response: " + summarizeBody(body)}
+	}
+	return &CallResult{Response: &resp, StatusCode: status, Latency: latency}, nil
+}
+
+// Post sends an already-encoded JSON-RPC payload (a single request or a batch)
+// and returns the raw response body, HTTP status and latency. Transport errors
+// never include the endpoint URL.
+func (c *Client) Post(ctx context.Context, payload json.RawMessage) ([]byte, int, time.Duration, error) {
+	return c.post(ctx, payload)
+}
+
+func (c *Client) post(ctx context.Context, payload interface{}) ([]byte, int, time.Duration, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("marshaling request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(data))
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "dwellir-cli")
+
+	started := time.Now()
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("request failed: %w", stripURL(err))
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	latency := time.Since(started)
+	if err != nil {
+		return nil, resp.StatusCode, latency, fmt.Errorf("reading response: %w", err)
+	}
+	return body, resp.StatusCode, latency, nil
+}
+
+// ParseParams converts positional CLI arguments into a JSON-RPC params value.
+// A single argument that is already a JSON array or object is used verbatim;
+// otherwise each argument becomes an array element, kept as JSON when it is a
+// valid number, boolean, null, string, array or object literal and quoted as a
+// string otherwise (so 0x-prefixed hex and block tags need no extra quoting).
+func ParseParams(args []string) (json.RawMessage, error) {
+	if len(args) == 0 {
+		return json.RawMessage("[]"), nil
+	}
+	if len(args) == 1 {
+		trimmed := strings.TrimSpace(args[0])
+		if strings.HasPrefix(trimmed, "[") || strings.HasPrefix(trimmed, "{") {
+			if !json.Valid([]byte(trimmed)) {
+				return nil, fmt.Errorf("params %q is not valid JSON", trimmed)
+			}
+			return json.RawMessage(trimmed), nil
+		}
+	}
+
+	values := make([]json.RawMessage, 0, len(args))
+	for _, arg := range args {
+		trimmed := strings.TrimSpace(arg)
+		if trimmed != "" && json.Valid([]byte(trimmed)) {
+			values = append(values, json.RawMessage(trimmed))
+			continue
+		}
+		quoted, err := json.Marshal(arg)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, quoted)
+	}
+	encoded, err := json.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+	return json.RawMessage(encoded), nil
+}
+
+// stripURL drops the request URL from transport errors; endpoint URLs carry
+// the API key and must not end up in error output.
+func stripURL(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return urlErr.Err
+	}
+	return err
+}
+
+func summarizeBody(body []byte) string {
+	text := strings.TrimSpace(string(body))
+	if text == "" {
+		return "empty body"
+	}
+	const limit = 200
+	if len(text) > limit {
+		return text[:limit] + "..."
+	}
+	return text
+}
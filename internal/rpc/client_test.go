@@ -0,0 +1,106 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCallSendsJSONRPCRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req Request
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		if req.JSONRPC != "2.0" || req.Method != "eth_getBalance" || string(req.Params) != `["0xabc","latest"]` {
+			t.Errorf("unexpected request: %+v params=%s", req, req.Params)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": "0x10"})
+	}))
+	defer server.Close()
+
+	params, err := ParseParams([]string{"0xabc", "latest"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	result, err := NewClient(server.URL).Call(context.Background(), "eth_getBalance", params)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.StatusCode != http.StatusOK || string(result.Response.Result) != `"0x10"` {
+		t.Fatalf("unexpected result: status=%d result=%s", result.StatusCode, result.Response.Result)
+	}
+}
+
+func TestCallKeepsNullResult(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":null}`))
+	}))
+	defer server.Close()
+
+	result, err := NewClient(server.URL).Call(context.Background(), "eth_getTransactionReceipt", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(result.Response.Result) != "null" {
+		t.Fatalf("expected null result, got %q", result.Response.Result)
+	}
+}
+
+func TestCallReturnsRPCErrorPayload(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32601,"message":"method not found"}}`))
+	}))
+	defer server.Close()
+
+	result, err := NewClient(server.URL).Call(context.Background(), "eth_nope", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Response.Error == nil || result.Response.Error.Code != -32601 {
+		t.Fatalf("expected JSON-RPC error, got %+v", result.Response)
+	}
+}
+
+func TestCallReturnsHTTPErrorWithoutPayload(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte("slow down"))
+	}))
+	defer server.Close()
+
+	_, err := NewClient(server.URL).Call(context.Background(), "eth_blockNumber", nil)
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("expected HTTPError 429, got %v", err)
+	}
+}
+
+func TestParseParams(t *testing.T) {
+	cases := []struct {
+		args []string
+		want string
+	}{
+		{nil, `[]`},
+		{[]string{"0x1b4", "true"}, `["0x1b4",true]`},
+		{[]string{"42", "latest"}, `[42,"latest"]`},
+		{[]string{`["0x1", false]`}, `["0x1", false]`},
+		{[]string{`{"address":"0xabc"}`}, `{"address":"0xabc"}`},
+	}
+	for _, tc := range cases {
+		got, err := ParseParams(tc.args)
+		if err != nil {
+			t.Fatalf("ParseParams(%v) error: %v", tc.args, err)
+		}
+		if string(got) != tc.want {
+			t.Fatalf("ParseParams(%v) = %s, want %s", tc.args, got, tc.want)
+		}
+	}
+
+	if _, err := ParseParams([]string{`[1,`}); err == nil {
+		t.Fatal("expected invalid JSON array to be rejected")
+	}
+}
@@ -0,0 +1,401 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/websocket"
+)
+
+// IdentityKind names the value a node reports to identify its chain.
+type IdentityKind string
+
+const (
+	IdentityEVMChainID    IdentityKind = "chain_id"        // eth_chainId, as a decimal string
+	IdentityGenesisHash   IdentityKind = "genesis_hash"    // chain_getBlockHash(0)
+	IdentityCosmosChainID IdentityKind = "cosmos_chain_id" // /status node_info.network
+	IdentitySuiChainID    IdentityKind = "sui_chain_id"    // sui_getChainIdentifier
+	IdentityAptosChainID  IdentityKind = "aptos_chain_id"  // GET /v1 chain_id
+)
+
+// IdentityKindFor returns the identity check matching a probe kind.
+func IdentityKindFor(kind ProbeKind) IdentityKind {
+	switch kind {
+	case ProbeSubstrate:
+		return IdentityGenesisHash
+	case ProbeCosmos:
+		return IdentityCosmosChainID
+	case ProbeSui:
+		return IdentitySuiChainID
+	case ProbeAptos:
+		return IdentityAptosChainID
+	default:
+		return IdentityEVMChainID
+	}
+}
+
+// ExpectedIdentity is a known-good identity for one chain network.
+type ExpectedIdentity struct {
+	Kind  IdentityKind `json:"kind"`
+	Value string       `json:"value"`
+}
+
+func evmID(id uint64) ExpectedIdentity {
+	return ExpectedIdentity{Kind: IdentityEVMChainID, Value: strconv.FormatUint(id, 10)}
+}
+
+func genesis(hash string) ExpectedIdentity {
+	return ExpectedIdentity{Kind: IdentityGenesisHash, Value: hash}
+}
+
+func cosmosID(id string) ExpectedIdentity {
+	return ExpectedIdentity{Kind: IdentityCosmosChainID, Value: id}
+}
+
+// knownIdentities maps chain slug -> network slug -> expected identity. Only
+// well-established networks are listed; anything else is reported as unknown
+// rather than guessed.
+var knownIdentities = map[string]map[string]ExpectedIdentity{
+	"ethereum":           {"mainnet": evmID(1), "sepolia": evmID(11155111), "holesky": evmID(17000), "hoodi": evmID(560048)},
+	"base":               {"mainnet": evmID(8453), "sepolia": evmID(84532)},
+	"optimism":           {"mainnet": evmID(10), "sepolia": evmID(11155420)},
+	"arbitrum":           {"mainnet": evmID(42161), "one": evmID(42161), "sepolia": evmID(421614), "nova": evmID(42170)},
+	"arbitrum-one":       {"mainnet": evmID(42161)},
+	"arbitrum-nova":      {"mainnet": evmID(42170)},
+	"polygon":            {"mainnet": evmID(137), "amoy": evmID(80002)},
+	"polygon-pos":        {"mainnet": evmID(137), "amoy": evmID(80002)},
+	"polygon-zkevm":      {"mainnet": evmID(1101), "cardona": evmID(2442)},
+	"bnb-smart-chain":    {"mainnet": evmID(56), "testnet": evmID(97)},
+	"bsc":                {"mainnet": evmID(56), "testnet": evmID(97)},
+	"avalanche":          {"mainnet": evmID(43114), "c-chain": evmID(43114), "fuji": evmID(43113)},
+	"gnosis":             {"mainnet": evmID(100), "chiado": evmID(10200)},
+	"linea":              {"mainnet": evmID(59144), "sepolia": evmID(59141)},
+	"scroll":             {"mainnet": evmID(534352), "sepolia": evmID(534351)},
+	"zksync":             {"mainnet": evmID(324), "sepolia": evmID(300)},
+	"zksync-era":         {"mainnet": evmID(324), "sepolia": evmID(300)},
+	"blast":              {"mainnet": evmID(81457)},
+	"mantle":             {"mainnet": evmID(5000)},
+	"celo":               {"mainnet": evmID(42220)},
+	"fantom":             {"mainnet": evmID(250)},
+	"moonbeam":           {"mainnet": evmID(1284), "moonbase-alpha": evmID(1287)},
+	"moonriver":          {"mainnet": evmID(1285)},
+	"cronos":             {"mainnet": evmID(25)},
+	"metis":              {"mainnet": evmID(1088)},
+	"zora":               {"mainnet": evmID(7777777)},
+	"sonic":              {"mainnet": evmID(146)},
+	"berachain":          {"mainnet": evmID(80094)},
+	"unichain":           {"mainnet": evmID(130)},
+	"opbnb":              {"mainnet": evmID(204)},
+	"polkadot":           {"mainnet": genesis("0x91b171bb158e2d3848fa23a9f1c25182fb8e20313b2c1eb49219da7a70ce90c3"), "westend": genesis("0xe143f23803ac50e8f6f8e62695d1ce9e4e1d68aa36c1cd2cfd15340213f3423e")},
+	"kusama":             {"mainnet": genesis("0xb0a8d493285c2df73290dfb7e61f870f17b41801197a149ca93654499ea3dafe")},
+	"westend":            {"testnet": genesis("0xe143f23803ac50e8f6f8e62695d1ce9e4e1d68aa36c1cd2cfd15340213f3423e")},
+	"polkadot-asset-hub": {"mainnet": genesis("0x68d56f15f85d3136970ec16946040bc1752654e906147f7e43e9d539d7c3de2f")},
+	"kusama-asset-hub":   {"mainnet": genesis("0x48239ef607d7928874027a43a67689209727dfb3d3dc5e5b03a39bdc2eda771a")},
+	"cosmos-hub":         {"mainnet": cosmosID("cosmoshub-4")},
+	"cosmos":             {"mainnet": cosmosID("cosmoshub-4")},
+	"osmosis":            {"mainnet": cosmosID("osmosis-1")},
+	"juno":               {"mainnet": cosmosID("juno-1")},
+	"stargaze":           {"mainnet": cosmosID("stargaze-1")},
+	"akash":              {"mainnet": cosmosID("akashnet-2")},
+	"injective":          {"mainnet": cosmosID("injective-1")},
+	"celestia":           {"mainnet": cosmosID("celestia")},
+	"neutron":            {"mainnet": cosmosID("neutron-1")},
+	"noble":              {"mainnet": cosmosID("noble-1")},
+	"dydx":               {"mainnet": cosmosID("dydx-mainnet-1")},
+	"sui":                {"mainnet": {Kind: IdentitySuiChainID, Value: "35834a8a"}, "testnet": {Kind: IdentitySuiChainID, Value: "4c78adac"}},
+	"aptos":              {"mainnet": {Kind: IdentityAptosChainID, Value: "1"}, "testnet": {Kind: IdentityAptosChainID, Value: "2"}},
+}
+
+// LookupIdentity finds the expected identity for a chain and network slug.
+// Network names such as "Sepolia Testnet" also match the "sepolia" entry, and
+// any name containing "mainnet" matches "mainnet".
+func LookupIdentity(chainSlug, networkSlug string) (ExpectedIdentity, bool) {
+	networks, ok := knownIdentities[chainSlug]
+	if !ok {
+		return ExpectedIdentity{}, false
+	}
+	candidates := []string{networkSlug, strings.TrimSuffix(networkSlug, "-testnet"), strings.TrimSuffix(networkSlug, "-mainnet")}
+	if strings.Contains(networkSlug, "mainnet") {
+		candidates = append(candidates, "mainnet")
+	}
+	for _, candidate := range candidates {
+		if identity, ok := networks[candidate]; ok {
+			return identity, true
+		}
+	}
+	return ExpectedIdentity{}, false
+}
+
+// IdentifyNetwork reverse-looks-up an observed identity, returning
+// "chain/network" for the first known match or "" when it is unknown.
+func IdentifyNetwork(kind IdentityKind, value string) string {
+	var matches []string
+	for chain, networks := range knownIdentities {
+		for network, identity := range networks {
+			if identity.Kind == kind && strings.EqualFold(identity.Value, value) {
+				matches = append(matches, chain+"/"+network)
+			}
+		}
+	}
+	if len(matches) == 0 {
+		return ""
+	}
+	// Several aliases can share a value; prefer the shortest, then alphabetical.
+	sort.Slice(matches, func(i, j int) bool {
+		if len(matches[i]) != len(matches[j]) {
+			return len(matches[i]) < len(matches[j])
+		}
+		return matches[i] < matches[j]
+	})
+	return matches[0]
+}
+
+// VerifyTarget is one node URL to check against an expected identity.
+type VerifyTarget struct {
+	ProbeTarget
+	Expected *ExpectedIdentity
+}
+
+// Verification statuses.
+const (
+	VerifyMatch    = "match"
+	VerifyMismatch = "mismatch"
+	VerifyUnknown  = "unknown"
+	VerifyError    = "error"
+)
+
+// VerifyResult reports the identity observed on one node.
+type VerifyResult struct {
+	Chain      string       `json:"chain"`
+	Network    string       `json:"network"`
+	NodeType   string       `json:"node_type,omitempty"`
+	Protocol   string       `json:"protocol"`
+	Endpoint   string       `json:"endpoint"`
+	Kind       IdentityKind `json:"kind"`
+	Expected   string       `json:"expected,omitempty"`
+	Observed   string       `json:"observed,omitempty"`
+	ObservedAs string       `json:"observed_as,omitempty"`
+	Status     string       `json:"status"`
+	ErrorClass string       `json:"error_class,omitempty"`
+	Error      string       `json:"error,omitempty"`
+}
+
+// Verify fetches each target's chain identity with at most concurrency nodes
+// in flight and compares it with the expected value. Results keep target order.
+func Verify(ctx context.Context, targets []VerifyTarget, concurrency int, timeout time.Duration) []VerifyResult {
+	if concurrency < 1 {
+		concurrency = defaultProbeConcurrency
+	}
+	if timeout <= 0 {
+		timeout = defaultProbeTimeout
+	}
+	httpClient := &http.Client{Timeout: timeout}
+
+	results := make([]VerifyResult, len(targets))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(concurrency, len(targets)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range jobs {
+				results[idx] = verifyTarget(ctx, httpClient, targets[idx], timeout)
+			}
+		}()
+	}
+	for idx := range targets {
+		jobs <- idx
+	}
+	close(jobs)
+	wg.Wait()
+	return results
+}
+
+func verifyTarget(ctx context.Context, httpClient *http.Client, target VerifyTarget, timeout time.Duration) VerifyResult {
+	kind := IdentityKindFor(target.Kind)
+	if target.Expected != nil {
+		kind = target.Expected.Kind
+	}
+	result := VerifyResult{
+		Chain:    target.Chain,
+		Network:  target.Network,
+		NodeType: target.NodeType,
+		Protocol: target.Protocol,
+		Endpoint: target.Endpoint,
+		Kind:     kind,
+	}
+
+	observed, err := FetchIdentity(ctx, httpClient, target.ProbeTarget, kind, timeout)
+	if err != nil {
+		result.Status = VerifyError
+		result.ErrorClass = ClassifyProbeError(err)
+		result.Error = err.Error()
+		if target.Expected != nil {
+			result.Expected = target.Expected.Value
+		}
+		return result
+	}
+	result.Observed = observed
+	result.ObservedAs = IdentifyNetwork(kind, observed)
+
+	switch {
+	case target.Expected == nil:
+		result.Status = VerifyUnknown
+	case strings.EqualFold(observed, target.Expected.Value):
+		result.Status = VerifyMatch
+		result.Expected = target.Expected.Value
+	default:
+		result.Status = VerifyMismatch
+		result.Expected = target.Expected.Value
+	}
+	return result
+}
+
+// FetchIdentity asks a node for its chain identity. EVM chain IDs are
+// returned in decimal and genesis hashes in lowercase so they compare
+// directly with ExpectedIdentity values.
+func FetchIdentity(ctx context.Context, httpClient *http.Client, target ProbeTarget, kind IdentityKind, timeout time.Duration) (string, error) {
+	var (
+		method string
+		params = json.RawMessage("[]")
+	)
+	switch kind {
+	case IdentityGenesisHash:
+		method, params = "chain_getBlockHash", json.RawMessage("[0]")
+	case IdentitySuiChainID:
+		method = "sui_getChainIdentifier"
+	case IdentityCosmosChainID:
+		if target.Protocol != "wss" {
+			body, err := probeGet(ctx, httpClient, target.URL, "/status")
+			if err != nil {
+				return "", err
+			}
+			return cosmosNetwork(body)
+		}
+		method, params = "status", json.RawMessage("{}")
+	case IdentityAptosChainID:
+		body, err := probeGet(ctx, httpClient, target.URL, "/v1")
+		if err != nil {
+			return "", err
+		}
+		var ledger struct {
+			ChainID json.RawMessage `json:"chain_id"`
+		}
+		if err := json.Unmarshal(body, &ledger); err != nil || len(ledger.ChainID) == 0 {
+			return "", &InvalidResponseError{Message: "unexpected ledger info: " + summarizeBody(body)}
+		}
+		return strings.Trim(string(ledger.ChainID), `"`), nil
+	default:
+		method = "eth_chainId"
+	}
+
+	var (
+		result json.RawMessage
+		err    error
+	)
+	if target.Protocol == "wss" {
+		result, err = wsCall(ctx, target.URL, method, params, timeout)
+	} else {
+		var res *CallResult
+		res, err = (&Client{url: target.URL, httpClient: httpClient}).Call(ctx, method, params)
+		if err == nil {
+			if res.Response.Error != nil {
+				return "", res.Response.Error
+			}
+			result = res.Response.Result
+		}
+	}
+	if err != nil {
+		return "", err
+	}
+
+	switch kind {
+	case IdentityCosmosChainID:
+		return cosmosNetwork(result)
+	case IdentityEVMChainID:
+		id, err := parseHeight(result)
+		if err != nil {
+			return "", &InvalidResponseError{Message: "unexpected chain id: " + summarizeBody(result)}
+		}
+		return strconv.FormatUint(id, 10), nil
+	default:
+		var value string
+		if err := json.Unmarshal(result, &value); err != nil || value == "" {
+			return "", &InvalidResponseError{Message: "unexpected " + method + " result: " + summarizeBody(result)}
+		}
+		return strings.ToLower(value), nil
+	}
+}
+
+// cosmosNetwork reads node_info.network from a CometBFT status response,
+// with or without the JSON-RPC result wrapper.
+func cosmosNetwork(body []byte) (string, error) {
+	var status struct {
+		Result *struct {
+			NodeInfo struct {
+				Network string `json:"network"`
+			} `json:"node_info"`
+		} `json:"result"`
+		NodeInfo struct {
+			Network string `json:"network"`
+		} `json:"node_info"`
+	}
+	if err := json.Unmarshal(body, &status); err != nil {
+		return "", &InvalidResponseError{Message: "unexpected status: " + summarizeBody(body)}
+	}
+	network := status.NodeInfo.Network
+	if status.Result != nil {
+		network = status.Result.NodeInfo.Network
+	}
+	if network == "" {
+		return "", &InvalidResponseError{Message: "status response has no node_info.network"}
+	}
+	return network, nil
+}
+
+// wsCall sends one request over a fresh WebSocket connection.
+func wsCall(ctx context.Context, url, method string, params json.RawMessage, timeout time.Duration) (json.RawMessage, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ws, err := (&Subscription{URL: url}).dial(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer ws.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = ws.SetDeadline(deadline)
+	}
+
+	req := Request{JSONRPC: "2.0", ID: json.RawMessage("1"), Method: method, Params: params}
+	if err := websocket.JSON.Send(ws, req); err != nil {
+		return nil, err
+	}
+	for {
+		msg, err := receive(ws)
+		if err != nil {
+			return nil, err
+		}
+		if idKey(msg.ID) != "1" {
+			continue
+		}
+		if msg.Error != nil {
+			return nil, msg.Error
+		}
+		return msg.Result, nil
+	}
+}
+
+// SortVerifyResults orders mismatches and errors before matches.
+func SortVerifyResults(results []VerifyResult) {
+	rank := map[string]int{VerifyMismatch: 0, VerifyError: 1, VerifyUnknown: 2, VerifyMatch: 3}
+	sort.SliceStable(results, func(i, j int) bool {
+		return rank[results[i].Status] < rank[results[j].Status]
+	})
+}
@@ -0,0 +1,78 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestLookupIdentityMatchesNetworkNames(t *testing.T) {
+	cases := []struct {
+		chain, network, want string
+	}{
+		{"ethereum", "mainnet", "1"},
+		{"ethereum", "sepolia-testnet", "11155111"},
+		{"base", "base-mainnet", "8453"},
+		{"polygon", "amoy", "80002"},
+	}
+	for _, tc := range cases {
+		got, ok := LookupIdentity(tc.chain, tc.network)
+		if !ok || got.Value != tc.want {
+			t.Errorf("LookupIdentity(%q, %q) = %+v, %v; want %s", tc.chain, tc.network, got, ok, tc.want)
+		}
+	}
+	if _, ok := LookupIdentity("ethereum", "some-devnet"); ok {
+		t.Fatal("expected unknown network to have no identity")
+	}
+	if got := IdentifyNetwork(IdentityEVMChainID, "11155111"); got != "ethereum/sepolia" {
+		t.Fatalf("unexpected reverse lookup: %q", got)
+	}
+}
+
+func TestVerifyDetectsMismatchedChain(t *testing.T) {
+	sepolia := newProbeNode(t, func(w http.ResponseWriter, r *http.Request) {
+		var req Request
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if req.Method != "eth_chainId" {
+			t.Errorf("unexpected method %q", req.Method)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": "0xaa36a7"})
+	})
+	polkadot := newProbeNode(t, func(w http.ResponseWriter, r *http.Request) {
+		var req Request
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if req.Method != "chain_getBlockHash" || string(req.Params) != "[0]" {
+			t.Errorf("unexpected request %s %s", req.Method, req.Params)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": "0x91B171BB158E2D3848FA23A9F1C25182FB8E20313B2C1EB49219DA7A70CE90C3"})
+	})
+	cosmos := newProbeNode(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"result":{"node_info":{"network":"theta-testnet-001"}}}`))
+	})
+
+	mainnet, _ := LookupIdentity("ethereum", "mainnet")
+	dot, _ := LookupIdentity("polkadot", "mainnet")
+	targets := []VerifyTarget{
+		{ProbeTarget: ProbeTarget{Chain: "Ethereum", Network: "Mainnet", Protocol: "https", URL: sepolia.URL, Kind: ProbeEVM}, Expected: &mainnet},
+		{ProbeTarget: ProbeTarget{Chain: "Polkadot", Network: "Mainnet", Protocol: "https", URL: polkadot.URL, Kind: ProbeSubstrate}, Expected: &dot},
+		{ProbeTarget: ProbeTarget{Chain: "Cosmos Hub", Network: "Theta", Protocol: "https", URL: cosmos.URL, Kind: ProbeCosmos}},
+	}
+	results := Verify(context.Background(), targets, 2, 2*time.Second)
+
+	if r := results[0]; r.Status != VerifyMismatch || r.Observed != "11155111" || r.Expected != "1" || r.ObservedAs != "ethereum/sepolia" {
+		t.Fatalf("expected mismatch, got %+v", r)
+	}
+	if r := results[1]; r.Status != VerifyMatch || r.Kind != IdentityGenesisHash {
+		t.Fatalf("expected genesis match, got %+v", r)
+	}
+	if r := results[2]; r.Status != VerifyUnknown || r.Observed != "theta-testnet-001" {
+		t.Fatalf("expected unknown cosmos identity, got %+v", r)
+	}
+
+	SortVerifyResults(results)
+	if results[0].Status != VerifyMismatch || results[2].Status != VerifyMatch {
+		t.Fatalf("unexpected order: %+v", results)
+	}
+}
@@ -0,0 +1,389 @@
+package rpc
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/websocket"
+
+	"github.com/dwellir-public/cli/internal/stats"
+)
+
+const (
+	defaultProbeSamples     = 3
+	defaultProbeConcurrency = 8
+	defaultProbeTimeout     = 10 * time.Second
+)
+
+// ProbeKind selects the lightweight request used to check a node.
+type ProbeKind string
+
+const (
+	ProbeEVM       ProbeKind = "evm"       // eth_blockNumber
+	ProbeSubstrate ProbeKind = "substrate" // chain_getHeader
+	ProbeCosmos    ProbeKind = "cosmos"    // GET /status (JSON-RPC status over WSS)
+	ProbeSui       ProbeKind = "sui"       // sui_getLatestCheckpointSequenceNumber
+	ProbeAptos     ProbeKind = "aptos"     // GET /v1
+)
+
+// ProbeKindFor picks a probe for a catalog chain. Chains outside the known
+// ecosystems are probed as EVM, which most Dwellir JSON-RPC endpoints accept.
+func ProbeKindFor(ecosystem, chain string) ProbeKind {
+	switch strings.ToLower(strings.TrimSpace(ecosystem)) {
+	case "substrate":
+		return ProbeSubstrate
+	case "cosmos":
+		return ProbeCosmos
+	case "move":
+		if strings.Contains(strings.ToLower(chain), "aptos") || strings.Contains(strings.ToLower(chain), "movement") {
+			return ProbeAptos
+		}
+		return ProbeSui
+	default:
+		return ProbeEVM
+	}
+}
+
+// Method names the request a probe sends, for display.
+func (k ProbeKind) Method(protocol string) string {
+	switch k {
+	case ProbeSubstrate:
+		return "chain_getHeader"
+	case ProbeCosmos:
+		if protocol == "wss" {
+			return "status"
+		}
+		return "GET /status"
+	case ProbeSui:
+		return "sui_getLatestCheckpointSequenceNumber"
+	case ProbeAptos:
+		return "GET /v1"
+	default:
+		return "eth_blockNumber"
+	}
+}
+
+// ProbeTarget is one node URL to probe.
+type ProbeTarget struct {
+	Chain    string
+	Network  string
+	NodeType string
+	Protocol string // https or wss
+	// Endpoint is the displayable URL (with any <key> placeholder kept).
+	Endpoint string
+	// URL is the dialable URL.
+	URL  string
+	Kind ProbeKind
+}
+
+// ProbeResult aggregates the samples taken against one node.
+type ProbeResult struct {
+	Chain       string  `json:"chain"`
+	Network     string  `json:"network"`
+	NodeType    string  `json:"node_type,omitempty"`
+	Protocol    string  `json:"protocol"`
+	Endpoint    string  `json:"endpoint"`
+	Method      string  `json:"method"`
+	Samples     int     `json:"samples"`
+	Succeeded   int     `json:"succeeded"`
+	SuccessRate float64 `json:"success_rate"`
+	P50Ms       float64 `json:"p50_ms"`
+	P95Ms       float64 `json:"p95_ms"`
+	HeadBlock   uint64  `json:"head_block,omitempty"`
+	ErrorClass  string  `json:"error_class,omitempty"`
+	Error       string  `json:"error,omitempty"`
+}
+
+// ProbeOptions controls sampling. Zero values fall back to defaults.
+type ProbeOptions struct {
+	Samples     int
+	Concurrency int
+	Timeout     time.Duration
+}
+
+// Probe checks every target with at most opts.Concurrency nodes in flight.
+// Samples for one node run sequentially so they do not compete with each
+// other. Results are ordered healthy-first by p50 latency.
+func Probe(ctx context.Context, targets []ProbeTarget, opts ProbeOptions) []ProbeResult {
+	if opts.Samples < 1 {
+		opts.Samples = defaultProbeSamples
+	}
+	if opts.Concurrency < 1 {
+		opts.Concurrency = defaultProbeConcurrency
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = defaultProbeTimeout
+	}
+	httpClient := &http.Client{Timeout: opts.Timeout}
+
+	results := make([]ProbeResult, len(targets))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(opts.Concurrency, len(targets)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range jobs {
+				results[idx] = probeTarget(ctx, httpClient, targets[idx], opts)
+			}
+		}()
+	}
+	for idx := range targets {
+		jobs <- idx
+	}
+	close(jobs)
+	wg.Wait()
+
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if (a.Succeeded > 0) != (b.Succeeded > 0) {
+			return a.Succeeded > 0
+		}
+		if a.SuccessRate != b.SuccessRate {
+			return a.SuccessRate > b.SuccessRate
+		}
+		return a.P50Ms < b.P50Ms
+	})
+	return results
+}
+
+func probeTarget(ctx context.Context, httpClient *http.Client, target ProbeTarget, opts ProbeOptions) ProbeResult {
+	result := ProbeResult{
+		Chain:    target.Chain,
+		Network:  target.Network,
+		NodeType: target.NodeType,
+		Protocol: target.Protocol,
+		Endpoint: target.Endpoint,
+		Method:   target.Kind.Method(target.Protocol),
+	}
+
+	var latencies []float64
+	classCounts := map[string]int{}
+	for i := 0; i < opts.Samples; i++ {
+		if ctx.Err() != nil {
+			break
+		}
+		result.Samples++
+		started := time.Now()
+		head, err := probeOnce(ctx, httpClient, target, opts.Timeout)
+		if err != nil {
+			class := ClassifyProbeError(err)
+			classCounts[class]++
+			result.Error = err.Error()
+			continue
+		}
+		latencies = append(latencies, millis(time.Since(started)))
+		result.Succeeded++
+		if head > result.HeadBlock {
+			result.HeadBlock = head
+		}
+	}
+
+	if result.Samples > 0 {
+		result.SuccessRate = float64(result.Succeeded) / float64(result.Samples)
+	}
+	if len(latencies) > 0 {
+		p := stats.Percentiles(latencies, 50, 95)
+		result.P50Ms, result.P95Ms = p[0], p[1]
+	}
+	// Report the dominant failure class; ties resolve alphabetically so the
+	// output is stable.
+	best := 0
+	for class, count := range classCounts {
+		if count > best || (count == best && class < result.ErrorClass) {
+			result.ErrorClass, best = class, count
+		}
+	}
+	if result.Succeeded == result.Samples {
+		result.Error = ""
+	}
+	return result
+}
+
+func probeOnce(ctx context.Context, httpClient *http.Client, target ProbeTarget, timeout time.Duration) (uint64, error) {
+	if target.Protocol == "wss" {
+		return probeWebSocket(ctx, target, timeout)
+	}
+
+	switch target.Kind {
+	case ProbeCosmos:
+		body, err := probeGet(ctx, httpClient, target.URL, "/status")
+		if err != nil {
+			return 0, err
+		}
+		return cosmosHeight(body)
+	case ProbeAptos:
+		body, err := probeGet(ctx, httpClient, target.URL, "/v1")
+		if err != nil {
+			return 0, err
+		}
+		var ledger struct {
+			BlockHeight json.RawMessage `json:"block_height"`
+		}
+		if err := json.Unmarshal(body, &ledger); err != nil {
+			return 0, &InvalidResponseError{Message: "unexpected ledger info: " + summarizeBody(body)}
+		}
+		return parseHeight(ledger.BlockHeight)
+	}
+
+	client := &Client{url: target.URL, httpClient: httpClient}
+	res, err := client.Call(ctx, target.Kind.Method("https"), json.RawMessage("[]"))
+	if err != nil {
+		return 0, err
+	}
+	if res.Response.Error != nil {
+		return 0, res.Response.Error
+	}
+	return rpcHeight(target.Kind, res.Response.Result)
+}
+
+func probeGet(ctx context.Context, httpClient *http.Client, base, path string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimRight(base, "/")+path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "dwellir-cli")
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", stripURL(err))
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	if err != nil {
+		return nil, fmt.Errorf("reading response: %w", err)
+	}
+	if resp.StatusCode >= 400 {
+		return nil, &HTTPError{StatusCode: resp.StatusCode, Body: strings.TrimSpace(string(body))}
+	}
+	return body, nil
+}
+
+func probeWebSocket(ctx context.Context, target ProbeTarget, timeout time.Duration) (uint64, error) {
+	params := json.RawMessage("[]")
+	if target.Kind == ProbeCosmos {
+		params = json.RawMessage("{}")
+	}
+	result, err := wsCall(ctx, target.URL, target.Kind.Method("wss"), params, timeout)
+	if err != nil {
+		return 0, err
+	}
+	if target.Kind == ProbeCosmos {
+		return cosmosHeight(result)
+	}
+	return rpcHeight(target.Kind, result)
+}
+
+func rpcHeight(kind ProbeKind, result json.RawMessage) (uint64, error) {
+	if kind == ProbeSubstrate {
+		var header struct {
+			Number json.RawMessage `json:"number"`
+		}
+		if err := json.Unmarshal(result, &header); err != nil {
+			return 0, &InvalidResponseError{Message: "unexpected header: " + summarizeBody(result)}
+		}
+		return parseHeight(header.Number)
+	}
+	return parseHeight(result)
+}
+
+// cosmosHeight reads sync_info.latest_block_height from a CometBFT status
+// response, with or without the JSON-RPC result wrapper.
+func cosmosHeight(body []byte) (uint64, error) {
+	var status struct {
+		Result *struct {
+			SyncInfo struct {
+				LatestBlockHeight json.RawMessage `json:"latest_block_height"`
+			} `json:"sync_info"`
+		} `json:"result"`
+		SyncInfo struct {
+			LatestBlockHeight json.RawMessage `json:"latest_block_height"`
+		} `json:"sync_info"`
+	}
+	if err := json.Unmarshal(body, &status); err != nil {
+		return 0, &InvalidResponseError{Message: "unexpected status: " + summarizeBody(body)}
+	}
+	if status.Result != nil {
+		return parseHeight(status.Result.SyncInfo.LatestBlockHeight)
+	}
+	return parseHeight(status.SyncInfo.LatestBlockHeight)
+}
+
+// parseHeight accepts 0x-prefixed hex strings, decimal strings and numbers.
+func parseHeight(raw json.RawMessage) (uint64, error) {
+	text := strings.TrimSpace(string(raw))
+	if unquoted, err := strconv.Unquote(text); err == nil {
+		text = unquoted
+	}
+	var (
+		n   uint64
+		err error
+	)
+	if hex, ok := strings.CutPrefix(text, "0x"); ok {
+		n, err = strconv.ParseUint(hex, 16, 64)
+	} else {
+		n, err = strconv.ParseUint(text, 10, 64)
+	}
+	if err != nil {
+		return 0, &InvalidResponseError{Message: "unexpected block height: " + summarizeBody(raw)}
+	}
+	return n, nil
+}
+
+// ClassifyProbeError maps a probe failure to a short, stable error class.
+func ClassifyProbeError(err error) string {
+	var (
+		rpcErr   *Error
+		httpErr  *HTTPError
+		respErr  *InvalidResponseError
+		dnsErr   *net.DNSError
+		opErr    *net.OpError
+		certErr  *tls.CertificateVerificationError
+		recordEr tls.RecordHeaderError
+		netErr   net.Error
+	)
+	switch {
+	case errors.Is(err, context.Canceled):
+		return "cancelled"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.As(err, &rpcErr):
+		return "rpc_error"
+	case errors.As(err, &httpErr):
+		switch {
+		case httpErr.StatusCode == http.StatusUnauthorized || httpErr.StatusCode == http.StatusForbidden:
+			return "auth"
+		case httpErr.StatusCode == http.StatusTooManyRequests:
+			return "rate_limited"
+		case httpErr.StatusCode >= 500:
+			return "http_5xx"
+		default:
+			return "http_4xx"
+		}
+	case errors.As(err, &respErr):
+		return "invalid_response"
+	case errors.As(err, &dnsErr):
+		return "dns"
+	case errors.As(err, &certErr), errors.As(err, &recordEr):
+		return "tls"
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return "timeout"
+	case errors.As(err, &opErr) && opErr.Op == "dial":
+		return "connection_refused"
+	case errors.Is(err, websocket.ErrBadStatus):
+		return "handshake"
+	default:
+		return "network"
+	}
+}
@@ -0,0 +1,126 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/net/websocket"
+)
+
+func newProbeNode(t *testing.T, handler http.HandlerFunc) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestProbeReportsLatencyHeadAndErrors(t *testing.T) {
+	evm := newProbeNode(t, func(w http.ResponseWriter, r *http.Request) {
+		var req Request
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if req.Method != "eth_blockNumber" {
+			t.Errorf("unexpected method %q", req.Method)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": "0x1b4"})
+	})
+	substrate := newProbeNode(t, func(w http.ResponseWriter, r *http.Request) {
+		var req Request
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": map[string]string{"number": "0x10"}})
+	})
+	cosmos := newProbeNode(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/key-1/status" {
+			t.Errorf("unexpected cosmos request %s %s", r.Method, r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":-1,"result":{"sync_info":{"latest_block_height":"987"}}}`))
+	})
+	var flakyCalls atomic.Int64
+	flaky := newProbeNode(t, func(w http.ResponseWriter, r *http.Request) {
+		if flakyCalls.Add(1)%2 == 0 {
+			w.WriteHeader(http.StatusBadGateway)
+			_, _ = w.Write([]byte("bad gateway"))
+			return
+		}
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x1"}`))
+	})
+	limited := newProbeNode(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	targets := []ProbeTarget{
+		{Chain: "Limited", Protocol: "https", URL: limited.URL, Kind: ProbeEVM},
+		{Chain: "Ethereum", Protocol: "https", URL: evm.URL, Endpoint: "https://eth/<key>", Kind: ProbeEVM},
+		{Chain: "Polkadot", Protocol: "https", URL: substrate.URL, Kind: ProbeSubstrate},
+		{Chain: "Cosmos Hub", Protocol: "https", URL: cosmos.URL + "/key-1", Kind: ProbeCosmos},
+		{Chain: "Flaky", Protocol: "https", URL: flaky.URL, Kind: ProbeEVM},
+	}
+	results := Probe(context.Background(), targets, ProbeOptions{Samples: 4, Concurrency: 2, Timeout: 2 * time.Second})
+	if len(results) != len(targets) {
+		t.Fatalf("expected %d results, got %d", len(targets), len(results))
+	}
+	byChain := map[string]ProbeResult{}
+	for _, r := range results {
+		byChain[r.Chain] = r
+	}
+
+	eth := byChain["Ethereum"]
+	if eth.Succeeded != 4 || eth.SuccessRate != 1 || eth.HeadBlock != 436 || eth.ErrorClass != "" || eth.P50Ms <= 0 || eth.P95Ms < eth.P50Ms {
+		t.Fatalf("unexpected ethereum result: %+v", eth)
+	}
+	if eth.Endpoint != "https://eth/<key>" || eth.Method != "eth_blockNumber" {
+		t.Fatalf("unexpected ethereum metadata: %+v", eth)
+	}
+	if byChain["Polkadot"].HeadBlock != 16 || byChain["Polkadot"].Method != "chain_getHeader" {
+		t.Fatalf("unexpected substrate result: %+v", byChain["Polkadot"])
+	}
+	if byChain["Cosmos Hub"].HeadBlock != 987 || byChain["Cosmos Hub"].Method != "GET /status" {
+		t.Fatalf("unexpected cosmos result: %+v", byChain["Cosmos Hub"])
+	}
+	if f := byChain["Flaky"]; f.SuccessRate != 0.5 || f.ErrorClass != "http_5xx" || f.Error == "" {
+		t.Fatalf("unexpected flaky result: %+v", f)
+	}
+	if l := byChain["Limited"]; l.Succeeded != 0 || l.ErrorClass != "rate_limited" {
+		t.Fatalf("unexpected limited result: %+v", l)
+	}
+	if results[len(results)-1].Chain != "Limited" {
+		t.Fatalf("expected failing node last, got order %v", results)
+	}
+}
+
+func TestProbeWebSocketNode(t *testing.T) {
+	server := httptest.NewServer(websocket.Handler(func(ws *websocket.Conn) {
+		var req Request
+		if err := websocket.JSON.Receive(ws, &req); err != nil {
+			return
+		}
+		_ = websocket.JSON.Send(ws, map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": "0x2a"})
+	}))
+	defer server.Close()
+
+	results := Probe(context.Background(), []ProbeTarget{
+		{Chain: "Base", Protocol: "wss", URL: "ws" + strings.TrimPrefix(server.URL, "http"), Kind: ProbeEVM},
+	}, ProbeOptions{Samples: 2})
+	if results[0].Succeeded != 2 || results[0].HeadBlock != 42 {
+		t.Fatalf("unexpected wss result: %+v", results[0])
+	}
+}
+
+func TestClassifyProbeErrorConnectionRefused(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+
+	results := Probe(context.Background(), []ProbeTarget{{Chain: "Down", Protocol: "https", URL: url + "/secret-key", Kind: ProbeEVM}}, ProbeOptions{Samples: 1})
+	if results[0].ErrorClass != "connection_refused" {
+		t.Fatalf("expected connection_refused, got %+v", results[0])
+	}
+	if strings.Contains(results[0].Error, "secret-key") {
+		t.Fatalf("error leaks endpoint URL: %q", results[0].Error)
+	}
+}
@@ -0,0 +1,279 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/net/websocket"
+)
+
+const (
+	defaultMaxReconnects  = 5
+	defaultReconnectDelay = time.Second
+	maxReconnectDelay     = 30 * time.Second
+	subscribeTimeout      = 15 * time.Second
+)
+
+// ErrStopSubscription can be returned by a notification handler to end a
+// subscription cleanly.
+var ErrStopSubscription = errors.New("stop subscription")
+
+// Notification is a single subscription event.
+type Notification struct {
+	Seq          int             `json:"seq"`
+	Subscription string          `json:"subscription"`
+	Method       string          `json:"method"`
+	ReceivedAt   time.Time       `json:"received_at"`
+	Result       json.RawMessage `json:"result"`
+}
+
+// Subscription streams notifications for one JSON-RPC subscription over a
+// WebSocket endpoint, resubscribing after the connection drops.
+type Subscription struct {
+	URL         string
+	Method      string
+	Unsubscribe string
+	Params      json.RawMessage
+
+	// MaxReconnects bounds consecutive reconnect attempts after a drop.
+	MaxReconnects  int
+	ReconnectDelay time.Duration
+
+	OnSubscribed func(id string)
+	OnReconnect  func(attempt int, delay time.Duration, err error)
+}
+
+// NewSubscription builds a subscription for name. Method names such as
+// chain_subscribeNewHeads are sent as-is; anything else (newHeads, logs,
+// newPendingTransactions) is treated as an eth_subscribe topic and prepended
+// to params.
+func NewSubscription(url, name string, params json.RawMessage) (*Subscription, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("subscription name is required")
+	}
+	sub := &Subscription{
+		URL:            url,
+		MaxReconnects:  defaultMaxReconnects,
+		ReconnectDelay: defaultReconnectDelay,
+	}
+
+	if prefix, rest, ok := strings.Cut(name, "_"); ok && strings.HasPrefix(rest, "subscribe") {
+		sub.Method = name
+		sub.Unsubscribe = prefix + "_unsubscribe" + strings.TrimPrefix(rest, "subscribe")
+		sub.Params = params
+		if len(sub.Params) == 0 {
+			sub.Params = json.RawMessage("[]")
+		}
+		return sub, nil
+	}
+
+	topic, err := json.Marshal(name)
+	if err != nil {
+		return nil, err
+	}
+	values := []json.RawMessage{topic}
+	trimmed := strings.TrimSpace(string(params))
+	switch {
+	case trimmed == "" || trimmed == "[]":
+	case strings.HasPrefix(trimmed, "["):
+		var extra []json.RawMessage
+		if err := json.Unmarshal([]byte(trimmed), &extra); err != nil {
+			return nil, fmt.Errorf("params %q is not valid JSON", trimmed)
+		}
+		values = append(values, extra...)
+	default:
+		values = append(values, json.RawMessage(trimmed))
+	}
+	encoded, err := json.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+	sub.Method = "eth_subscribe"
+	sub.Unsubscribe = "eth_unsubscribe"
+	sub.Params = encoded
+	return sub, nil
+}
+
+// Run subscribes and calls handle for every notification until ctx is done,
+// handle returns ErrStopSubscription (reported as nil), or the connection
+// cannot be re-established. Errors before the first successful subscription
+// are returned immediately rather than retried.
+func (s *Subscription) Run(ctx context.Context, handle func(Notification) error) error {
+	seq := 0
+	subscribed := false
+	failures := 0
+	for {
+		err := s.session(ctx, &seq, &subscribed, &failures, handle)
+		if errors.Is(err, ErrStopSubscription) {
+			return nil
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		var rpcErr *Error
+		if !subscribed || errors.As(err, &rpcErr) {
+			return err
+		}
+
+		failures++
+		if failures > s.MaxReconnects {
+			return fmt.Errorf("subscription lost after %d reconnect attempts: %w", s.MaxReconnects, err)
+		}
+		delay := s.reconnectDelay(failures)
+		if s.OnReconnect != nil {
+			s.OnReconnect(failures, delay, err)
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (s *Subscription) session(ctx context.Context, seq *int, subscribed *bool, failures *int, handle func(Notification) error) error {
+	ws, err := s.dial(ctx)
+	if err != nil {
+		return err
+	}
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = ws.Close()
+		case <-done:
+		}
+	}()
+	defer ws.Close()
+
+	const subscribeID = "1"
+	req := Request{JSONRPC: "2.0", ID: json.RawMessage(subscribeID), Method: s.Method, Params: s.Params}
+	if err := websocket.JSON.Send(ws, req); err != nil {
+		return fmt.Errorf("sending %s: %w", s.Method, err)
+	}
+
+	_ = ws.SetReadDeadline(time.Now().Add(subscribeTimeout))
+	subID := ""
+	for subID == "" {
+		msg, err := receive(ws)
+		if err != nil {
+			return fmt.Errorf("waiting for %s response: %w", s.Method, err)
+		}
+		if idKey(msg.ID) != subscribeID {
+			continue
+		}
+		if msg.Error != nil {
+			return msg.Error
+		}
+		subID = idKey(msg.Result)
+		if subID == "" || subID == "null" {
+			return fmt.Errorf("%s returned no subscription id", s.Method)
+		}
+	}
+	_ = ws.SetReadDeadline(time.Time{})
+	*subscribed = true
+	*failures = 0
+	if s.OnSubscribed != nil {
+		s.OnSubscribed(subID)
+	}
+
+	for {
+		msg, err := receive(ws)
+		if err != nil {
+			return fmt.Errorf("connection lost: %w", err)
+		}
+		if msg.Method == "" || msg.Params == nil || idKey(msg.Params.Subscription) != subID {
+			continue
+		}
+		*seq++
+		err = handle(Notification{
+			Seq:          *seq,
+			Subscription: subID,
+			Method:       msg.Method,
+			ReceivedAt:   time.Now().UTC(),
+			Result:       msg.Params.Result,
+		})
+		if err != nil {
+			if errors.Is(err, ErrStopSubscription) && s.Unsubscribe != "" {
+				params, _ := json.Marshal([]string{subID})
+				_ = ws.SetWriteDeadline(time.Now().Add(time.Second))
+				_ = websocket.JSON.Send(ws, Request{JSONRPC: "2.0", ID: json.RawMessage("2"), Method: s.Unsubscribe, Params: params})
+			}
+			return err
+		}
+	}
+}
+
+func (s *Subscription) dial(ctx context.Context) (*websocket.Conn, error) {
+	return DialWebSocket(ctx, s.URL)
+}
+
+// DialWebSocket opens a WebSocket connection to url. Errors never include the
+// URL, which carries the API key.
+func DialWebSocket(ctx context.Context, url string) (*websocket.Conn, error) {
+	origin := url
+	if rest, ok := strings.CutPrefix(origin, "wss://"); ok {
+		origin = "https://" + rest
+	} else if rest, ok := strings.CutPrefix(origin, "ws://"); ok {
+		origin = "http://" + rest
+	}
+	config, err := websocket.NewConfig(url, origin)
+	if err != nil {
+		return nil, fmt.Errorf("invalid WebSocket URL: %w", stripURL(err))
+	}
+	config.Header.Set("User-Agent", "dwellir-cli")
+	ws, err := config.DialContext(ctx)
+	if err != nil {
+		// DialError embeds the URL (and with it the API key) in its message.
+		var dialErr *websocket.DialError
+		if errors.As(err, &dialErr) {
+			err = dialErr.Err
+		}
+		return nil, fmt.Errorf("connecting to WebSocket endpoint: %w", err)
+	}
+	ws.MaxPayloadBytes = maxResponseBytes
+	return ws, nil
+}
+
+func (s *Subscription) reconnectDelay(attempt int) time.Duration {
+	delay := s.ReconnectDelay
+	if delay <= 0 {
+		delay = defaultReconnectDelay
+	}
+	for i := 1; i < attempt && delay < maxReconnectDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxReconnectDelay)
+}
+
+// wsMessage covers both responses and subscription notifications.
+type wsMessage struct {
+	ID     json.RawMessage `json:"id"`
+	Method string          `json:"method"`
+	Result json.RawMessage `json:"result"`
+	Error  *Error          `json:"error"`
+	Params *struct {
+		Subscription json.RawMessage `json:"subscription"`
+		Result       json.RawMessage `json:"result"`
+	} `json:"params"`
+}
+
+func receive(ws *websocket.Conn) (wsMessage, error) {
+	var data []byte
+	if err := websocket.Message.Receive(ws, &data); err != nil {
+		return wsMessage{}, err
+	}
+	var msg wsMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return wsMessage{}, fmt.Errorf("parsing WebSocket message: %s", summarizeBody(data))
+	}
+	return msg, nil
+}
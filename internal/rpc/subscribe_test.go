@@ -0,0 +1,119 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/net/websocket"
+)
+
+func TestNewSubscriptionMethods(t *testing.T) {
+	sub, err := NewSubscription("wss://example", "newHeads", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sub.Method != "eth_subscribe" || sub.Unsubscribe != "eth_unsubscribe" || string(sub.Params) != `["newHeads"]` {
+		t.Fatalf("unexpected subscription: %+v params=%s", sub, sub.Params)
+	}
+
+	sub, err = NewSubscription("wss://example", "logs", json.RawMessage(`{"address":"0xabc"}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(sub.Params) != `["logs",{"address":"0xabc"}]` {
+		t.Fatalf("unexpected params: %s", sub.Params)
+	}
+
+	sub, err = NewSubscription("wss://example", "chain_subscribeNewHeads", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sub.Method != "chain_subscribeNewHeads" || sub.Unsubscribe != "chain_unsubscribeNewHeads" || string(sub.Params) != `[]` {
+		t.Fatalf("unexpected subscription: %+v params=%s", sub, sub.Params)
+	}
+}
+
+func TestSubscriptionResubscribesAfterDrop(t *testing.T) {
+	var connections atomic.Int64
+	server := httptest.NewServer(websocket.Handler(func(ws *websocket.Conn) {
+		conn := connections.Add(1)
+		var req Request
+		if err := websocket.JSON.Receive(ws, &req); err != nil {
+			return
+		}
+		if req.Method != "eth_subscribe" {
+			t.Errorf("unexpected method %q", req.Method)
+		}
+		subID := fmt.Sprintf("0xsub%d", conn)
+		_ = websocket.JSON.Send(ws, map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": subID})
+		for i := 0; i < 2; i++ {
+			_ = websocket.JSON.Send(ws, map[string]interface{}{
+				"jsonrpc": "2.0",
+				"method":  "eth_subscription",
+				"params":  map[string]interface{}{"subscription": subID, "result": map[string]int64{"n": conn*10 + int64(i)}},
+			})
+		}
+		if conn > 1 {
+			// Keep the second connection open until the client unsubscribes.
+			_ = websocket.JSON.Receive(ws, &req)
+		}
+	}))
+	defer server.Close()
+
+	sub, err := NewSubscription("ws"+strings.TrimPrefix(server.URL, "http"), "newHeads", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sub.ReconnectDelay = 10 * time.Millisecond
+	reconnects := 0
+	sub.OnReconnect = func(int, time.Duration, error) { reconnects++ }
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var got []string
+	err = sub.Run(ctx, func(n Notification) error {
+		got = append(got, fmt.Sprintf("%d:%s:%s", n.Seq, n.Subscription, n.Result))
+		if len(got) == 3 {
+			return ErrStopSubscription
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{`1:0xsub1:{"n":10}`, `2:0xsub1:{"n":11}`, `3:0xsub2:{"n":20}`}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("unexpected notifications: %v", got)
+	}
+	if reconnects != 1 {
+		t.Fatalf("expected 1 reconnect, got %d", reconnects)
+	}
+}
+
+func TestSubscriptionReturnsRejectedSubscribe(t *testing.T) {
+	server := httptest.NewServer(websocket.Handler(func(ws *websocket.Conn) {
+		var req Request
+		if err := websocket.JSON.Receive(ws, &req); err != nil {
+			return
+		}
+		_ = websocket.JSON.Send(ws, map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      req.ID,
+			"error":   map[string]interface{}{"code": -32601, "message": "subscriptions not supported"},
+		})
+	}))
+	defer server.Close()
+
+	sub, _ := NewSubscription("ws"+strings.TrimPrefix(server.URL, "http"), "newHeads", nil)
+	err := sub.Run(context.Background(), func(Notification) error { return nil })
+	rpcErr, ok := err.(*Error)
+	if !ok || rpcErr.Code != -32601 {
+		t.Fatalf("expected JSON-RPC error, got %v", err)
+	}
+}
@@ -0,0 +1,118 @@
+// Package stats holds small numeric helpers shared by latency reports, usage
+// forecasts, and anomaly detection.
+package stats
+
+import (
+	"math"
+	"sort"
+)
+
+// Percentile returns the nearest-rank p-th percentile (0-100) of values.
+// values does not need to be sorted and is not modified. It returns 0 for an
+// empty slice.
+func Percentile(values []float64, p float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	return percentileSorted(sorted, p)
+}
+
+// Percentiles returns several percentiles of values with a single sort.
+func Percentiles(values []float64, ps ...float64) []float64 {
+	out := make([]float64, len(ps))
+	if len(values) == 0 {
+		return out
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	for i, p := range ps {
+		out[i] = percentileSorted(sorted, p)
+	}
+	return out
+}
+
+func percentileSorted(sorted []float64, p float64) float64 {
+	switch {
+	case p <= 0:
+		return sorted[0]
+	case p >= 100:
+		return sorted[len(sorted)-1]
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// Mean returns the arithmetic mean of values, or 0 for an empty slice.
+func Mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	total := 0.0
+	for _, v := range values {
+		total += v
+	}
+	return total / float64(len(values))
+}
+
+// LinearFit fits y = intercept + slope*x by least squares to values observed
+// at x = 0, 1, 2, .... Fewer than two values give a flat line at their mean.
+func LinearFit(values []float64) (intercept, slope float64) {
+	n := float64(len(values))
+	if len(values) < 2 {
+		return Mean(values), 0
+	}
+	meanX, meanY := (n-1)/2, Mean(values)
+	var sxy, sxx float64
+	for i, y := range values {
+		dx := float64(i) - meanX
+		sxy += dx * (y - meanY)
+		sxx += dx * dx
+	}
+	slope = sxy / sxx
+	return meanY - slope*meanX, slope
+}
+
+// Median returns the median of values, averaging the middle two of an even
+// count, or 0 for an empty slice. values is not modified.
+func Median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+// MedianAbsDeviation returns the median of values and the median absolute
+// deviation from it.
+func MedianAbsDeviation(values []float64) (median, mad float64) {
+	median = Median(values)
+	deviations := make([]float64, len(values))
+	for i, v := range values {
+		deviations[i] = math.Abs(v - median)
+	}
+	return median, Median(deviations)
+}
+
+// StdDev returns the sample standard deviation of values, or 0 for fewer
+// than two values.
+func StdDev(values []float64) float64 {
+	if len(values) < 2 {
+		return 0
+	}
+	mean := Mean(values)
+	sum := 0.0
+	for _, v := range values {
+		sum += (v - mean) * (v - mean)
+	}
+	return math.Sqrt(sum / float64(len(values)-1))
+}
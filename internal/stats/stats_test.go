@@ -0,0 +1,59 @@
+package stats
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPercentileNearestRank(t *testing.T) {
+	values := []float64{15, 20, 35, 40, 50}
+	cases := map[float64]float64{0: 15, 30: 20, 40: 20, 50: 35, 95: 50, 100: 50}
+	for p, want := range cases {
+		if got := Percentile(values, p); got != want {
+			t.Errorf("Percentile(%v) = %v, want %v", p, got, want)
+		}
+	}
+	if values[0] != 15 || values[4] != 50 {
+		t.Fatalf("input was modified: %v", values)
+	}
+	if got := Percentile(nil, 50); got != 0 {
+		t.Fatalf("expected 0 for empty input, got %v", got)
+	}
+}
+
+func TestPercentilesSharesSort(t *testing.T) {
+	got := Percentiles([]float64{5, 1, 4, 2, 3}, 50, 100)
+	if got[0] != 3 || got[1] != 5 {
+		t.Fatalf("unexpected percentiles: %v", got)
+	}
+}
+
+func TestLinearFit(t *testing.T) {
+	intercept, slope := LinearFit([]float64{3, 5, 7, 9})
+	if intercept != 3 || slope != 2 {
+		t.Fatalf("expected 3 + 2x, got %v + %vx", intercept, slope)
+	}
+	intercept, slope = LinearFit([]float64{4})
+	if intercept != 4 || slope != 0 {
+		t.Fatalf("expected a flat line for one value, got %v + %vx", intercept, slope)
+	}
+	if got := Mean(nil); got != 0 {
+		t.Fatalf("expected 0 mean for empty input, got %v", got)
+	}
+}
+
+func TestMedianAbsDeviationAndStdDev(t *testing.T) {
+	if got := Median([]float64{4, 1, 3, 2}); got != 2.5 {
+		t.Fatalf("expected 2.5, got %v", got)
+	}
+	median, mad := MedianAbsDeviation([]float64{1, 1, 2, 2, 4, 6, 9})
+	if median != 2 || mad != 1 {
+		t.Fatalf("expected median 2 and MAD 1, got %v and %v", median, mad)
+	}
+	if got := StdDev([]float64{2, 4, 4, 4, 5, 5, 7, 9}); math.Abs(got-2.138) > 0.001 {
+		t.Fatalf("expected sample std dev 2.138, got %v", got)
+	}
+	if got := StdDev([]float64{3}); got != 0 {
+		t.Fatalf("expected 0 for one value, got %v", got)
+	}
+}
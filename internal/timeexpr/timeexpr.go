@@ -0,0 +1,154 @@
+// Package timeexpr parses the absolute and relative time expressions accepted
+// by the --from, --to and --since flags.
+package timeexpr
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Reference is what relative expressions are resolved against.
+type Reference struct {
+	// Now is the current time.
+	Now time.Time
+	// Location interprets dates and times without a zone, and the day
+	// boundaries of today and yesterday. Nil means UTC.
+	Location *time.Location
+	// CycleStart returns the start of the billing cycle containing at. It is
+	// only called for this-cycle and last-cycle; nil disables them.
+	CycleStart func(at time.Time) (time.Time, error)
+}
+
+// Layouts of absolute times without a zone, read in the reference location.
+var localLayouts = []string{
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+var durationPattern = regexp.MustCompile(`^(\d+(?:\.\d+)?)\s*([a-z]+)$`)
+
+// durationUnits maps the unit names accepted on top of Go durations.
+var durationUnits = map[string]time.Duration{
+	"s": time.Second, "sec": time.Second, "secs": time.Second, "second": time.Second, "seconds": time.Second,
+	"m": time.Minute, "min": time.Minute, "mins": time.Minute, "minute": time.Minute, "minutes": time.Minute,
+	"h": time.Hour, "hr": time.Hour, "hrs": time.Hour, "hour": time.Hour, "hours": time.Hour,
+	"d": 24 * time.Hour, "day": 24 * time.Hour, "days": 24 * time.Hour,
+	"w": 7 * 24 * time.Hour, "week": 7 * 24 * time.Hour, "weeks": 7 * 24 * time.Hour,
+}
+
+// Parse resolves expr to an instant (in UTC). It accepts:
+//
+//   - RFC3339 timestamps (2026-02-27T00:00:00Z)
+//   - dates and times without a zone (2026-02-27, 2026-02-27 14:00)
+//   - now, today, yesterday
+//   - offsets into the past: -15m, -7d, 24h ago, 2 days ago
+//   - this-cycle and last-cycle: the start of the current or previous
+//     billing cycle
+func Parse(expr string, ref Reference) (time.Time, error) {
+	value := strings.ToLower(strings.Join(strings.Fields(expr), " "))
+	loc := ref.location()
+	switch strings.NewReplacer(" ", "-", "_", "-").Replace(value) {
+	case "":
+		return time.Time{}, fmt.Errorf("empty time")
+	case "now":
+		return ref.Now.UTC(), nil
+	case "today":
+		return startOfDay(ref.Now, loc).UTC(), nil
+	case "yesterday":
+		return startOfDay(ref.Now, loc).AddDate(0, 0, -1).UTC(), nil
+	case "this-cycle":
+		return ref.cycleStart(ref.Now)
+	case "last-cycle":
+		current, err := ref.cycleStart(ref.Now)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return ref.cycleStart(current.Add(-time.Nanosecond))
+	}
+
+	if offset, ok := strings.CutPrefix(value, "-"); ok {
+		d, err := ParseDuration(offset)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("unrecognized time %q", expr)
+		}
+		return ref.Now.Add(-d).UTC(), nil
+	}
+	if offset, ok := strings.CutSuffix(value, " ago"); ok {
+		d, err := ParseDuration(offset)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("unrecognized time %q", expr)
+		}
+		return ref.Now.Add(-d).UTC(), nil
+	}
+
+	trimmed := strings.TrimSpace(expr)
+	if parsed, err := time.Parse(time.RFC3339Nano, trimmed); err == nil {
+		return parsed.UTC(), nil
+	}
+	for _, layout := range localLayouts {
+		if parsed, err := time.ParseInLocation(layout, trimmed, loc); err == nil {
+			return parsed.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized time %q", expr)
+}
+
+// ParseSince is Parse for --since flags, where a bare duration (15m, 2d)
+// means that long ago.
+func ParseSince(expr string, ref Reference) (time.Time, error) {
+	if d, err := ParseDuration(strings.ToLower(strings.TrimSpace(expr))); err == nil {
+		return ref.Now.Add(-d).UTC(), nil
+	}
+	return Parse(expr, ref)
+}
+
+// ParseDuration parses a non-negative Go duration (1h30m) or a number with
+// a unit, including days and weeks (7d, 2 weeks).
+func ParseDuration(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if d, err := time.ParseDuration(value); err == nil && d >= 0 {
+		return d, nil
+	}
+	match := durationPattern.FindStringSubmatch(strings.ToLower(value))
+	if match == nil {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+	unit, ok := durationUnits[match[2]]
+	if !ok {
+		return 0, fmt.Errorf("invalid duration unit %q", match[2])
+	}
+	n, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+	return time.Duration(n * float64(unit)), nil
+}
+
+func (r Reference) location() *time.Location {
+	if r.Location == nil {
+		return time.UTC
+	}
+	return r.Location
+}
+
+func (r Reference) cycleStart(at time.Time) (time.Time, error) {
+	if r.CycleStart == nil {
+		return time.Time{}, fmt.Errorf("billing cycle is not available")
+	}
+	start, err := r.CycleStart(at)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("look up billing cycle: %w", err)
+	}
+	return start.UTC(), nil
+}
+
+func startOfDay(t time.Time, loc *time.Location) time.Time {
+	local := t.In(loc)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+}
@@ -0,0 +1,84 @@
+package timeexpr
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	stockholm, err := time.LoadLocation("Europe/Stockholm")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	now := time.Date(2026, 3, 15, 14, 30, 0, 0, time.UTC)
+	ref := Reference{
+		Now: now,
+		CycleStart: func(at time.Time) (time.Time, error) {
+			// Cycles start on the 10th of each month.
+			start := time.Date(at.Year(), at.Month(), 10, 0, 0, 0, 0, time.UTC)
+			if at.Before(start) {
+				start = start.AddDate(0, -1, 0)
+			}
+			return start, nil
+		},
+	}
+	local := ref
+	local.Location = stockholm
+
+	tests := []struct {
+		expr string
+		ref  Reference
+		want string
+	}{
+		{"now", ref, "2026-03-15T14:30:00Z"},
+		{"-15m", ref, "2026-03-15T14:15:00Z"},
+		{"-7d", ref, "2026-03-08T14:30:00Z"},
+		{"24h ago", ref, "2026-03-14T14:30:00Z"},
+		{"2 days ago", ref, "2026-03-13T14:30:00Z"},
+		{"1 week ago", ref, "2026-03-08T14:30:00Z"},
+		{"today", ref, "2026-03-15T00:00:00Z"},
+		{"Yesterday", ref, "2026-03-14T00:00:00Z"},
+		{"today", local, "2026-03-14T23:00:00Z"},
+		{"this-cycle", ref, "2026-03-10T00:00:00Z"},
+		{"last cycle", ref, "2026-02-10T00:00:00Z"},
+		{"2026-02-27", ref, "2026-02-27T00:00:00Z"},
+		{"2026-02-27", local, "2026-02-26T23:00:00Z"},
+		{"2026-02-27 09:30", ref, "2026-02-27T09:30:00Z"},
+		{"2026-02-27T10:00:00+02:00", local, "2026-02-27T08:00:00Z"},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.expr, tt.ref)
+		if err != nil || got.Format(time.RFC3339) != tt.want {
+			t.Errorf("Parse(%q) = %s, %v; want %s", tt.expr, got.Format(time.RFC3339), err, tt.want)
+		}
+	}
+
+	for _, expr := range []string{"", "soon", "-15x", "15m", "2026-13-01", "3 fortnights ago"} {
+		if _, err := Parse(expr, ref); err == nil {
+			t.Errorf("Parse(%q): expected an error", expr)
+		}
+	}
+	if _, err := Parse("this-cycle", Reference{Now: now}); err == nil {
+		t.Error("expected an error for this-cycle without a billing cycle")
+	}
+	failing := Reference{Now: now, CycleStart: func(time.Time) (time.Time, error) { return time.Time{}, errors.New("offline") }}
+	if _, err := Parse("last-cycle", failing); err == nil {
+		t.Error("expected the cycle lookup error")
+	}
+}
+
+func TestParseSince(t *testing.T) {
+	ref := Reference{Now: time.Date(2026, 3, 15, 14, 30, 0, 0, time.UTC)}
+	for expr, want := range map[string]string{
+		"15m":   "2026-03-15T14:15:00Z",
+		"2d":    "2026-03-13T14:30:00Z",
+		"today": "2026-03-15T00:00:00Z",
+		"-1h":   "2026-03-15T13:30:00Z",
+	} {
+		got, err := ParseSince(expr, ref)
+		if err != nil || got.Format(time.RFC3339) != want {
+			t.Errorf("ParseSince(%q) = %s, %v; want %s", expr, got.Format(time.RFC3339), err, want)
+		}
+	}
+}
@@ -81,3 +81,113 @@ func TestEndpointsHelpShowsFilters(t *testing.T) {
 		t.Fatalf("expected endpoints help to mention filter flags, got:\n%s", res.stdout)
 	}
 }
+
+func TestEndpointsOfflineUsesCachedCatalog(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v3/chains" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`[{"id": 1, "name": "Ethereum", "ecosystem": "evm", "networks": [
+  {"id": 1, "name": "Mainnet", "nodes": [{"id": 1, "https": "https://mainnet.example", "wss": "", "node_type": {"name": "full"}}]}
+]}]`))
+	}))
+	env := map[string]string{
+		"DWELLIR_TOKEN":   "test-token",
+		"DWELLIR_API_URL": server.URL,
+	}
+	configDir := t.TempDir()
+
+	res := runCLIWithConfigDirAndEnv(t, configDir, env, "endpoints", "get", "ethereum", "--offline", "--json")
+	if res.exitCode == 0 || !strings.Contains(res.stdout, `"not_cached"`) {
+		t.Fatalf("expected not_cached before the first fetch, got exit %d\nstdout: %s", res.exitCode, res.stdout)
+	}
+
+	res = runCLIWithConfigDirAndEnv(t, configDir, env, "endpoints", "list", "--json")
+	if res.exitCode != 0 {
+		t.Fatalf("expected success exit code, got %d\nstderr: %s\nstdout: %s", res.exitCode, res.stderr, res.stdout)
+	}
+	if meta, _ := parseJSON(t, res.stdout)["meta"].(map[string]interface{}); meta["cache"] != nil {
+		t.Fatalf("expected no cache meta for a network fetch, got %v", meta["cache"])
+	}
+
+	server.Close()
+	res = runCLIWithConfigDirAndEnv(t, configDir, env, "endpoints", "get", "ethereum", "--offline", "--json")
+	if res.exitCode != 0 {
+		t.Fatalf("expected offline success, got %d\nstderr: %s\nstdout: %s", res.exitCode, res.stderr, res.stdout)
+	}
+	meta, _ := parseJSON(t, res.stdout)["meta"].(map[string]interface{})
+	cache, _ := meta["cache"].(map[string]interface{})
+	if cache["source"] != "cache" || cache["fetched_at"] == "" {
+		t.Fatalf("expected cache meta in envelope, got %v", meta)
+	}
+}
+
+func TestEndpointsGetSuggestsSimilarChains(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[
+  {"id": 1, "name": "Ethereum", "ecosystem": "evm", "networks": [{"id": 1, "name": "Mainnet", "nodes": [{"id": 1, "https": "https://eth.example", "node_type": {"name": "full"}}]}]},
+  {"id": 2, "name": "Base", "ecosystem": "evm", "networks": [{"id": 2, "name": "Base Mainnet", "nodes": [{"id": 2, "https": "https://base.example", "node_type": {"name": "full"}}]}]}
+]`))
+	}))
+	defer server.Close()
+
+	res := runCLIWithEnv(t, map[string]string{
+		"DWELLIR_TOKEN":   "test-token",
+		"DWELLIR_API_URL": server.URL,
+	}, "endpoints", "get", "etherum", "--json")
+	if res.exitCode == 0 {
+		t.Fatalf("expected failure exit code\nstdout: %s", res.stdout)
+	}
+	errBody, _ := parseJSON(t, res.stdout)["error"].(map[string]interface{})
+	details, _ := errBody["details"].(map[string]interface{})
+	suggestions, _ := details["suggestions"].([]interface{})
+	if errBody["code"] != "not_found" || len(suggestions) != 1 || suggestions[0] != "ethereum" {
+		t.Fatalf("expected not_found with an ethereum suggestion, got %v", errBody)
+	}
+	if help, _ := errBody["help"].(string); !strings.Contains(help, "Did you mean: ethereum?") {
+		t.Fatalf("expected did-you-mean help, got %q", help)
+	}
+}
+
+func TestEndpointsDiffReportsCatalogChanges(t *testing.T) {
+	catalog := `[{"id": 1, "name": "Ethereum", "ecosystem": "evm", "networks": [{"id": 1, "name": "Mainnet", "nodes": [{"id": 1, "https": "https://eth.example", "node_type": {"name": "full"}}]}]}]`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v3/chains" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(catalog))
+	}))
+	defer server.Close()
+	env := map[string]string{
+		"DWELLIR_TOKEN":   "test-token",
+		"DWELLIR_API_URL": server.URL,
+	}
+	configDir := t.TempDir()
+
+	res := runCLIWithConfigDirAndEnv(t, configDir, env, "endpoints", "diff", "--exit-code", "--json")
+	if res.exitCode != 0 {
+		t.Fatalf("expected baseline run to succeed, got %d\nstdout: %s", res.exitCode, res.stdout)
+	}
+	if data, _ := parseJSON(t, res.stdout)["data"].(map[string]interface{}); data["baseline"] != true {
+		t.Fatalf("expected baseline diff, got %v", data)
+	}
+
+	catalog = `[{"id": 1, "name": "Ethereum", "ecosystem": "evm", "networks": [{"id": 1, "name": "Mainnet", "nodes": [{"id": 1, "https": "https://eth.example", "node_type": {"name": "archive"}}]}]},
+  {"id": 2, "name": "Base", "ecosystem": "evm", "networks": []}]`
+	res = runCLIWithConfigDirAndEnv(t, configDir, env, "endpoints", "diff", "--exit-code", "--json")
+	if res.exitCode == 0 {
+		t.Fatalf("expected --exit-code to fail on changes\nstdout: %s", res.stdout)
+	}
+	errBody, _ := parseJSON(t, res.stdout)["error"].(map[string]interface{})
+	details, _ := errBody["details"].(map[string]interface{})
+	if errBody["code"] != "catalog_changed" || details["added"] != float64(1) || details["changed"] != float64(1) {
+		t.Fatalf("unexpected catalog_changed error: %v", errBody)
+	}
+
+	res = runCLIWithConfigDirAndEnv(t, configDir, env, "endpoints", "diff", "--exit-code", "--json")
+	if res.exitCode != 0 {
+		t.Fatalf("expected no changes after the snapshot was updated, got %d\nstdout: %s", res.exitCode, res.stdout)
+	}
+}